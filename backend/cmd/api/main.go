@@ -3,13 +3,19 @@ package main
 import (
 	"context"
 	"log"
+	"time"
 
 	"github.com/dcunha/finance/backend/internal/config"
 	"github.com/dcunha/finance/backend/internal/domain/usecase"
 	"github.com/dcunha/finance/backend/internal/infrastructure/database"
 	"github.com/dcunha/finance/backend/internal/infrastructure/email"
+	"github.com/dcunha/finance/backend/internal/infrastructure/exchangerate"
 	"github.com/dcunha/finance/backend/internal/infrastructure/http/handler"
 	"github.com/dcunha/finance/backend/internal/infrastructure/http/router"
+	"github.com/dcunha/finance/backend/internal/infrastructure/oidc"
+	"github.com/dcunha/finance/backend/internal/infrastructure/ratelimit"
+	"github.com/dcunha/finance/backend/internal/infrastructure/scheduler"
+	"github.com/dcunha/finance/backend/internal/infrastructure/storage"
 	"github.com/gin-gonic/gin"
 )
 
@@ -43,28 +49,88 @@ func main() {
 	// Email sender
 	emailSender := email.NewSender(cfg.SendGridAPIKey, cfg.EmailFrom)
 
+	// Attachment storage
+	attachmentStore, err := storage.NewLocalStorage(cfg.AttachmentsDir)
+	if err != nil {
+		log.Fatalf("Failed to initialize attachment storage: %v", err)
+	}
+
+	// Exchange rate provider (nil when rates are entered by hand only)
+	rateProvider, err := exchangerate.NewProvider(cfg.ExchangeRateProvider)
+	if err != nil {
+		log.Fatalf("Failed to initialize exchange rate provider: %v", err)
+	}
+
+	// Rate limits for logins and second-factor codes
+	rateLimitStore, err := ratelimit.NewStore(cfg.RateLimitStore, pool)
+	if err != nil {
+		log.Fatalf("Failed to initialize rate limit store: %v", err)
+	}
+	limiter := ratelimit.NewLimiter(rateLimitStore)
+
+	// Sign-in with Google or another OpenID Connect provider (nil when not configured)
+	var oidcProvider *oidc.Provider
+	if cfg.OIDCClientID != "" {
+		oidcProvider = oidc.NewProvider(oidc.Config{
+			Issuer:       cfg.OIDCIssuer,
+			ClientID:     cfg.OIDCClientID,
+			ClientSecret: cfg.OIDCClientSecret,
+			RedirectURL:  cfg.OIDCRedirectURL,
+		})
+	}
+
 	// Repositories
 	tenantRepo := database.NewTenantRepo(pool)
 	userRepo := database.NewUserRepo()
 	categoryRepo := database.NewCategoryRepo()
+	accountRepo := database.NewAccountRepo()
+	creditCardRepo := database.NewCreditCardRepo()
 	transactionRepo := database.NewTransactionRepo()
 	expenseLimitRepo := database.NewExpenseLimitRepo()
 	recurringRepo := database.NewRecurringTransactionRepo()
+	importProfileRepo := database.NewImportProfileRepo()
+	ruleRepo := database.NewCategorizationRuleRepo()
+	tagRepo := database.NewTagRepo()
+	attachmentRepo := database.NewAttachmentRepo()
+	exchangeRateRepo := database.NewExchangeRateRepo()
+	settingsRepo := database.NewSettingsRepo()
+	goalRepo := database.NewGoalRepo()
+	budgetRepo := database.NewBudgetRepo()
+	limitAlertRepo := database.NewLimitAlertRepo()
+	reportRepo := database.NewReportRepo()
 	globalUserRepo := database.NewGlobalUserRepo(pool)
 	membershipRepo := database.NewMembershipRepo(pool)
 	inviteRepo := database.NewInviteRepo(pool)
+	sessionRepo := database.NewSessionRepo(pool)
+	recoveryCodeRepo := database.NewRecoveryCodeRepo(pool)
+	oidcIdentityRepo := database.NewOIDCIdentityRepo(pool)
 
 	// Usecases
 	healthUc := usecase.NewHealthUsecase(pool)
-	authUC := usecase.NewAuthUsecase(userRepo, globalUserRepo, membershipRepo, cfg.JWTSecret)
-	adminUC := usecase.NewAdminUsecase(userRepo)
+	twoFactorUC := usecase.NewTwoFactorUsecase(globalUserRepo, recoveryCodeRepo, membershipRepo, tenantRepo, sessionRepo, tenantCache, limiter)
+	authUC := usecase.NewAuthUsecase(userRepo, globalUserRepo, membershipRepo, sessionRepo, oidcIdentityRepo, twoFactorUC, cfg.JWTSecret)
+	adminUC := usecase.NewAdminUsecase(userRepo, sessionRepo)
 	categoryUC := usecase.NewCategoryUsecase(categoryRepo)
-	transactionUC := usecase.NewTransactionUsecase(transactionRepo)
+	accountUC := usecase.NewAccountUsecase(accountRepo)
+	dashboardUC := usecase.NewDashboardUsecase(transactionRepo, reportRepo, expenseLimitRepo, recurringRepo, tagRepo, goalRepo, budgetRepo, settingsRepo, exchangeRateRepo)
+	reportUC := usecase.NewReportUsecase(reportRepo, transactionRepo, recurringRepo, settingsRepo, exchangeRateRepo)
+	limitAlertUC := usecase.NewLimitAlertUsecase(pool, dashboardUC, limitAlertRepo, userRepo, settingsRepo, emailSender, cfg.AppURL)
+	transactionUC := usecase.NewTransactionUsecase(transactionRepo, recurringRepo, accountRepo, creditCardRepo, ruleRepo, attachmentRepo, attachmentStore, limitAlertUC)
+	transferUC := usecase.NewTransferUsecase(transactionRepo, accountRepo)
+	attachmentUC := usecase.NewAttachmentUsecase(attachmentRepo, transactionRepo, attachmentStore)
 	expenseLimitUC := usecase.NewExpenseLimitUsecase(expenseLimitRepo)
-	dashboardUC := usecase.NewDashboardUsecase(transactionRepo, expenseLimitRepo)
-	recurringUC := usecase.NewRecurringTransactionUsecase(recurringRepo, transactionRepo)
+	goalUC := usecase.NewGoalUsecase(goalRepo, transactionRepo)
+	budgetUC := usecase.NewBudgetUsecase(budgetRepo)
+	recurringUC := usecase.NewRecurringTransactionUsecase(recurringRepo, transactionRepo, accountRepo, creditCardRepo, cfg.RecurringHorizonMonths)
+	creditCardUC := usecase.NewCreditCardUsecase(creditCardRepo, accountRepo, transactionRepo, transactionUC, recurringUC, transferUC)
+	importUC := usecase.NewImportUsecase(transactionRepo, accountRepo, creditCardRepo, ruleRepo, limitAlertUC)
+	importProfileUC := usecase.NewImportProfileUsecase(importProfileRepo)
+	ruleUC := usecase.NewCategorizationRuleUsecase(ruleRepo, categoryRepo, accountRepo, transactionRepo)
+	tagUC := usecase.NewTagUsecase(tagRepo)
+	exchangeRateUC := usecase.NewExchangeRateUsecase(exchangeRateRepo, settingsRepo, rateProvider)
+	settingsUC := usecase.NewSettingsUsecase(settingsRepo)
 	registrationUC := usecase.NewRegistrationUsecase(
-		globalUserRepo, membershipRepo, tenantRepo, userRepo,
+		globalUserRepo, membershipRepo, tenantRepo, userRepo, sessionRepo,
 		sm, tenantCache, pool, emailSender,
 		cfg.AppURL, cfg.DatabaseURL, "tenant_migrations",
 	)
@@ -73,24 +139,58 @@ func main() {
 		registrationUC, tenantCache, emailSender, cfg.AppURL,
 	)
 
+	// Count failed logins only for existing accounts, and email the owner
+	// the first time one gets locked
+	limiter.AccountExists = registrationUC.AccountExists
+	limiter.OnLockout = func(emailAddr string, until time.Time) {
+		go func() {
+			if err := registrationUC.SendLockoutNotice(context.Background(), emailAddr, until); err != nil {
+				log.Printf("Failed to send lockout notice to %s: %v", emailAddr, err)
+			}
+		}()
+	}
+
+	// Background jobs
+	ratelimit.StartCleanup(ctx, rateLimitStore, time.Hour)
+	scheduler.NewRecurringHorizonJob(pool, tenantCache, recurringUC, 24*time.Hour).Start(ctx)
+	if rateProvider != nil {
+		scheduler.NewExchangeRateJob(pool, tenantCache, exchangeRateUC, 24*time.Hour).Start(ctx)
+	}
+
 	// Handlers
 	handlers := router.Handlers{
-		Health:       handler.NewHealthHandler(healthUc),
-		Auth:         handler.NewAuthHandler(authUC, pool, tenantCache),
-		Registration: handler.NewRegistrationHandler(registrationUC),
-		Invite:       handler.NewInviteHandler(inviteUC),
-		Admin:        handler.NewAdminHandler(adminUC),
-		Category:     handler.NewCategoryHandler(categoryUC),
-		Transaction:  handler.NewTransactionHandler(transactionUC),
-		ExpenseLimit: handler.NewExpenseLimitHandler(expenseLimitUC),
-		Dashboard:    handler.NewDashboardHandler(dashboardUC),
-		Recurring:    handler.NewRecurringTransactionHandler(recurringUC),
+		Health:        handler.NewHealthHandler(healthUc),
+		Auth:          handler.NewAuthHandler(authUC, pool, tenantCache),
+		TwoFactor:     handler.NewTwoFactorHandler(twoFactorUC),
+		OIDC:          handler.NewOIDCHandler(authUC, oidcProvider, cfg.AppURL),
+		Registration:  handler.NewRegistrationHandler(registrationUC),
+		Invite:        handler.NewInviteHandler(inviteUC),
+		Admin:         handler.NewAdminHandler(adminUC),
+		Category:      handler.NewCategoryHandler(categoryUC),
+		Account:       handler.NewAccountHandler(accountUC),
+		CreditCard:    handler.NewCreditCardHandler(creditCardUC),
+		Transaction:   handler.NewTransactionHandler(transactionUC),
+		Transfer:      handler.NewTransferHandler(transferUC),
+		Attachment:    handler.NewAttachmentHandler(attachmentUC),
+		Export:        handler.NewExportHandler(transactionUC, accountUC),
+		Import:        handler.NewImportHandler(importUC, importProfileUC),
+		ImportProfile: handler.NewImportProfileHandler(importProfileUC),
+		Rule:          handler.NewCategorizationRuleHandler(ruleUC),
+		Tag:           handler.NewTagHandler(tagUC),
+		ExchangeRate:  handler.NewExchangeRateHandler(exchangeRateUC),
+		Settings:      handler.NewSettingsHandler(settingsUC),
+		ExpenseLimit:  handler.NewExpenseLimitHandler(expenseLimitUC),
+		Budget:        handler.NewBudgetHandler(budgetUC),
+		Goal:          handler.NewGoalHandler(goalUC),
+		Dashboard:     handler.NewDashboardHandler(dashboardUC),
+		Report:        handler.NewReportHandler(reportUC),
+		Recurring:     handler.NewRecurringTransactionHandler(recurringUC),
 	}
 
 	// Router
 	r := gin.Default()
 	r.TrustedPlatform = gin.PlatformCloudflare
-	router.Setup(r, cfg.JWTSecret, cfg.StaticDir, cfg.AllowedOrigin, pool, tenantCache, handlers)
+	router.Setup(r, cfg.JWTSecret, cfg.StaticDir, cfg.AllowedOrigin, pool, tenantCache, sessionRepo, limiter, handlers)
 
 	log.Printf("Server starting on :%s", cfg.Port)
 	if err := r.Run(":" + cfg.Port); err != nil {
@@ -0,0 +1,261 @@
+// Command mockoidc is a minimal OpenID Connect provider for trying the
+// "Entrar com Google" flow locally, without a Google project. Its login page
+// asks for any name and email and signs the ID token with a key generated at
+// startup. Point the API at it with
+//
+//	OIDC_ISSUER=http://localhost:9000 OIDC_CLIENT_ID=finance-dev OIDC_CLIENT_SECRET=finance-dev
+//
+// It keeps everything in memory and checks only what the API relies on
+// (client credentials, redirect URI and PKCE). Never expose it.
+package main
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"html/template"
+	"log"
+	"math/big"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const keyID = "mockoidc"
+
+type authorization struct {
+	clientID      string
+	redirectURI   string
+	nonce         string
+	codeChallenge string
+	email         string
+	name          string
+	emailVerified bool
+	expiresAt     time.Time
+}
+
+type server struct {
+	issuer       string
+	clientID     string
+	clientSecret string
+	key          *rsa.PrivateKey
+
+	mu    sync.Mutex
+	codes map[string]authorization
+}
+
+func main() {
+	addr := flag.String("addr", ":9000", "listen address")
+	issuer := flag.String("issuer", "http://localhost:9000", "issuer URL, as the API reaches it")
+	clientID := flag.String("client-id", "finance-dev", "accepted client id")
+	clientSecret := flag.String("client-secret", "finance-dev", "accepted client secret")
+	flag.Parse()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		log.Fatalf("Failed to generate signing key: %v", err)
+	}
+	s := &server{
+		issuer:       *issuer,
+		clientID:     *clientID,
+		clientSecret: *clientSecret,
+		key:          key,
+		codes:        make(map[string]authorization),
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /.well-known/openid-configuration", s.discovery)
+	mux.HandleFunc("GET /jwks", s.jwks)
+	mux.HandleFunc("GET /authorize", s.authorizeForm)
+	mux.HandleFunc("POST /authorize", s.authorize)
+	mux.HandleFunc("POST /token", s.token)
+
+	log.Printf("Mock OIDC provider at %s (client %q)", s.issuer, s.clientID)
+	log.Fatal(http.ListenAndServe(*addr, mux))
+}
+
+func (s *server) discovery(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]any{
+		"issuer":                                s.issuer,
+		"authorization_endpoint":                s.issuer + "/authorize",
+		"token_endpoint":                        s.issuer + "/token",
+		"jwks_uri":                              s.issuer + "/jwks",
+		"response_types_supported":              []string{"code"},
+		"subject_types_supported":               []string{"public"},
+		"id_token_signing_alg_values_supported": []string{"RS256"},
+		"code_challenge_methods_supported":      []string{"S256"},
+	})
+}
+
+func (s *server) jwks(w http.ResponseWriter, r *http.Request) {
+	pub := s.key.PublicKey
+	writeJSON(w, http.StatusOK, map[string]any{
+		"keys": []map[string]string{{
+			"kid": keyID,
+			"kty": "RSA",
+			"use": "sig",
+			"alg": "RS256",
+			"n":   base64.RawURLEncoding.EncodeToString(pub.N.Bytes()),
+			"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(pub.E)).Bytes()),
+		}},
+	})
+}
+
+var formTemplate = template.Must(template.New("form").Parse(`<!DOCTYPE html>
+<html>
+<body style="font-family: sans-serif; max-width: 400px; margin: 40px auto;">
+  <h2>Mock OIDC</h2>
+  <form method="post">
+    {{range $k, $v := .Params}}<input type="hidden" name="{{$k}}" value="{{index $v 0}}">{{end}}
+    <p><label>Nome<br><input name="name" value="Maria Silva" style="width: 100%"></label></p>
+    <p><label>Email<br><input name="email" type="email" value="maria@example.com" required style="width: 100%"></label></p>
+    <p><label><input name="email_verified" type="checkbox" checked> Email verificado</label></p>
+    <button type="submit">Entrar</button>
+    <button type="submit" name="deny" value="1" formnovalidate>Cancelar</button>
+  </form>
+</body>
+</html>`))
+
+func (s *server) authorizeForm(w http.ResponseWriter, r *http.Request) {
+	if err := s.checkAuthorizeParams(r.URL.Query()); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	formTemplate.Execute(w, map[string]any{"Params": r.URL.Query()})
+}
+
+func (s *server) authorize(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	params := r.PostForm
+	if err := s.checkAuthorizeParams(params); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	back, _ := url.Parse(params.Get("redirect_uri"))
+	query := back.Query()
+	query.Set("state", params.Get("state"))
+	if params.Get("deny") != "" {
+		query.Set("error", "access_denied")
+	} else {
+		code := randomString()
+		s.mu.Lock()
+		s.codes[code] = authorization{
+			clientID:      params.Get("client_id"),
+			redirectURI:   params.Get("redirect_uri"),
+			nonce:         params.Get("nonce"),
+			codeChallenge: params.Get("code_challenge"),
+			email:         params.Get("email"),
+			name:          params.Get("name"),
+			emailVerified: params.Get("email_verified") != "",
+			expiresAt:     time.Now().Add(time.Minute),
+		}
+		s.mu.Unlock()
+		query.Set("code", code)
+	}
+	back.RawQuery = query.Encode()
+	http.Redirect(w, r, back.String(), http.StatusFound)
+}
+
+func (s *server) checkAuthorizeParams(params url.Values) error {
+	switch {
+	case params.Get("response_type") != "code":
+		return fmt.Errorf("response_type must be code")
+	case params.Get("client_id") != s.clientID:
+		return fmt.Errorf("unknown client_id")
+	case params.Get("redirect_uri") == "":
+		return fmt.Errorf("missing redirect_uri")
+	case params.Get("code_challenge_method") != "S256" || params.Get("code_challenge") == "":
+		return fmt.Errorf("PKCE with S256 is required")
+	}
+	return nil
+}
+
+func (s *server) token(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		tokenError(w, "invalid_request", err.Error())
+		return
+	}
+	clientID, clientSecret, ok := r.BasicAuth()
+	if !ok {
+		clientID, clientSecret = r.PostForm.Get("client_id"), r.PostForm.Get("client_secret")
+	}
+	if clientID != s.clientID || clientSecret != s.clientSecret {
+		writeJSON(w, http.StatusUnauthorized, map[string]string{"error": "invalid_client"})
+		return
+	}
+	if r.PostForm.Get("grant_type") != "authorization_code" {
+		tokenError(w, "unsupported_grant_type", "")
+		return
+	}
+
+	code := r.PostForm.Get("code")
+	s.mu.Lock()
+	auth, found := s.codes[code]
+	delete(s.codes, code)
+	s.mu.Unlock()
+	sum := sha256.Sum256([]byte(r.PostForm.Get("code_verifier")))
+	switch {
+	case !found || time.Now().After(auth.expiresAt) || auth.clientID != clientID:
+		tokenError(w, "invalid_grant", "unknown or expired code")
+		return
+	case auth.redirectURI != r.PostForm.Get("redirect_uri"):
+		tokenError(w, "invalid_grant", "redirect_uri does not match")
+		return
+	case base64.RawURLEncoding.EncodeToString(sum[:]) != auth.codeChallenge:
+		tokenError(w, "invalid_grant", "code_verifier does not match")
+		return
+	}
+
+	now := time.Now()
+	idToken := jwt.NewWithClaims(jwt.SigningMethodRS256, jwt.MapClaims{
+		"iss":            s.issuer,
+		"sub":            "mock-" + auth.email,
+		"aud":            s.clientID,
+		"iat":            now.Unix(),
+		"exp":            now.Add(time.Hour).Unix(),
+		"nonce":          auth.nonce,
+		"email":          auth.email,
+		"email_verified": auth.emailVerified,
+		"name":           auth.name,
+	})
+	idToken.Header["kid"] = keyID
+	signed, err := idToken.SignedString(s.key)
+	if err != nil {
+		tokenError(w, "server_error", err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]any{
+		"access_token": randomString(),
+		"token_type":   "Bearer",
+		"expires_in":   3600,
+		"id_token":     signed,
+	})
+}
+
+func tokenError(w http.ResponseWriter, code, description string) {
+	writeJSON(w, http.StatusBadRequest, map[string]string{"error": code, "error_description": description})
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+func randomString() string {
+	b := make([]byte, 24)
+	rand.Read(b)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
@@ -2,6 +2,8 @@ package config
 
 import (
 	"os"
+	"strconv"
+	"strings"
 
 	"github.com/joho/godotenv"
 )
@@ -15,6 +17,22 @@ type Config struct {
 	AppURL         string
 	SendGridAPIKey string
 	EmailFrom      string
+	// How many months ahead recurring occurrences are stored as transactions.
+	RecurringHorizonMonths int
+	// Directory where transaction attachments are stored.
+	AttachmentsDir string
+	// Source of fetched exchange rates ("ptax"); empty means manual rates only.
+	ExchangeRateProvider string
+	// Where login rate limit counters live: "memory" (one instance) or
+	// "postgres" (shared by every instance).
+	RateLimitStore string
+	// Sign-in with an OpenID Connect provider, enabled by OIDCClientID. The
+	// issuer defaults to Google and the redirect URL to the API callback
+	// under AppURL.
+	OIDCIssuer       string
+	OIDCClientID     string
+	OIDCClientSecret string
+	OIDCRedirectURL  string
 }
 
 func Load() *Config {
@@ -29,6 +47,7 @@ func Load() *Config {
 		AppURL:         os.Getenv("APP_URL"),
 		SendGridAPIKey: os.Getenv("SENDGRID_API_KEY"),
 		EmailFrom:      os.Getenv("EMAIL_FROM"),
+		AttachmentsDir: os.Getenv("ATTACHMENTS_DIR"),
 	}
 
 	if cfg.Port == "" {
@@ -37,6 +56,32 @@ func Load() *Config {
 	if cfg.AppURL == "" {
 		cfg.AppURL = "http://localhost:5173"
 	}
+	if cfg.AttachmentsDir == "" {
+		cfg.AttachmentsDir = "data/attachments"
+	}
+
+	cfg.ExchangeRateProvider = os.Getenv("EXCHANGE_RATE_PROVIDER")
+
+	cfg.RateLimitStore = os.Getenv("RATE_LIMIT_STORE")
+	if cfg.RateLimitStore == "" {
+		cfg.RateLimitStore = "memory"
+	}
+
+	cfg.RecurringHorizonMonths, _ = strconv.Atoi(os.Getenv("RECURRING_HORIZON_MONTHS"))
+	if cfg.RecurringHorizonMonths <= 0 {
+		cfg.RecurringHorizonMonths = 3
+	}
+
+	cfg.OIDCClientID = os.Getenv("OIDC_CLIENT_ID")
+	cfg.OIDCClientSecret = os.Getenv("OIDC_CLIENT_SECRET")
+	cfg.OIDCIssuer = os.Getenv("OIDC_ISSUER")
+	if cfg.OIDCIssuer == "" {
+		cfg.OIDCIssuer = "https://accounts.google.com"
+	}
+	cfg.OIDCRedirectURL = os.Getenv("OIDC_REDIRECT_URL")
+	if cfg.OIDCRedirectURL == "" {
+		cfg.OIDCRedirectURL = strings.TrimSuffix(cfg.AppURL, "/") + "/api/v1/auth/oidc/callback"
+	}
 
 	return cfg
 }
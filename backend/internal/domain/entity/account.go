@@ -0,0 +1,20 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Account struct {
+	ID             uuid.UUID  `json:"id"`
+	UserID         *uuid.UUID `json:"user_id,omitempty"`
+	Name           string     `json:"name"`
+	Type           string     `json:"type"`
+	Currency       string     `json:"currency"`
+	InitialBalance Money      `json:"initial_balance"`
+	Balance        Money      `json:"balance"`
+	IsDefault      bool       `json:"is_default"`
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+}
@@ -0,0 +1,20 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Attachment is a file, such as a receipt photo or a boleto PDF, attached to a
+// transaction. StorageKey locates the content in the attachment storage.
+type Attachment struct {
+	ID            uuid.UUID  `json:"id"`
+	TransactionID uuid.UUID  `json:"transaction_id"`
+	UserID        *uuid.UUID `json:"user_id,omitempty"`
+	FileName      string     `json:"file_name"`
+	ContentType   string     `json:"content_type"`
+	Size          int64      `json:"size"`
+	StorageKey    string     `json:"-"`
+	CreatedAt     time.Time  `json:"created_at"`
+}
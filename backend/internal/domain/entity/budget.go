@@ -0,0 +1,40 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Budget is a spending limit that repeats every period (weekly, monthly,
+// quarterly or yearly) from StartDate until EndDate, if set. It has either a
+// fixed Amount or an IncomePercentage of the income received in the same
+// period, from IncomeCategoryID or from all income. With Rollover, what is
+// left unspent in a period is added to the next one. Amounts are in the base
+// currency. AlertThresholds work as in ExpenseLimit, once per period.
+type Budget struct {
+	ID                 uuid.UUID  `json:"id"`
+	UserID             uuid.UUID  `json:"user_id"`
+	CategoryID         *uuid.UUID `json:"category_id"`
+	CategoryName       string     `json:"category_name,omitempty"`
+	Period             string     `json:"period"`
+	Amount             *Money     `json:"amount,omitempty"`
+	IncomePercentage   *float64   `json:"income_percentage,omitempty"`
+	IncomeCategoryID   *uuid.UUID `json:"income_category_id,omitempty"`
+	IncomeCategoryName string     `json:"income_category_name,omitempty"`
+	Rollover           bool       `json:"rollover"`
+	StartDate          string     `json:"start_date"`
+	EndDate            *string    `json:"end_date,omitempty"`
+	AlertThresholds    []int      `json:"alert_thresholds"`
+	CreatedAt          time.Time  `json:"created_at"`
+	UpdatedAt          time.Time  `json:"updated_at"`
+}
+
+// BudgetPeriod is one period of a budget with what was spent in its category
+// and the income it is based on, both in the base currency.
+type BudgetPeriod struct {
+	Start  string
+	End    string
+	Spent  Money
+	Income Money
+}
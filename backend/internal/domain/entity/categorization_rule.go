@@ -0,0 +1,31 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// CategorizationRule assigns CategoryID to transactions matching all of its
+// conditions; empty conditions match anything. DescriptionContains ignores
+// case and accents. Rules run by ascending Priority and the first match wins.
+type CategorizationRule struct {
+	ID                  uuid.UUID  `json:"id"`
+	UserID              *uuid.UUID `json:"user_id,omitempty"`
+	Name                string     `json:"name"`
+	Priority            int        `json:"priority"`
+	DescriptionContains string     `json:"description_contains,omitempty"`
+	Type                string     `json:"type,omitempty"`
+	MinAmount           *Money     `json:"min_amount,omitempty"`
+	MaxAmount           *Money     `json:"max_amount,omitempty"`
+	AccountID           *uuid.UUID `json:"account_id,omitempty"`
+	CategoryID          uuid.UUID  `json:"category_id"`
+	CategoryName        string     `json:"category_name,omitempty"`
+	IsActive            bool       `json:"is_active"`
+	CreatedAt           time.Time  `json:"created_at"`
+	UpdatedAt           time.Time  `json:"updated_at"`
+}
+
+type RuleApplyResult struct {
+	Updated int `json:"updated"`
+}
@@ -0,0 +1,46 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	StatementStatusOpen    = "open"
+	StatementStatusClosed  = "closed"
+	StatementStatusPaid    = "paid"
+	StatementStatusOverdue = "overdue"
+)
+
+// CreditCard is backed by an account of type "credit_card". Purchases made on
+// that account are assigned to a statement by the card's closing day.
+type CreditCard struct {
+	ID          uuid.UUID `json:"id"`
+	AccountID   uuid.UUID `json:"account_id"`
+	Name        string    `json:"name"`
+	ClosingDay  int       `json:"closing_day"`
+	DueDay      int       `json:"due_day"`
+	CreditLimit *Money    `json:"credit_limit"`
+	Balance     Money     `json:"balance"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// CreditCardStatement is a card bill (fatura). Month and Year are those of the
+// due date; purchases made before ClosingDate and on or after the previous
+// statement's closing date belong to it.
+type CreditCardStatement struct {
+	ID           uuid.UUID  `json:"id"`
+	CreditCardID uuid.UUID  `json:"credit_card_id"`
+	Month        int        `json:"month"`
+	Year         int        `json:"year"`
+	ClosingDate  string     `json:"closing_date"`
+	DueDate      string     `json:"due_date"`
+	Total        Money      `json:"total"`
+	PaidAmount   Money      `json:"paid_amount"`
+	PaidAt       *time.Time `json:"paid_at"`
+	Status       string     `json:"status"`
+	CreatedAt    time.Time  `json:"created_at"`
+	UpdatedAt    time.Time  `json:"updated_at"`
+}
@@ -1,16 +1,57 @@
 package entity
 
+// DashboardSummary totals are in the tenant's base currency; each account
+// balance is in the account's own currency.
 type DashboardSummary struct {
-	TotalIncome     float64 `json:"total_income"`
-	TotalExpenses   float64 `json:"total_expenses"`
-	Balance         float64 `json:"balance"`
-	PreviousBalance float64 `json:"previous_balance"`
-	IncomeCount     int     `json:"income_count"`
-	ExpenseCount    int     `json:"expense_count"`
+	Currency        string           `json:"currency"`
+	TotalIncome     Money            `json:"total_income"`
+	TotalExpenses   Money            `json:"total_expenses"`
+	Balance         Money            `json:"balance"`
+	PreviousBalance Money            `json:"previous_balance"`
+	IncomeCount     int              `json:"income_count"`
+	ExpenseCount    int              `json:"expense_count"`
+	Accounts        []AccountBalance `json:"accounts"`
+}
+
+type AccountBalance struct {
+	AccountID   string `json:"account_id"`
+	AccountName string `json:"account_name"`
+	Currency    string `json:"currency"`
+	Balance     Money  `json:"balance"`
 }
 
 type CategoryTotal struct {
-	CategoryID   string  `json:"category_id"`
-	CategoryName string  `json:"category_name"`
-	Total        float64 `json:"total"`
+	CategoryID   string `json:"category_id"`
+	CategoryName string `json:"category_name"`
+	Total        Money  `json:"total"`
+}
+
+type TagTotal struct {
+	TagID   string `json:"tag_id"`
+	TagName string `json:"tag_name"`
+	Total   Money  `json:"total"`
+}
+
+// Forecast projects the balance at the end of each coming month, starting from
+// the current month's balance. Scheduled amounts are stored future
+// transactions (materialized recurring occurrences and installments) plus
+// projected recurring occurrences. When requested, AverageByCategory holds the
+// average monthly non-recurring spending of recent months, which is then
+// subtracted from every forecast month as EstimatedSpending. All amounts are
+// in the base currency.
+type Forecast struct {
+	Currency          string          `json:"currency"`
+	StartingBalance   Money           `json:"starting_balance"`
+	Months            []ForecastMonth `json:"months"`
+	AverageByCategory []CategoryTotal `json:"average_by_category,omitempty"`
+}
+
+type ForecastMonth struct {
+	Month             int   `json:"month"`
+	Year              int   `json:"year"`
+	Income            Money `json:"income"`
+	Expenses          Money `json:"expenses"`
+	EstimatedSpending Money `json:"estimated_spending"`
+	Balance           Money `json:"balance"`
+	Negative          bool  `json:"negative"`
 }
@@ -0,0 +1,29 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ExchangeRate says one unit of FromCurrency is worth Rate units of
+// ToCurrency on Date. Source is "manual" for rates entered by hand and the
+// provider name for fetched ones.
+type ExchangeRate struct {
+	ID           uuid.UUID `json:"id"`
+	FromCurrency string    `json:"from_currency"`
+	ToCurrency   string    `json:"to_currency"`
+	Date         string    `json:"date"`
+	Rate         float64   `json:"rate"`
+	Source       string    `json:"source"`
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+// ExchangeRateFilter narrows rate listings. Currency matches either side of
+// the pair.
+type ExchangeRateFilter struct {
+	Currency  string
+	StartDate string
+	EndDate   string
+}
@@ -13,14 +13,26 @@ type ExpenseLimit struct {
 	CategoryName string     `json:"category_name,omitempty"`
 	Month        int        `json:"month"`
 	Year         int        `json:"year"`
-	Amount       float64    `json:"amount"`
-	CreatedAt    time.Time  `json:"created_at"`
-	UpdatedAt    time.Time  `json:"updated_at"`
+	Amount       Money      `json:"amount"`
+	// AlertThresholds are the percentages of Amount at which an email alert
+	// is sent, once per month each.
+	AlertThresholds []int     `json:"alert_thresholds"`
+	CreatedAt       time.Time `json:"created_at"`
+	UpdatedAt       time.Time `json:"updated_at"`
 }
 
+// LimitProgress is the spending against a monthly expense limit or against the
+// current period of a budget. For a budget, Budget is set and Limit describes
+// the period: its category, the month and year asked for, and the amount
+// budgeted for the period, to which Rollover, the amount left unspent in
+// earlier periods, is added.
 type LimitProgress struct {
-	Limit      ExpenseLimit `json:"limit"`
-	Spent      float64      `json:"spent"`
-	Remaining  float64      `json:"remaining"`
-	Percentage float64      `json:"percentage"`
+	Limit       ExpenseLimit `json:"limit"`
+	Budget      *Budget      `json:"budget,omitempty"`
+	PeriodStart string       `json:"period_start"`
+	PeriodEnd   string       `json:"period_end"`
+	Rollover    Money        `json:"rollover"`
+	Spent       Money        `json:"spent"`
+	Remaining   Money        `json:"remaining"`
+	Percentage  float64      `json:"percentage"`
 }
@@ -7,14 +7,19 @@ import (
 )
 
 type GlobalUser struct {
-	ID                  uuid.UUID  `json:"id"`
-	Name                string     `json:"name"`
-	Email               string     `json:"email"`
-	PasswordHash        string     `json:"-"`
-	EmailVerified       bool       `json:"email_verified"`
-	EmailToken          *string    `json:"-"`
-	EmailTokenExpiresAt *time.Time `json:"-"`
-	MaxOwnedTenants     int        `json:"max_owned_tenants"`
-	CreatedAt           time.Time  `json:"created_at"`
-	UpdatedAt           time.Time  `json:"updated_at"`
+	ID                     uuid.UUID  `json:"id"`
+	Name                   string     `json:"name"`
+	Email                  string     `json:"email"`
+	PasswordHash           string     `json:"-"`
+	EmailVerified          bool       `json:"email_verified"`
+	EmailToken             *string    `json:"-"`
+	EmailTokenExpiresAt    *time.Time `json:"-"`
+	PasswordResetToken     *string    `json:"-"`
+	PasswordResetExpiresAt *time.Time `json:"-"`
+	TOTPSecret             *string    `json:"-"`
+	TOTPEnabled            bool       `json:"totp_enabled"`
+	TOTPLastStep           int64      `json:"-"`
+	MaxOwnedTenants        int        `json:"max_owned_tenants"`
+	CreatedAt              time.Time  `json:"created_at"`
+	UpdatedAt              time.Time  `json:"updated_at"`
 }
@@ -0,0 +1,53 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Goal is a savings target, such as R$ 20.000 for a car by December 2027.
+// TargetAmount is in the base currency; progress comes from the transactions
+// linked to the goal as contributions.
+type Goal struct {
+	ID           uuid.UUID  `json:"id"`
+	UserID       *uuid.UUID `json:"user_id,omitempty"`
+	Name         string     `json:"name"`
+	TargetAmount Money      `json:"target_amount"`
+	Deadline     string     `json:"deadline"`
+	CreatedAt    time.Time  `json:"created_at"`
+	UpdatedAt    time.Time  `json:"updated_at"`
+}
+
+// GoalContribution is a transaction counted towards a goal. Amount is in the
+// transaction's own currency.
+type GoalContribution struct {
+	GoalID        uuid.UUID  `json:"goal_id"`
+	TransactionID uuid.UUID  `json:"transaction_id"`
+	TransferID    *uuid.UUID `json:"transfer_id,omitempty"`
+	AccountName   string     `json:"account_name"`
+	Type          string     `json:"type"`
+	Amount        Money      `json:"amount"`
+	Currency      string     `json:"currency"`
+	Description   string     `json:"description"`
+	Date          string     `json:"date"`
+	CreatedAt     time.Time  `json:"created_at"`
+}
+
+// GoalProgress is a goal with what has been contributed so far, in the base
+// currency, and the pace needed to meet the deadline. MonthsLeft counts the
+// current month; MonthlyNeeded spreads the remainder over them. The projected
+// completion follows the average monthly contribution since StartedOn, the
+// date of the first contribution, and is omitted while there is none.
+type GoalProgress struct {
+	Goal                Goal    `json:"goal"`
+	Contributed         Money   `json:"contributed"`
+	Remaining           Money   `json:"remaining"`
+	Percentage          float64 `json:"percentage"`
+	StartedOn           string  `json:"started_on,omitempty"`
+	MonthsLeft          int     `json:"months_left"`
+	MonthlyNeeded       Money   `json:"monthly_needed"`
+	AverageMonthly      Money   `json:"average_monthly"`
+	ProjectedCompletion string  `json:"projected_completion,omitempty"`
+	OnTrack             bool    `json:"on_track"`
+}
@@ -0,0 +1,38 @@
+package entity
+
+import "github.com/google/uuid"
+
+const (
+	ImportStatusNew               = "new"
+	ImportStatusDuplicate         = "duplicate"
+	ImportStatusPossibleDuplicate = "possible_duplicate"
+)
+
+// ImportCandidate is a row read from an imported file. Status tells whether it
+// already exists: "duplicate" matches a stored external ID, while
+// "possible_duplicate" matches the amount of a stored transaction dated a few
+// days apart. RuleID is the categorization rule that chose CategoryID, if any.
+type ImportCandidate struct {
+	ExternalID    string     `json:"external_id,omitempty"`
+	Type          string     `json:"type"`
+	Amount        Money      `json:"amount"`
+	Description   string     `json:"description"`
+	Date          string     `json:"date"`
+	CategoryID    *uuid.UUID `json:"category_id"`
+	Status        string     `json:"status"`
+	DuplicateOfID *uuid.UUID `json:"duplicate_of_id,omitempty"`
+	RuleID        *uuid.UUID `json:"rule_id,omitempty"`
+}
+
+type ImportPreview struct {
+	AccountID         uuid.UUID         `json:"account_id"`
+	Candidates        []ImportCandidate `json:"candidates"`
+	NewCount          int               `json:"new_count"`
+	DuplicateCount    int               `json:"duplicate_count"`
+	PossibleDuplicate int               `json:"possible_duplicate_count"`
+}
+
+type ImportResult struct {
+	Imported int `json:"imported"`
+	Skipped  int `json:"skipped"`
+}
@@ -0,0 +1,30 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ImportProfile describes how to read a CSV statement. Columns are header
+// names, or 1-based positions when HasHeader is false. NegateAmounts flips the
+// sign of every amount, for files that list purchases as positive values.
+type ImportProfile struct {
+	ID                       uuid.UUID  `json:"id"`
+	UserID                   *uuid.UUID `json:"user_id,omitempty"`
+	Name                     string     `json:"name"`
+	Delimiter                string     `json:"delimiter"`
+	HasHeader                bool       `json:"has_header"`
+	SkipRows                 int        `json:"skip_rows"`
+	DateColumn               string     `json:"date_column"`
+	DescriptionColumn        string     `json:"description_column"`
+	AmountColumn             string     `json:"amount_column"`
+	ExternalIDColumn         *string    `json:"external_id_column,omitempty"`
+	DateFormat               string     `json:"date_format"`
+	DecimalSeparator         string     `json:"decimal_separator"`
+	NegateAmounts            bool       `json:"negate_amounts"`
+	DefaultIncomeCategoryID  *uuid.UUID `json:"default_income_category_id,omitempty"`
+	DefaultExpenseCategoryID *uuid.UUID `json:"default_expense_category_id,omitempty"`
+	CreatedAt                time.Time  `json:"created_at"`
+	UpdatedAt                time.Time  `json:"updated_at"`
+}
@@ -0,0 +1,19 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// LimitAlert records that spending against an expense limit or a budget
+// crossed Threshold percent in the period starting at PeriodStart, so the
+// alert is sent only once. Exactly one of ExpenseLimitID and BudgetID is set.
+type LimitAlert struct {
+	ID             uuid.UUID  `json:"id"`
+	ExpenseLimitID *uuid.UUID `json:"expense_limit_id,omitempty"`
+	BudgetID       *uuid.UUID `json:"budget_id,omitempty"`
+	PeriodStart    string     `json:"period_start"`
+	Threshold      int        `json:"threshold"`
+	SentAt         time.Time  `json:"sent_at"`
+}
@@ -18,7 +18,8 @@ type Membership struct {
 
 // TenantMembership is used in the tenant selector after login.
 type TenantMembership struct {
-	TenantID   uuid.UUID `json:"tenant_id"`
-	TenantName string    `json:"tenant_name"`
-	Role       string    `json:"role"`
+	TenantID         uuid.UUID `json:"tenant_id"`
+	TenantName       string    `json:"tenant_name"`
+	Role             string    `json:"role"`
+	RequireTwoFactor bool      `json:"require_two_factor"`
 }
@@ -0,0 +1,190 @@
+package entity
+
+import (
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Money is an exact amount in cents. It is written to JSON and to the
+// database as a decimal with two places ("12.34"), and read from either a
+// decimal or a whole number, so amounts never pass through floating point.
+type Money int64
+
+var ErrInvalidMoney = errors.New("invalid amount: use a decimal with at most 2 places")
+
+// ParseMoney reads a decimal such as "12", "-12.3" or "12.340" with "." as the
+// separator. Digits past the cents must be zeros; anything else is rejected
+// rather than rounded.
+func ParseMoney(s string) (Money, error) {
+	s = strings.TrimSpace(s)
+	negative := false
+	switch {
+	case strings.HasPrefix(s, "-"):
+		negative = true
+		s = s[1:]
+	case strings.HasPrefix(s, "+"):
+		s = s[1:]
+	}
+
+	whole, frac, _ := strings.Cut(s, ".")
+	if whole == "" && frac == "" {
+		return 0, ErrInvalidMoney
+	}
+	if whole == "" {
+		whole = "0"
+	}
+	if len(frac) > 2 {
+		if strings.Trim(frac[2:], "0") != "" {
+			return 0, ErrInvalidMoney
+		}
+		frac = frac[:2]
+	}
+	for len(frac) < 2 {
+		frac += "0"
+	}
+	if !isDigits(whole) || !isDigits(frac) {
+		return 0, ErrInvalidMoney
+	}
+
+	units, err := strconv.ParseInt(whole, 10, 64)
+	if err != nil || units > math.MaxInt64/100-1 {
+		return 0, ErrInvalidMoney
+	}
+	cents, _ := strconv.ParseInt(frac, 10, 64)
+	m := Money(units*100 + cents)
+	if negative {
+		m = -m
+	}
+	return m, nil
+}
+
+func isDigits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// String formats the amount as a plain decimal with two places.
+func (m Money) String() string {
+	sign := ""
+	cents := int64(m)
+	if cents < 0 {
+		sign = "-"
+		cents = -cents
+	}
+	return fmt.Sprintf("%s%d.%02d", sign, cents/100, cents%100)
+}
+
+// Abs returns the amount without its sign.
+func (m Money) Abs() Money {
+	if m < 0 {
+		return -m
+	}
+	return m
+}
+
+// MulRate converts the amount at an exchange rate, rounding half away from
+// zero to the cent.
+func (m Money) MulRate(rate float64) Money {
+	return Money(math.Round(float64(m) * rate))
+}
+
+// Part returns the i-th (0-based) of n parts that add up to the amount
+// exactly. Every part gets the amount divided by n, truncated to the cent, and
+// the leftover cents (fewer than n) go one each to the first parts: R$ 100,00
+// in 3 parts is 33,34 + 33,33 + 33,33.
+func (m Money) Part(n, i int) Money {
+	base := m / Money(n)
+	leftover := int(m % Money(n)) // same sign as m
+	switch {
+	case leftover > 0 && i < leftover:
+		return base + 1
+	case leftover < 0 && i < -leftover:
+		return base - 1
+	}
+	return base
+}
+
+func (m Money) MarshalJSON() ([]byte, error) {
+	return []byte(m.String()), nil
+}
+
+// UnmarshalJSON accepts a JSON number or a string holding one.
+func (m *Money) UnmarshalJSON(data []byte) error {
+	s := string(data)
+	if s == "null" {
+		return nil
+	}
+	s = strings.Trim(s, `"`)
+	if strings.ContainsAny(s, "eE") {
+		return ErrInvalidMoney
+	}
+	parsed, err := ParseMoney(s)
+	if err != nil {
+		return err
+	}
+	*m = parsed
+	return nil
+}
+
+// Scan reads a NUMERIC (which pgx hands over as text) or an integer column.
+// Float values are rounded to the cent.
+func (m *Money) Scan(src any) error {
+	switch v := src.(type) {
+	case nil:
+		*m = 0
+	case string:
+		return m.scanDecimal(v)
+	case []byte:
+		return m.scanDecimal(string(v))
+	case int64:
+		*m = Money(v * 100)
+	case float64:
+		*m = Money(math.Round(v * 100))
+	default:
+		return fmt.Errorf("money: cannot scan %T", src)
+	}
+	return nil
+}
+
+// scanDecimal rounds half away from zero to the cent: the database keeps
+// amounts in DECIMAL(12,2), but computed values such as conversions may carry
+// more places.
+func (m *Money) scanDecimal(s string) error {
+	whole, frac, ok := strings.Cut(s, ".")
+	if ok && len(frac) > 2 {
+		roundUp := frac[2] >= '5'
+		parsed, err := ParseMoney(whole + "." + frac[:2])
+		if err != nil {
+			return err
+		}
+		if roundUp {
+			if strings.HasPrefix(whole, "-") {
+				parsed--
+			} else {
+				parsed++
+			}
+		}
+		*m = parsed
+		return nil
+	}
+	parsed, err := ParseMoney(s)
+	if err != nil {
+		return err
+	}
+	*m = parsed
+	return nil
+}
+
+// Value writes the amount as a decimal string, which Postgres reads exactly
+// into NUMERIC.
+func (m Money) Value() (driver.Value, error) {
+	return m.String(), nil
+}
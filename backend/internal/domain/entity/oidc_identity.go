@@ -0,0 +1,18 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// OIDCIdentity links an account at an OpenID Connect provider, such as
+// Google, to a global user. Email is the one the provider verified when the
+// link was made.
+type OIDCIdentity struct {
+	Issuer       string    `json:"issuer"`
+	Subject      string    `json:"subject"`
+	GlobalUserID uuid.UUID `json:"global_user_id"`
+	Email        string    `json:"email"`
+	CreatedAt    time.Time `json:"created_at"`
+}
@@ -14,23 +14,52 @@ const (
 	DeleteModeFutureOnly       DeleteMode = "future_only"
 )
 
+type UpdateScope string
+
+const (
+	UpdateScopeThis          UpdateScope = "this"
+	UpdateScopeThisAndFuture UpdateScope = "this_and_future"
+	UpdateScopeAll           UpdateScope = "all"
+)
+
 type RecurringTransaction struct {
-	ID             uuid.UUID  `json:"id"`
-	UserID         uuid.UUID  `json:"user_id"`
-	CategoryID     uuid.UUID  `json:"category_id"`
-	CategoryName   string     `json:"category_name,omitempty"`
-	Type           string     `json:"type"`
-	Amount         float64    `json:"amount"`
-	Description    string     `json:"description"`
-	Frequency      string     `json:"frequency"`
-	StartDate      string     `json:"start_date"`
-	EndDate        *string    `json:"end_date"`
-	MaxOccurrences *int       `json:"max_occurrences"`
-	DayOfMonth     *int       `json:"day_of_month"`
-	IsActive       bool       `json:"is_active"`
-	PausedAt       *time.Time `json:"paused_at"`
-	CreatedAt      time.Time  `json:"created_at"`
-	UpdatedAt      time.Time  `json:"updated_at"`
+	ID                uuid.UUID   `json:"id"`
+	UserID            uuid.UUID   `json:"user_id"`
+	CategoryID        uuid.UUID   `json:"category_id"`
+	CategoryName      string      `json:"category_name,omitempty"`
+	AccountID         uuid.UUID   `json:"account_id"`
+	AccountName       string      `json:"account_name,omitempty"`
+	Type              string      `json:"type"`
+	Amount            Money       `json:"amount"`
+	Currency          string      `json:"currency"`
+	Description       string      `json:"description"`
+	Frequency         string      `json:"frequency"`
+	StartDate         string      `json:"start_date"`
+	EndDate           *string     `json:"end_date"`
+	MaxOccurrences    *int        `json:"max_occurrences"`
+	DayOfMonth        *int        `json:"day_of_month"`
+	IsActive          bool        `json:"is_active"`
+	PausedAt          *time.Time  `json:"paused_at"`
+	MaterializedUntil *string     `json:"materialized_until"`
+	TagIDs            []uuid.UUID `json:"tag_ids"`
+	CreatedAt         time.Time   `json:"created_at"`
+	UpdatedAt         time.Time   `json:"updated_at"`
+}
+
+// RecurringTransactionUpdate describes a change to a recurring series. Nil
+// fields are left unchanged. OccurrenceDate selects the occurrence for the
+// "this" and "this_and_future" scopes. For installment series, Amount is the
+// purchase total except with scope "this", where it is the single installment.
+type RecurringTransactionUpdate struct {
+	Scope          UpdateScope
+	OccurrenceDate string
+	Amount         *Money
+	Description    *string
+	CategoryID     *uuid.UUID
+	AccountID      *uuid.UUID
+	Frequency      *string
+	DayOfMonth     *int
+	TagIDs         []uuid.UUID
 }
 
 type RecurringTransactionFilter struct {
@@ -0,0 +1,65 @@
+package entity
+
+// MonthlyTotals is one month of a report series, in the base currency. Net is
+// income minus expenses; Balance is the balance at the end of the month, as
+// in DashboardSummary.
+type MonthlyTotals struct {
+	Month    int   `json:"month"`
+	Year     int   `json:"year"`
+	Income   Money `json:"income"`
+	Expenses Money `json:"expenses"`
+	Net      Money `json:"net"`
+	Balance  Money `json:"balance"`
+}
+
+// CategoryMonthTotal is a category's total for one month, as read from the
+// database before it is arranged into a CategorySeries.
+type CategoryMonthTotal struct {
+	CategoryID   string
+	CategoryName string
+	Month        int
+	Year         int
+	Total        Money
+}
+
+// CategorySeries is a category's total for every month of a report range,
+// with zeros for months without transactions.
+type CategorySeries struct {
+	CategoryID   string       `json:"category_id"`
+	CategoryName string       `json:"category_name"`
+	Total        Money        `json:"total"`
+	Months       []MonthTotal `json:"months"`
+}
+
+type MonthTotal struct {
+	Month int   `json:"month"`
+	Year  int   `json:"year"`
+	Total Money `json:"total"`
+}
+
+// Comparison holds a value for a period and the same period of the compared
+// year. PercentageChange is relative to Previous and is null when Previous is
+// zero.
+type Comparison struct {
+	Current          Money    `json:"current"`
+	Previous         Money    `json:"previous"`
+	Delta            Money    `json:"delta"`
+	PercentageChange *float64 `json:"percentage_change"`
+}
+
+// MonthComparison compares one month, or whole years when Month is 0.
+type MonthComparison struct {
+	Month    int        `json:"month,omitempty"`
+	Income   Comparison `json:"income"`
+	Expenses Comparison `json:"expenses"`
+	Net      Comparison `json:"net"`
+}
+
+// YearOverYear compares each month of Year with the same month of
+// CompareYear, and the whole years in Totals.
+type YearOverYear struct {
+	Year        int               `json:"year"`
+	CompareYear int               `json:"compare_year"`
+	Months      []MonthComparison `json:"months"`
+	Totals      MonthComparison   `json:"totals"`
+}
@@ -0,0 +1,27 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Session is one signed-in device in one tenant. It lives until it expires
+// or is revoked by logout, a password change or the member's removal.
+type Session struct {
+	ID                uuid.UUID  `json:"id"`
+	GlobalUserID      uuid.UUID  `json:"global_user_id"`
+	TenantID          uuid.UUID  `json:"tenant_id"`
+	SchemaUserID      uuid.UUID  `json:"schema_user_id"`
+	RefreshTokenHash  string     `json:"-"`
+	PreviousTokenHash *string    `json:"-"`
+	ExpiresAt         time.Time  `json:"expires_at"`
+	RevokedAt         *time.Time `json:"revoked_at"`
+	CreatedAt         time.Time  `json:"created_at"`
+	LastUsedAt        time.Time  `json:"last_used_at"`
+}
+
+// Active reports whether the session can still be used at t.
+func (s *Session) Active(t time.Time) bool {
+	return s.RevokedAt == nil && s.ExpiresAt.After(t)
+}
@@ -0,0 +1,10 @@
+package entity
+
+import "time"
+
+// Settings are the tenant-wide preferences. Dashboard totals are reported in
+// BaseCurrency.
+type Settings struct {
+	BaseCurrency string    `json:"base_currency"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
@@ -7,12 +7,13 @@ import (
 )
 
 type Tenant struct {
-	ID         uuid.UUID  `json:"id"`
-	Name       string     `json:"name"`
-	Domain     *string    `json:"domain"`
-	SchemaName string     `json:"schema_name"`
-	IsActive   bool       `json:"is_active"`
-	OwnerID    *uuid.UUID `json:"owner_id,omitempty"`
-	CreatedAt  time.Time  `json:"created_at"`
-	UpdatedAt  time.Time  `json:"updated_at"`
+	ID               uuid.UUID  `json:"id"`
+	Name             string     `json:"name"`
+	Domain           *string    `json:"domain"`
+	SchemaName       string     `json:"schema_name"`
+	IsActive         bool       `json:"is_active"`
+	OwnerID          *uuid.UUID `json:"owner_id,omitempty"`
+	RequireTwoFactor bool       `json:"require_two_factor"`
+	CreatedAt        time.Time  `json:"created_at"`
+	UpdatedAt        time.Time  `json:"updated_at"`
 }
@@ -7,26 +7,58 @@ import (
 )
 
 type Transaction struct {
-	ID           uuid.UUID  `json:"id"`
-	UserID       uuid.UUID  `json:"user_id"`
-	CategoryID   uuid.UUID  `json:"category_id"`
-	CategoryName string     `json:"category_name,omitempty"`
-	Type         string     `json:"type"`
-	Amount       float64    `json:"amount"`
-	Description  string     `json:"description"`
-	Date         string     `json:"date"`
-	RecurringID  *uuid.UUID `json:"recurring_id,omitempty"`
-	CreatedAt    time.Time  `json:"created_at"`
-	UpdatedAt    time.Time  `json:"updated_at"`
+	ID                uuid.UUID          `json:"id"`
+	UserID            uuid.UUID          `json:"user_id"`
+	CategoryID        uuid.UUID          `json:"category_id,omitzero"`
+	CategoryName      string             `json:"category_name,omitempty"`
+	AccountID         uuid.UUID          `json:"account_id"`
+	AccountName       string             `json:"account_name,omitempty"`
+	Type              string             `json:"type"`
+	Amount            Money              `json:"amount"`
+	Currency          string             `json:"currency"`
+	Description       string             `json:"description"`
+	Date              string             `json:"date"`
+	RecurringID       *uuid.UUID         `json:"recurring_id,omitempty"`
+	TransferID        *uuid.UUID         `json:"transfer_id,omitempty"`
+	TransferDirection string             `json:"transfer_direction,omitempty"`
+	StatementID       *uuid.UUID         `json:"statement_id,omitempty"`
+	ExternalID        *string            `json:"external_id,omitempty"`
+	TagIDs            []uuid.UUID        `json:"tag_ids,omitempty"`
+	Splits            []TransactionSplit `json:"splits,omitempty"`
+	IsProjected       bool               `json:"is_projected,omitempty"`
+	Highlight         string             `json:"highlight,omitempty"`
+	CreatedAt         time.Time          `json:"created_at"`
+	UpdatedAt         time.Time          `json:"updated_at"`
 }
 
+// TransactionSplit is one category line of a split transaction. The lines of a
+// transaction add up to its amount.
+type TransactionSplit struct {
+	ID           uuid.UUID `json:"id"`
+	CategoryID   uuid.UUID `json:"category_id"`
+	CategoryName string    `json:"category_name,omitempty"`
+	Amount       Money     `json:"amount"`
+	Description  string    `json:"description,omitempty"`
+}
+
+// TransactionFilter narrows transaction listings. Query is a full-text search
+// over descriptions; when set, results are ordered by relevance. CategoryIDs
+// also matches split transactions with a line in one of the categories; TagIDs
+// matches transactions carrying any of the tags.
 type TransactionFilter struct {
-	Type       string
-	CategoryID *uuid.UUID
-	StartDate  string
-	EndDate    string
-	Page       int
-	PerPage    int
+	Type        string
+	Query       string
+	CategoryIDs []uuid.UUID
+	TagIDs      []uuid.UUID
+	AccountID   *uuid.UUID
+	StatementID *uuid.UUID
+	UserID      *uuid.UUID
+	MinAmount   *Money
+	MaxAmount   *Money
+	StartDate   string
+	EndDate     string
+	Page        int
+	PerPage     int
 }
 
 type PaginatedTransactions struct {
@@ -0,0 +1,22 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Transfer moves money between two accounts of the tenant. It is stored as two
+// transactions of type "transfer" sharing the transfer ID: an "out" leg on the
+// source account and an "in" leg on the destination.
+type Transfer struct {
+	ID            uuid.UUID `json:"id"`
+	UserID        uuid.UUID `json:"user_id"`
+	FromAccountID uuid.UUID `json:"from_account_id"`
+	ToAccountID   uuid.UUID `json:"to_account_id"`
+	Amount        Money     `json:"amount"`
+	Description   string    `json:"description"`
+	Date          string    `json:"date"`
+	CreatedAt     time.Time `json:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
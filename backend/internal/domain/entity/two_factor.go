@@ -0,0 +1,15 @@
+package entity
+
+// TwoFactorSetup starts enrollment: the user scans ProvisioningURI as a QR
+// code, or types Secret, into an authenticator app.
+type TwoFactorSetup struct {
+	Secret          string `json:"secret"`
+	ProvisioningURI string `json:"provisioning_uri"`
+}
+
+type TwoFactorStatus struct {
+	Enabled bool `json:"enabled"`
+	// Required is set when a tenant the user belongs to requires it.
+	Required          bool `json:"required"`
+	RecoveryCodesLeft int  `json:"recovery_codes_left"`
+}
@@ -24,6 +24,39 @@ var (
 	ErrMaxTenantsReached  = errors.New("maximum number of owned tenants reached")
 	ErrInviteExpired      = errors.New("invite has expired")
 	ErrInviteAlreadyUsed  = errors.New("invite has already been accepted")
+	ErrResetTokenExpired  = errors.New("password reset link has expired")
 	ErrNoMemberships      = errors.New("user has no tenant memberships")
 	ErrDuplicateTenant    = errors.New("tenant name already in use")
+	ErrInvalidScope       = errors.New("invalid update scope")
+	ErrInvalidOccurrence  = errors.New("date is not an occurrence of this recurring transaction")
+	ErrInstallmentTotal   = errors.New("total is lower than the installments already recorded")
+	ErrRecurringPaused    = errors.New("recurring transaction is paused")
+	ErrDuplicateAccount   = errors.New("account name already exists")
+	ErrAccountInUse       = errors.New("account is in use by transactions")
+	ErrSameAccount        = errors.New("source and destination account must be different")
+	ErrTransferLeg        = errors.New("transaction is part of a transfer")
+	ErrMissingCategory    = errors.New("category is required and no categorization rule matched")
+	ErrInvalidImportRow   = errors.New("imported transaction has an invalid type, amount or date")
+	ErrDuplicateProfile   = errors.New("import profile name already exists")
+	ErrInvalidRule        = errors.New("rule needs at least one condition and a category matching its type")
+	ErrDuplicateTag       = errors.New("tag name already exists")
+	ErrUnknownTag         = errors.New("one or more tags do not exist")
+	ErrAttachmentTooLarge = errors.New("attachment exceeds the maximum size")
+	ErrAttachmentType     = errors.New("attachment must be a JPEG, PNG, WebP or PDF file")
+	ErrInvalidSplit       = errors.New("split needs at least two lines with existing categories adding up to the transaction amount")
+	ErrCurrencyMismatch   = errors.New("transfer accounts use different currencies")
+	ErrDuplicateRate      = errors.New("exchange rate already exists for this date")
+	ErrSameCurrency       = errors.New("exchange rate needs two different currencies")
+	ErrNoRateProvider     = errors.New("no exchange rate provider is configured")
+	ErrMissingRate        = errors.New("no exchange rate between the currencies")
+	ErrDuplicateGoal      = errors.New("goal name already exists")
+	ErrContributionExists = errors.New("transaction already contributes to a goal")
+	ErrDuplicateBudget    = errors.New("budget already exists for this category and period")
+	ErrInvalidBudget      = errors.New("budget needs either an amount or an income percentage, and cannot end before it starts")
+	ErrSessionRevoked     = errors.New("session has expired or was revoked")
+	ErrInvalidTOTPCode    = errors.New("invalid two-factor authentication code")
+	ErrTwoFactorRequired  = errors.New("two-factor authentication is required by one of your tenants")
+	ErrTwoFactorEnabled   = errors.New("two-factor authentication is already enabled")
+	ErrTwoFactorDisabled  = errors.New("two-factor authentication is not enabled")
+	ErrTooManyAttempts    = errors.New("too many wrong codes, sign in again later")
 )
@@ -0,0 +1,18 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/dcunha/finance/backend/internal/domain/entity"
+	"github.com/google/uuid"
+)
+
+type AccountRepository interface {
+	Create(ctx context.Context, account *entity.Account) error
+	Update(ctx context.Context, account *entity.Account) error
+	Delete(ctx context.Context, id uuid.UUID) error
+	FindByID(ctx context.Context, id uuid.UUID) (*entity.Account, error)
+	FindDefault(ctx context.Context) (*entity.Account, error)
+	FindAll(ctx context.Context) ([]entity.Account, error)
+	IsInUse(ctx context.Context, id uuid.UUID) (bool, error)
+}
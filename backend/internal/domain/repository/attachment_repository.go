@@ -0,0 +1,15 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/dcunha/finance/backend/internal/domain/entity"
+	"github.com/google/uuid"
+)
+
+type AttachmentRepository interface {
+	Create(ctx context.Context, a *entity.Attachment) error
+	Delete(ctx context.Context, id uuid.UUID) error
+	FindByID(ctx context.Context, id uuid.UUID) (*entity.Attachment, error)
+	FindByTransactionID(ctx context.Context, transactionID uuid.UUID) ([]entity.Attachment, error)
+}
@@ -0,0 +1,19 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/dcunha/finance/backend/internal/domain/entity"
+	"github.com/google/uuid"
+)
+
+type BudgetRepository interface {
+	Create(ctx context.Context, budget *entity.Budget) error
+	Update(ctx context.Context, budget *entity.Budget) error
+	Delete(ctx context.Context, id uuid.UUID) error
+	FindByID(ctx context.Context, id uuid.UUID) (*entity.Budget, error)
+	FindAll(ctx context.Context) ([]entity.Budget, error)
+	// FillPeriodTotals sets Spent and Income on each period of the budget,
+	// counting only userID's transactions when it is set.
+	FillPeriodTotals(ctx context.Context, budget *entity.Budget, periods []entity.BudgetPeriod, userID *uuid.UUID) error
+}
@@ -0,0 +1,16 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/dcunha/finance/backend/internal/domain/entity"
+	"github.com/google/uuid"
+)
+
+type CategorizationRuleRepository interface {
+	Create(ctx context.Context, rule *entity.CategorizationRule) error
+	Update(ctx context.Context, rule *entity.CategorizationRule) error
+	Delete(ctx context.Context, id uuid.UUID) error
+	FindByID(ctx context.Context, id uuid.UUID) (*entity.CategorizationRule, error)
+	FindAll(ctx context.Context) ([]entity.CategorizationRule, error)
+}
@@ -0,0 +1,20 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/dcunha/finance/backend/internal/domain/entity"
+	"github.com/google/uuid"
+)
+
+type CreditCardRepository interface {
+	Create(ctx context.Context, card *entity.CreditCard, userID uuid.UUID) error
+	Update(ctx context.Context, card *entity.CreditCard) error
+	FindByID(ctx context.Context, id uuid.UUID) (*entity.CreditCard, error)
+	FindByAccountID(ctx context.Context, accountID uuid.UUID) (*entity.CreditCard, error)
+	FindAll(ctx context.Context) ([]entity.CreditCard, error)
+	FindOrCreateStatement(ctx context.Context, st *entity.CreditCardStatement) error
+	FindStatementByID(ctx context.Context, id uuid.UUID) (*entity.CreditCardStatement, error)
+	FindStatements(ctx context.Context, cardID uuid.UUID) ([]entity.CreditCardStatement, error)
+	AddStatementPayment(ctx context.Context, id uuid.UUID, amount entity.Money) error
+}
@@ -0,0 +1,24 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/dcunha/finance/backend/internal/domain/entity"
+	"github.com/google/uuid"
+)
+
+type ExchangeRateRepository interface {
+	Create(ctx context.Context, rate *entity.ExchangeRate) error
+	Update(ctx context.Context, rate *entity.ExchangeRate) error
+	Delete(ctx context.Context, id uuid.UUID) error
+	FindByID(ctx context.Context, id uuid.UUID) (*entity.ExchangeRate, error)
+	FindAll(ctx context.Context, filter entity.ExchangeRateFilter) ([]entity.ExchangeRate, error)
+	// Upsert stores a fetched rate, replacing an earlier fetched rate for the
+	// same pair and date but never a manual one.
+	Upsert(ctx context.Context, rate *entity.ExchangeRate) error
+	// FindRate returns what one unit of from is worth in to on date, using the
+	// nearest stored rate. ErrNotFound when the pair has no rate.
+	FindRate(ctx context.Context, from, to, date string) (float64, error)
+	// CurrenciesInUse lists the currencies of accounts and transactions.
+	CurrenciesInUse(ctx context.Context) ([]string, error)
+}
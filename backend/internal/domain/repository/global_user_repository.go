@@ -13,5 +13,12 @@ type GlobalUserRepository interface {
 	FindByID(ctx context.Context, id uuid.UUID) (*entity.GlobalUser, error)
 	Update(ctx context.Context, user *entity.GlobalUser) error
 	FindByEmailToken(ctx context.Context, token string) (*entity.GlobalUser, error)
+	FindByPasswordResetToken(ctx context.Context, token string) (*entity.GlobalUser, error)
+	// ConsumePasswordResetToken clears an unexpired reset token and returns the
+	// id of its user. Of concurrent calls with the same token, only one succeeds.
+	ConsumePasswordResetToken(ctx context.Context, token string) (uuid.UUID, error)
+	// RecordTOTPStep stores step as the last accepted TOTP code, and reports
+	// false if a code from that step or a later one was already accepted.
+	RecordTOTPStep(ctx context.Context, id uuid.UUID, step int64) (bool, error)
 	CountOwnedTenants(ctx context.Context, globalUserID uuid.UUID) (int, error)
 }
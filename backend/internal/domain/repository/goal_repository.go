@@ -0,0 +1,24 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/dcunha/finance/backend/internal/domain/entity"
+	"github.com/google/uuid"
+)
+
+type GoalRepository interface {
+	Create(ctx context.Context, goal *entity.Goal) error
+	Update(ctx context.Context, goal *entity.Goal) error
+	Delete(ctx context.Context, id uuid.UUID) error
+	FindByID(ctx context.Context, id uuid.UUID) (*entity.Goal, error)
+	FindAll(ctx context.Context) ([]entity.Goal, error)
+	// AddContribution links a transaction to a goal. ErrContributionExists
+	// when it already counts towards a goal.
+	AddContribution(ctx context.Context, goalID, transactionID uuid.UUID) error
+	RemoveContribution(ctx context.Context, goalID, transactionID uuid.UUID) error
+	FindContributions(ctx context.Context, goalID uuid.UUID) ([]entity.GoalContribution, error)
+	// GetProgress returns every goal with Contributed and StartedOn filled in;
+	// the pace fields are left to the caller.
+	GetProgress(ctx context.Context) ([]entity.GoalProgress, error)
+}
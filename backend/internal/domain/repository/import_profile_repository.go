@@ -0,0 +1,16 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/dcunha/finance/backend/internal/domain/entity"
+	"github.com/google/uuid"
+)
+
+type ImportProfileRepository interface {
+	Create(ctx context.Context, profile *entity.ImportProfile) error
+	Update(ctx context.Context, profile *entity.ImportProfile) error
+	Delete(ctx context.Context, id uuid.UUID) error
+	FindByID(ctx context.Context, id uuid.UUID) (*entity.ImportProfile, error)
+	FindAll(ctx context.Context) ([]entity.ImportProfile, error)
+}
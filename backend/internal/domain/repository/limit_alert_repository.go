@@ -0,0 +1,15 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/dcunha/finance/backend/internal/domain/entity"
+	"github.com/google/uuid"
+)
+
+type LimitAlertRepository interface {
+	// Record stores the alert unless the same threshold was already recorded
+	// for the period, and reports whether it was stored.
+	Record(ctx context.Context, alert *entity.LimitAlert) (bool, error)
+	Delete(ctx context.Context, id uuid.UUID) error
+}
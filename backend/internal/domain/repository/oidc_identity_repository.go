@@ -0,0 +1,17 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/dcunha/finance/backend/internal/domain/entity"
+	"github.com/google/uuid"
+)
+
+type OIDCIdentityRepository interface {
+	Create(ctx context.Context, identity *entity.OIDCIdentity) error
+	Find(ctx context.Context, issuer, subject string) (*entity.OIDCIdentity, error)
+	// RecordTokenUse marks the login completion token jti as used until
+	// expiresAt, and reports false if it already was.
+	RecordTokenUse(ctx context.Context, jti uuid.UUID, expiresAt time.Time) (bool, error)
+}
@@ -0,0 +1,15 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+type RecoveryCodeRepository interface {
+	// Replace discards the user's codes and stores the given hashes.
+	Replace(ctx context.Context, globalUserID uuid.UUID, codeHashes []string) error
+	// Use marks an unused code as used and reports whether there was one.
+	Use(ctx context.Context, globalUserID uuid.UUID, codeHash string) (bool, error)
+	CountUnused(ctx context.Context, globalUserID uuid.UUID) (int, error)
+}
@@ -10,9 +10,12 @@ import (
 
 type RecurringTransactionRepository interface {
 	Create(ctx context.Context, rt *entity.RecurringTransaction) error
+	Update(ctx context.Context, rt *entity.RecurringTransaction) error
 	Delete(ctx context.Context, id uuid.UUID) error
 	FindByID(ctx context.Context, id uuid.UUID) (*entity.RecurringTransaction, error)
 	FindAll(ctx context.Context, userID uuid.UUID, filter entity.RecurringTransactionFilter) (*entity.PaginatedRecurringTransactions, error)
 	Pause(ctx context.Context, id uuid.UUID, pausedAt time.Time) error
 	Resume(ctx context.Context, id uuid.UUID) error
+	FindActive(ctx context.Context) ([]entity.RecurringTransaction, error)
+	UpdateMaterializedUntil(ctx context.Context, id uuid.UUID, date string) error
 }
@@ -0,0 +1,23 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/dcunha/finance/backend/internal/domain/entity"
+	"github.com/google/uuid"
+)
+
+// ReportRepository reads totals over ranges of whole months. Dates are the
+// first day of the first and of the last month, YYYY-MM-DD.
+type ReportRepository interface {
+	// GetMonthlyTotals returns income and expenses for every month of the
+	// range, including months without transactions. Net and Balance are left
+	// for the caller.
+	GetMonthlyTotals(ctx context.Context, startMonth, endMonth string, userID *uuid.UUID) ([]entity.MonthlyTotals, error)
+	// GetBalanceBefore returns the balance before date, as the previous
+	// balance of DashboardSummary.
+	GetBalanceBefore(ctx context.Context, date string, userID *uuid.UUID) (entity.Money, error)
+	// GetCategoryMonthlyTotals returns the non-zero totals of the given type
+	// per category and month.
+	GetCategoryMonthlyTotals(ctx context.Context, startMonth, endMonth, txType string, userID *uuid.UUID) ([]entity.CategoryMonthTotal, error)
+}
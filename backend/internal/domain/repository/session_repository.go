@@ -0,0 +1,27 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/dcunha/finance/backend/internal/domain/entity"
+	"github.com/google/uuid"
+)
+
+type SessionRepository interface {
+	Create(ctx context.Context, session *entity.Session) error
+	FindByID(ctx context.Context, id uuid.UUID) (*entity.Session, error)
+	// FindByTokenHash matches either the current or the previous refresh token.
+	FindByTokenHash(ctx context.Context, hash string) (*entity.Session, error)
+	// Rotate replaces the refresh token only if currentHash is still the
+	// current one, so two refreshes racing with the same token cannot both win.
+	Rotate(ctx context.Context, id uuid.UUID, currentHash, newHash string, expiresAt time.Time) error
+	Revoke(ctx context.Context, id uuid.UUID) error
+	// RevokeAllForUser revokes every session of the global user except keepID
+	// (uuid.Nil keeps none).
+	RevokeAllForUser(ctx context.Context, globalUserID, keepID uuid.UUID) error
+	RevokeAllForMember(ctx context.Context, tenantID, schemaUserID uuid.UUID) error
+	// RevokeWithoutTwoFactor revokes the tenant's sessions of users who have
+	// not enabled two-factor authentication.
+	RevokeWithoutTwoFactor(ctx context.Context, tenantID uuid.UUID) error
+}
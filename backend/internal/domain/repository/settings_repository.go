@@ -0,0 +1,12 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/dcunha/finance/backend/internal/domain/entity"
+)
+
+type SettingsRepository interface {
+	Get(ctx context.Context) (*entity.Settings, error)
+	Update(ctx context.Context, settings *entity.Settings) error
+}
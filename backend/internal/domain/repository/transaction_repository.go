@@ -17,9 +17,19 @@ type TransactionRepository interface {
 	CountByRecurringID(ctx context.Context, recurringID uuid.UUID) (int, error)
 	CountByRecurringIDBeforeDate(ctx context.Context, recurringID uuid.UUID, beforeDate string) (int, error)
 	FindByID(ctx context.Context, id uuid.UUID) (*entity.Transaction, error)
-	FindAll(ctx context.Context, filter entity.TransactionFilter) (*entity.PaginatedTransactions, error)
+	FindAll(ctx context.Context, filter entity.TransactionFilter, projected []entity.Transaction) (*entity.PaginatedTransactions, error)
+	Stream(ctx context.Context, filter entity.TransactionFilter, fn func(entity.Transaction) error) error
 	GetSummary(ctx context.Context, month, year int, userID *uuid.UUID) (*entity.DashboardSummary, error)
 	GetByCategory(ctx context.Context, month, year int, txType string, userID *uuid.UUID) ([]entity.CategoryTotal, error)
+	GetByTag(ctx context.Context, month, year int, txType string, userID *uuid.UUID) ([]entity.TagTotal, error)
+	// GetNonRecurringExpenses sums, per category, the expenses between the two
+	// dates that did not come from a recurring template or installment plan.
+	GetNonRecurringExpenses(ctx context.Context, startDate, endDate string, userID *uuid.UUID) ([]entity.CategoryTotal, error)
 	FindByRecurringIDAndDateRange(ctx context.Context, recurringID uuid.UUID, fromDate, toDate string) ([]entity.Transaction, error)
+	FindByAccountAndDateRange(ctx context.Context, accountID uuid.UUID, fromDate, toDate string) ([]entity.Transaction, error)
 	BulkUpdate(ctx context.Context, txs []entity.Transaction) error
+	CreateTransfer(ctx context.Context, legs []entity.Transaction) error
+	UpdateTransfer(ctx context.Context, legs []entity.Transaction) error
+	FindByTransferID(ctx context.Context, transferID uuid.UUID) ([]entity.Transaction, error)
+	DeleteByTransferID(ctx context.Context, transferID uuid.UUID) error
 }
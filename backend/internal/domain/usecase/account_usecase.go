@@ -0,0 +1,90 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/dcunha/finance/backend/internal/domain"
+	"github.com/dcunha/finance/backend/internal/domain/entity"
+	"github.com/dcunha/finance/backend/internal/domain/repository"
+	"github.com/google/uuid"
+)
+
+type AccountUsecase struct {
+	accountRepo repository.AccountRepository
+}
+
+func NewAccountUsecase(repo repository.AccountRepository) *AccountUsecase {
+	return &AccountUsecase{accountRepo: repo}
+}
+
+func (uc *AccountUsecase) List(ctx context.Context) ([]entity.Account, error) {
+	return uc.accountRepo.FindAll(ctx)
+}
+
+func (uc *AccountUsecase) GetByID(ctx context.Context, id uuid.UUID) (*entity.Account, error) {
+	return uc.accountRepo.FindByID(ctx, id)
+}
+
+func (uc *AccountUsecase) Create(ctx context.Context, account *entity.Account) error {
+	return uc.accountRepo.Create(ctx, account)
+}
+
+// Update saves the account. An empty currency keeps the current one; when it
+// changes, the balance is read again since transactions are then converted.
+func (uc *AccountUsecase) Update(ctx context.Context, id uuid.UUID, name, accountType, currency string, initialBalance entity.Money) (*entity.Account, error) {
+	account, err := uc.accountRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	account.Balance += initialBalance - account.InitialBalance
+	account.Name = name
+	// Card accounts keep their type; they are managed through the card.
+	if account.Type != "credit_card" {
+		account.Type = accountType
+	}
+	account.InitialBalance = initialBalance
+	currencyChanged := currency != "" && currency != account.Currency
+	if currencyChanged {
+		account.Currency = currency
+	}
+	if err := uc.accountRepo.Update(ctx, account); err != nil {
+		return nil, err
+	}
+	if currencyChanged {
+		return uc.accountRepo.FindByID(ctx, id)
+	}
+	return account, nil
+}
+
+func (uc *AccountUsecase) Delete(ctx context.Context, id uuid.UUID) error {
+	account, err := uc.accountRepo.FindByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if account.IsDefault {
+		return domain.ErrForbidden
+	}
+	inUse, err := uc.accountRepo.IsInUse(ctx, id)
+	if err != nil {
+		return err
+	}
+	if inUse {
+		return domain.ErrAccountInUse
+	}
+	return uc.accountRepo.Delete(ctx, id)
+}
+
+// resolveAccountID returns id, or the tenant's default account when id is nil.
+func resolveAccountID(ctx context.Context, repo repository.AccountRepository, id uuid.UUID) (uuid.UUID, error) {
+	if id != uuid.Nil {
+		if _, err := repo.FindByID(ctx, id); err != nil {
+			return uuid.Nil, err
+		}
+		return id, nil
+	}
+	account, err := repo.FindDefault(ctx)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	return account.ID, nil
+}
@@ -11,11 +11,12 @@ import (
 )
 
 type AdminUsecase struct {
-	userRepo repository.UserRepository
+	userRepo    repository.UserRepository
+	sessionRepo repository.SessionRepository
 }
 
-func NewAdminUsecase(userRepo repository.UserRepository) *AdminUsecase {
-	return &AdminUsecase{userRepo: userRepo}
+func NewAdminUsecase(userRepo repository.UserRepository, sessionRepo repository.SessionRepository) *AdminUsecase {
+	return &AdminUsecase{userRepo: userRepo, sessionRepo: sessionRepo}
 }
 
 func (uc *AdminUsecase) ListUsers(ctx context.Context) ([]entity.AdminUser, error) {
@@ -73,14 +74,19 @@ func (uc *AdminUsecase) UpdateUser(ctx context.Context, id uuid.UUID, name, emai
 	}, nil
 }
 
-func (uc *AdminUsecase) DeleteUser(ctx context.Context, id uuid.UUID) error {
+// DeleteUser removes the member and signs them out of the tenant.
+func (uc *AdminUsecase) DeleteUser(ctx context.Context, tenantID, id uuid.UUID) error {
 	if _, err := uc.userRepo.FindByID(ctx, id); err != nil {
 		return err
 	}
-	return uc.userRepo.DeleteUser(ctx, id)
+	if err := uc.userRepo.DeleteUser(ctx, id); err != nil {
+		return err
+	}
+	return uc.sessionRepo.RevokeAllForMember(ctx, tenantID, id)
 }
 
-func (uc *AdminUsecase) ResetPassword(ctx context.Context, id uuid.UUID, newPassword string) error {
+// ResetPassword sets the member's password and signs them out of the tenant.
+func (uc *AdminUsecase) ResetPassword(ctx context.Context, tenantID, id uuid.UUID, newPassword string) error {
 	user, err := uc.userRepo.FindByID(ctx, id)
 	if err != nil {
 		return err
@@ -90,5 +96,8 @@ func (uc *AdminUsecase) ResetPassword(ctx context.Context, id uuid.UUID, newPass
 		return err
 	}
 	user.PasswordHash = string(hash)
-	return uc.userRepo.Update(ctx, user)
+	if err := uc.userRepo.Update(ctx, user); err != nil {
+		return err
+	}
+	return uc.sessionRepo.RevokeAllForMember(ctx, tenantID, id)
 }
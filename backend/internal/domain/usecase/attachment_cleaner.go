@@ -0,0 +1,44 @@
+package usecase
+
+import (
+	"context"
+	"log"
+
+	"github.com/dcunha/finance/backend/internal/domain/repository"
+	"github.com/dcunha/finance/backend/internal/infrastructure/storage"
+	"github.com/google/uuid"
+)
+
+// attachmentCleaner removes the stored files of transactions being deleted.
+// The attachment rows go away with the transaction through the foreign key,
+// so the keys are collected first and the files removed once the delete has
+// succeeded.
+type attachmentCleaner struct {
+	attachmentRepo repository.AttachmentRepository
+	storage        storage.Storage
+}
+
+// keys returns the storage keys of every attachment of the transactions.
+func (c attachmentCleaner) keys(ctx context.Context, transactionIDs ...uuid.UUID) ([]string, error) {
+	var keys []string
+	for _, id := range transactionIDs {
+		attachments, err := c.attachmentRepo.FindByTransactionID(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		for _, a := range attachments {
+			keys = append(keys, a.StorageKey)
+		}
+	}
+	return keys, nil
+}
+
+// remove deletes the files. Failures only leave orphaned files, so they are
+// logged rather than undoing the delete.
+func (c attachmentCleaner) remove(ctx context.Context, keys []string) {
+	for _, key := range keys {
+		if err := c.storage.Delete(ctx, key); err != nil {
+			log.Printf("Attachments: removing %s: %v", key, err)
+		}
+	}
+}
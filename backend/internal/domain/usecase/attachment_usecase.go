@@ -0,0 +1,168 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"mime"
+	"net/http"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/dcunha/finance/backend/internal/domain"
+	"github.com/dcunha/finance/backend/internal/domain/entity"
+	"github.com/dcunha/finance/backend/internal/domain/repository"
+	"github.com/dcunha/finance/backend/internal/infrastructure/storage"
+	"github.com/dcunha/finance/backend/internal/tenant"
+	"github.com/google/uuid"
+)
+
+// MaxAttachmentSize is the largest file accepted as an attachment.
+const MaxAttachmentSize = 10 << 20
+
+// attachmentTypes are the accepted content types, detected from the file's
+// first bytes rather than trusted from the client.
+var attachmentTypes = map[string]bool{
+	"image/jpeg":      true,
+	"image/png":       true,
+	"image/webp":      true,
+	"application/pdf": true,
+}
+
+type AttachmentUsecase struct {
+	attachmentRepo  repository.AttachmentRepository
+	transactionRepo repository.TransactionRepository
+	storage         storage.Storage
+	files           attachmentCleaner
+}
+
+func NewAttachmentUsecase(
+	attachmentRepo repository.AttachmentRepository,
+	transactionRepo repository.TransactionRepository,
+	store storage.Storage,
+) *AttachmentUsecase {
+	return &AttachmentUsecase{
+		attachmentRepo:  attachmentRepo,
+		transactionRepo: transactionRepo,
+		storage:         store,
+		files:           attachmentCleaner{attachmentRepo: attachmentRepo, storage: store},
+	}
+}
+
+func (uc *AttachmentUsecase) List(ctx context.Context, transactionID uuid.UUID) ([]entity.Attachment, error) {
+	if _, err := uc.transactionRepo.FindByID(ctx, transactionID); err != nil {
+		return nil, err
+	}
+	return uc.attachmentRepo.FindByTransactionID(ctx, transactionID)
+}
+
+// Upload stores content as a new attachment of a.TransactionID. The content
+// type is sniffed from the data; the size is counted while storing, so files
+// over MaxAttachmentSize are removed again. Keys are prefixed with the tenant
+// schema to keep each tenant's files apart.
+func (uc *AttachmentUsecase) Upload(ctx context.Context, a *entity.Attachment, content io.Reader) error {
+	if _, err := uc.transactionRepo.FindByID(ctx, a.TransactionID); err != nil {
+		return err
+	}
+
+	head := make([]byte, 512)
+	n, err := io.ReadFull(content, head)
+	if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) && !errors.Is(err, io.EOF) {
+		return err
+	}
+	contentType, _, _ := mime.ParseMediaType(http.DetectContentType(head[:n]))
+	if n == 0 || !attachmentTypes[contentType] {
+		return domain.ErrAttachmentType
+	}
+
+	a.ContentType = contentType
+	a.FileName = attachmentFileName(a.FileName, contentType)
+	a.StorageKey = path.Join(tenant.SchemaFromContext(ctx), a.TransactionID.String(), uuid.NewString())
+
+	body := &countingReader{r: io.LimitReader(io.MultiReader(bytes.NewReader(head[:n]), content), MaxAttachmentSize+1)}
+	if err := uc.storage.Put(ctx, a.StorageKey, body); err != nil {
+		return err
+	}
+	if body.n > MaxAttachmentSize {
+		uc.files.remove(ctx, []string{a.StorageKey})
+		return domain.ErrAttachmentTooLarge
+	}
+	a.Size = body.n
+
+	if err := uc.attachmentRepo.Create(ctx, a); err != nil {
+		uc.files.remove(ctx, []string{a.StorageKey})
+		return err
+	}
+	return nil
+}
+
+// Open returns the attachment and its content. The caller must close the reader.
+func (uc *AttachmentUsecase) Open(ctx context.Context, transactionID, id uuid.UUID) (*entity.Attachment, io.ReadCloser, error) {
+	a, err := uc.find(ctx, transactionID, id)
+	if err != nil {
+		return nil, nil, err
+	}
+	r, err := uc.storage.Open(ctx, a.StorageKey)
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			return nil, nil, domain.ErrNotFound
+		}
+		return nil, nil, err
+	}
+	return a, r, nil
+}
+
+func (uc *AttachmentUsecase) Delete(ctx context.Context, transactionID, id uuid.UUID) error {
+	a, err := uc.find(ctx, transactionID, id)
+	if err != nil {
+		return err
+	}
+	if err := uc.attachmentRepo.Delete(ctx, a.ID); err != nil {
+		return err
+	}
+	uc.files.remove(ctx, []string{a.StorageKey})
+	return nil
+}
+
+// find loads an attachment, treating one that belongs to another transaction
+// as missing.
+func (uc *AttachmentUsecase) find(ctx context.Context, transactionID, id uuid.UUID) (*entity.Attachment, error) {
+	a, err := uc.attachmentRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if a.TransactionID != transactionID {
+		return nil, domain.ErrNotFound
+	}
+	return a, nil
+}
+
+// attachmentFileName keeps only the base name of the uploaded file, falling
+// back to a generic name with the right extension.
+func attachmentFileName(name, contentType string) string {
+	name = strings.TrimSpace(filepath.Base(strings.ReplaceAll(name, `\`, "/")))
+	if name == "" || name == "." || name == "/" {
+		name = "anexo"
+		if exts, _ := mime.ExtensionsByType(contentType); len(exts) > 0 {
+			name += exts[0]
+		}
+	}
+	if len(name) > 255 {
+		name = name[len(name)-255:]
+	}
+	return name
+}
+
+// countingReader counts the bytes read through it.
+type countingReader struct {
+	r io.Reader
+	n int64
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n += int64(n)
+	return n, err
+}
@@ -2,6 +2,9 @@ package usecase
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
 	"time"
 
 	"github.com/dcunha/finance/backend/internal/domain"
@@ -13,39 +16,88 @@ import (
 )
 
 type AuthUsecase struct {
-	userRepo       repository.UserRepository
-	globalUserRepo repository.GlobalUserRepository
-	membershipRepo repository.MembershipRepository
-	jwtSecret      string
+	userRepo         repository.UserRepository
+	globalUserRepo   repository.GlobalUserRepository
+	membershipRepo   repository.MembershipRepository
+	sessionRepo      repository.SessionRepository
+	oidcIdentityRepo repository.OIDCIdentityRepository
+	twoFactor        *TwoFactorUsecase
+	jwtSecret        string
 }
 
+const (
+	// accessTokenTTL is short because revocation only takes effect once the
+	// client has to come back to refresh.
+	accessTokenTTL = 15 * time.Minute
+	// refreshTokenTTL restarts on every refresh, so a session only expires
+	// after this long without use.
+	refreshTokenTTL = 30 * 24 * time.Hour
+	// twoFactorTokenTTL leaves time to install an authenticator app when
+	// enrollment happens during login.
+	twoFactorTokenTTL = 10 * time.Minute
+	// oidcTokenTTL only needs to cover the redirect from the callback to the
+	// frontend and its request back.
+	oidcTokenTTL = time.Minute
+)
+
+// Purposes of the short-lived tokens that carry a login between steps.
+const (
+	purposeSelect         = "select"
+	purposeTwoFactor      = "2fa"
+	purposeTwoFactorSetup = "2fa_setup"
+	purposeOIDC           = "oidc"
+)
+
 func NewAuthUsecase(
 	userRepo repository.UserRepository,
 	globalUserRepo repository.GlobalUserRepository,
 	membershipRepo repository.MembershipRepository,
+	sessionRepo repository.SessionRepository,
+	oidcIdentityRepo repository.OIDCIdentityRepository,
+	twoFactor *TwoFactorUsecase,
 	jwtSecret string,
 ) *AuthUsecase {
 	return &AuthUsecase{
-		userRepo:       userRepo,
-		globalUserRepo: globalUserRepo,
-		membershipRepo: membershipRepo,
-		jwtSecret:      jwtSecret,
+		userRepo:         userRepo,
+		globalUserRepo:   globalUserRepo,
+		membershipRepo:   membershipRepo,
+		sessionRepo:      sessionRepo,
+		oidcIdentityRepo: oidcIdentityRepo,
+		twoFactor:        twoFactor,
+		jwtSecret:        jwtSecret,
 	}
 }
 
+// AuthTokens is a short-lived access token and the refresh token that
+// replaces it.
+type AuthTokens struct {
+	Token        string `json:"token"`
+	RefreshToken string `json:"refresh_token"`
+}
+
 type LoginResult struct {
-	// Single tenant: token + user + tenant_id returned directly
-	Token    string    `json:"token,omitempty"`
-	User     *entity.User `json:"user,omitempty"`
-	TenantID *uuid.UUID   `json:"tenant_id,omitempty"`
+	// Single tenant: tokens + user + tenant_id returned directly
+	Token        string       `json:"token,omitempty"`
+	RefreshToken string       `json:"refresh_token,omitempty"`
+	User         *entity.User `json:"user,omitempty"`
+	TenantID     *uuid.UUID   `json:"tenant_id,omitempty"`
 
 	// Multi-tenant: selector_token + tenants list
 	SelectorToken string                    `json:"selector_token,omitempty"`
 	Tenants       []entity.TenantMembership `json:"tenants,omitempty"`
+
+	// Two-factor step: two_factor_token + what it is for, "verify" a code or
+	// "setup" enrollment first because a tenant requires it
+	TwoFactorToken string `json:"two_factor_token,omitempty"`
+	TwoFactor      string `json:"two_factor,omitempty"`
+
+	// Shown once, when enrollment completes during login
+	RecoveryCodes []string `json:"recovery_codes,omitempty"`
 }
 
 // AuthenticateGlobal validates credentials against global_users and returns either
-// a full JWT (single tenant) or a selector token (multiple tenants).
+// a full JWT (single tenant), a selector token (multiple tenants) or a
+// two-factor token (the code is still missing).
 func (uc *AuthUsecase) AuthenticateGlobal(ctx context.Context, email, password string) (*LoginResult, error) {
 	globalUser, err := uc.globalUserRepo.FindByEmail(ctx, email)
 	if err != nil {
@@ -63,6 +115,129 @@ func (uc *AuthUsecase) AuthenticateGlobal(ctx context.Context, email, password s
 		return nil, domain.ErrEmailNotVerified
 	}
 
+	return uc.continueLogin(ctx, globalUser)
+}
+
+// OIDCProfile is what an OpenID Connect provider verified about the person
+// signing in.
+type OIDCProfile struct {
+	Issuer        string
+	Subject       string
+	Email         string
+	EmailVerified bool
+	Name          string
+}
+
+// AuthenticateOIDC finds the global user of a provider account, linking it by
+// email on first use or creating a user without a password when the email is
+// new, and returns a short-lived token for CompleteOIDC. Only emails the
+// provider verified are trusted.
+//
+// Linking to a user whose email was never verified marks it verified and
+// drops its password: whoever chose that password never proved they own the
+// email, and the provider just did.
+func (uc *AuthUsecase) AuthenticateOIDC(ctx context.Context, profile OIDCProfile) (string, error) {
+	globalUser, err := uc.oidcUser(ctx, profile)
+	if err != nil {
+		return "", err
+	}
+	return uc.generatePurposeToken(globalUser.ID, purposeOIDC, oidcTokenTTL)
+}
+
+// CompleteOIDC continues a login started by AuthenticateOIDC exactly like a
+// password login: two-factor step, single tenant or tenant selector. The token
+// travels in a URL, so it is accepted only once.
+func (uc *AuthUsecase) CompleteOIDC(ctx context.Context, oidcToken string) (*LoginResult, error) {
+	claims, err := uc.parsePurposeClaims(oidcToken, purposeOIDC)
+	if err != nil {
+		return nil, err
+	}
+	globalUserID, err := purposeTokenUser(claims)
+	if err != nil {
+		return nil, err
+	}
+	jtiStr, _ := claims["jti"].(string)
+	jti, err := uuid.Parse(jtiStr)
+	if err != nil {
+		return nil, domain.ErrInvalidCredentials
+	}
+	exp, err := claims.GetExpirationTime()
+	if err != nil || exp == nil {
+		return nil, domain.ErrInvalidCredentials
+	}
+	first, err := uc.oidcIdentityRepo.RecordTokenUse(ctx, jti, exp.Time)
+	if err != nil {
+		return nil, err
+	}
+	if !first {
+		return nil, domain.ErrInvalidCredentials
+	}
+	globalUser, err := uc.globalUserRepo.FindByID(ctx, globalUserID)
+	if err != nil {
+		return nil, err
+	}
+	return uc.continueLogin(ctx, globalUser)
+}
+
+func (uc *AuthUsecase) oidcUser(ctx context.Context, profile OIDCProfile) (*entity.GlobalUser, error) {
+	identity, err := uc.oidcIdentityRepo.Find(ctx, profile.Issuer, profile.Subject)
+	if err == nil {
+		return uc.globalUserRepo.FindByID(ctx, identity.GlobalUserID)
+	}
+	if !errors.Is(err, domain.ErrNotFound) {
+		return nil, err
+	}
+
+	if profile.Email == "" || !profile.EmailVerified {
+		return nil, domain.ErrEmailNotVerified
+	}
+
+	globalUser, err := uc.globalUserRepo.FindByEmail(ctx, profile.Email)
+	switch {
+	case err == nil:
+		if !globalUser.EmailVerified {
+			globalUser.EmailVerified = true
+			globalUser.EmailToken = nil
+			globalUser.EmailTokenExpiresAt = nil
+			globalUser.PasswordHash = ""
+			if err := uc.globalUserRepo.Update(ctx, globalUser); err != nil {
+				return nil, err
+			}
+		}
+	case errors.Is(err, domain.ErrNotFound):
+		name := profile.Name
+		if name == "" {
+			name = profile.Email
+		}
+		globalUser = &entity.GlobalUser{
+			Name:            name,
+			Email:           profile.Email,
+			EmailVerified:   true,
+			MaxOwnedTenants: 1,
+		}
+		if err := uc.globalUserRepo.Create(ctx, globalUser); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, err
+	}
+
+	err = uc.oidcIdentityRepo.Create(ctx, &entity.OIDCIdentity{
+		Issuer:       profile.Issuer,
+		Subject:      profile.Subject,
+		GlobalUserID: globalUser.ID,
+		Email:        profile.Email,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return globalUser, nil
+}
+
+// continueLogin follows a verified password or provider login with the
+// two-factor step, when the user has it enabled or a tenant requires it, or
+// else with the tenant selection.
+func (uc *AuthUsecase) continueLogin(ctx context.Context, globalUser *entity.GlobalUser) (*LoginResult, error) {
 	memberships, err := uc.membershipRepo.FindByGlobalUser(ctx, globalUser.ID)
 	if err != nil {
 		return nil, err
@@ -72,21 +247,47 @@ func (uc *AuthUsecase) AuthenticateGlobal(ctx context.Context, email, password s
 		return nil, domain.ErrNoMemberships
 	}
 
+	purpose, step := "", ""
+	switch {
+	case globalUser.TOTPEnabled:
+		purpose, step = purposeTwoFactor, "verify"
+	case requiresTwoFactor(memberships):
+		purpose, step = purposeTwoFactorSetup, "setup"
+	}
+	if purpose != "" {
+		token, err := uc.generatePurposeToken(globalUser.ID, purpose, twoFactorTokenTTL)
+		if err != nil {
+			return nil, err
+		}
+		return &LoginResult{TwoFactorToken: token, TwoFactor: step}, nil
+	}
+
+	return uc.completeLogin(ctx, globalUser.ID, memberships)
+}
+
+// completeLogin starts a session right away for a single tenant, or returns
+// the selector token for several.
+func (uc *AuthUsecase) completeLogin(ctx context.Context, globalUserID uuid.UUID, memberships []entity.TenantMembership) (*LoginResult, error) {
 	// Single tenant: auto-select
 	if len(memberships) == 1 {
-		token, schemaUserID, role, tenantID, err := uc.selectTenantInternal(ctx, globalUser.ID, memberships[0].TenantID)
+		tokens, membership, err := uc.selectTenantInternal(ctx, globalUserID, memberships[0].TenantID)
 		if err != nil {
 			return nil, err
 		}
 		user := &entity.User{
-			ID:   schemaUserID,
-			Role: role,
+			ID:   membership.SchemaUserID,
+			Role: membership.Role,
 		}
-		return &LoginResult{Token: token, User: user, TenantID: &tenantID}, nil
+		return &LoginResult{
+			Token:        tokens.Token,
+			RefreshToken: tokens.RefreshToken,
+			User:         user,
+			TenantID:     &membership.TenantID,
+		}, nil
 	}
 
 	// Multiple tenants: return selector token
-	selectorToken, err := uc.generateSelectorToken(globalUser.ID)
+	selectorToken, err := uc.generatePurposeToken(globalUserID, purposeSelect, 5*time.Minute)
 	if err != nil {
 		return nil, err
 	}
@@ -94,44 +295,168 @@ func (uc *AuthUsecase) AuthenticateGlobal(ctx context.Context, email, password s
 	return &LoginResult{SelectorToken: selectorToken, Tenants: memberships}, nil
 }
 
-// SelectTenant validates a selector token and returns a full JWT for the chosen tenant.
-func (uc *AuthUsecase) SelectTenant(ctx context.Context, selectorToken string, tenantID uuid.UUID) (string, uuid.UUID, string, error) {
-	claims, err := uc.parseSelectorToken(selectorToken)
+// VerifyTwoFactor finishes a login with a TOTP or recovery code.
+func (uc *AuthUsecase) VerifyTwoFactor(ctx context.Context, twoFactorToken, code string) (*LoginResult, error) {
+	globalUserID, err := uc.parsePurposeToken(twoFactorToken, purposeTwoFactor)
 	if err != nil {
-		return "", uuid.Nil, "", domain.ErrInvalidCredentials
+		return nil, err
+	}
+	globalUser, err := uc.globalUserRepo.FindByID(ctx, globalUserID)
+	if err != nil {
+		return nil, err
+	}
+	if err := uc.twoFactor.verify(ctx, globalUser, code); err != nil {
+		return nil, err
 	}
 
-	globalUserIDStr, _ := claims["global_user_id"].(string)
-	globalUserID, err := uuid.Parse(globalUserIDStr)
+	memberships, err := uc.membershipRepo.FindByGlobalUser(ctx, globalUserID)
+	if err != nil {
+		return nil, err
+	}
+	if len(memberships) == 0 {
+		return nil, domain.ErrNoMemberships
+	}
+	return uc.completeLogin(ctx, globalUserID, memberships)
+}
+
+// SetupTwoFactor starts the enrollment a tenant requires before the login
+// can go on.
+func (uc *AuthUsecase) SetupTwoFactor(ctx context.Context, twoFactorToken string) (*entity.TwoFactorSetup, error) {
+	globalUserID, err := uc.parsePurposeToken(twoFactorToken, purposeTwoFactorSetup)
+	if err != nil {
+		return nil, err
+	}
+	return uc.twoFactor.Setup(ctx, globalUserID)
+}
+
+// EnableTwoFactor confirms the enrollment started by SetupTwoFactor and
+// finishes the login. The result carries the recovery codes.
+func (uc *AuthUsecase) EnableTwoFactor(ctx context.Context, twoFactorToken, code string) (*LoginResult, error) {
+	globalUserID, err := uc.parsePurposeToken(twoFactorToken, purposeTwoFactorSetup)
+	if err != nil {
+		return nil, err
+	}
+	recoveryCodes, err := uc.twoFactor.Enable(ctx, globalUserID, code)
 	if err != nil {
-		return "", uuid.Nil, "", domain.ErrInvalidCredentials
+		return nil, err
 	}
 
-	purpose, _ := claims["purpose"].(string)
-	if purpose != "select" {
-		return "", uuid.Nil, "", domain.ErrInvalidCredentials
+	memberships, err := uc.membershipRepo.FindByGlobalUser(ctx, globalUserID)
+	if err != nil {
+		return nil, err
+	}
+	if len(memberships) == 0 {
+		return nil, domain.ErrNoMemberships
+	}
+	result, err := uc.completeLogin(ctx, globalUserID, memberships)
+	if err != nil {
+		return nil, err
 	}
+	result.RecoveryCodes = recoveryCodes
+	return result, nil
+}
 
-	token, schemaUserID, role, _, err := uc.selectTenantInternal(ctx, globalUserID, tenantID)
+// SelectTenant validates a selector token and starts a session in the chosen tenant.
+func (uc *AuthUsecase) SelectTenant(ctx context.Context, selectorToken string, tenantID uuid.UUID) (*AuthTokens, *entity.Membership, error) {
+	globalUserID, err := uc.parsePurposeToken(selectorToken, purposeSelect)
 	if err != nil {
-		return "", uuid.Nil, "", err
+		return nil, nil, err
 	}
 
-	return token, schemaUserID, role, nil
+	return uc.selectTenantInternal(ctx, globalUserID, tenantID)
 }
 
-func (uc *AuthUsecase) selectTenantInternal(ctx context.Context, globalUserID, tenantID uuid.UUID) (string, uuid.UUID, string, uuid.UUID, error) {
+func (uc *AuthUsecase) selectTenantInternal(ctx context.Context, globalUserID, tenantID uuid.UUID) (*AuthTokens, *entity.Membership, error) {
 	membership, err := uc.membershipRepo.FindByGlobalUserAndTenant(ctx, globalUserID, tenantID)
 	if err != nil {
-		return "", uuid.Nil, "", uuid.Nil, domain.ErrForbidden
+		return nil, nil, domain.ErrForbidden
+	}
+
+	refreshToken, err := generateRandomToken()
+	if err != nil {
+		return nil, nil, err
+	}
+	session := &entity.Session{
+		GlobalUserID:     globalUserID,
+		TenantID:         tenantID,
+		SchemaUserID:     membership.SchemaUserID,
+		RefreshTokenHash: hashToken(refreshToken),
+		ExpiresAt:        time.Now().Add(refreshTokenTTL),
+	}
+	if err := uc.sessionRepo.Create(ctx, session); err != nil {
+		return nil, nil, err
 	}
 
-	token, err := uc.generateToken(membership.SchemaUserID, tenantID, globalUserID, membership.Role)
+	token, err := uc.generateToken(membership.SchemaUserID, tenantID, globalUserID, membership.Role, session.ID)
 	if err != nil {
-		return "", uuid.Nil, "", uuid.Nil, err
+		return nil, nil, err
 	}
 
-	return token, membership.SchemaUserID, membership.Role, tenantID, nil
+	return &AuthTokens{Token: token, RefreshToken: refreshToken}, membership, nil
+}
+
+// Refresh exchanges a refresh token for a new access token and a new refresh
+// token; the one presented stops working. Presenting a token that was already
+// rotated means it leaked or was replayed, so the whole session is revoked.
+// The role is read again from the membership, and a member who has left the
+// tenant loses the session.
+func (uc *AuthUsecase) Refresh(ctx context.Context, refreshToken string) (*AuthTokens, error) {
+	hash := hashToken(refreshToken)
+	session, err := uc.sessionRepo.FindByTokenHash(ctx, hash)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, domain.ErrSessionRevoked
+		}
+		return nil, err
+	}
+	if !session.Active(time.Now()) {
+		return nil, domain.ErrSessionRevoked
+	}
+	if session.RefreshTokenHash != hash {
+		if err := uc.sessionRepo.Revoke(ctx, session.ID); err != nil {
+			return nil, err
+		}
+		return nil, domain.ErrSessionRevoked
+	}
+
+	membership, err := uc.membershipRepo.FindByGlobalUserAndTenant(ctx, session.GlobalUserID, session.TenantID)
+	if err != nil {
+		if !errors.Is(err, domain.ErrNotFound) {
+			return nil, err
+		}
+		if err := uc.sessionRepo.Revoke(ctx, session.ID); err != nil {
+			return nil, err
+		}
+		return nil, domain.ErrSessionRevoked
+	}
+
+	newRefreshToken, err := generateRandomToken()
+	if err != nil {
+		return nil, err
+	}
+	if err := uc.sessionRepo.Rotate(ctx, session.ID, hash, hashToken(newRefreshToken), time.Now().Add(refreshTokenTTL)); err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, domain.ErrSessionRevoked
+		}
+		return nil, err
+	}
+
+	token, err := uc.generateToken(membership.SchemaUserID, session.TenantID, session.GlobalUserID, membership.Role, session.ID)
+	if err != nil {
+		return nil, err
+	}
+	return &AuthTokens{Token: token, RefreshToken: newRefreshToken}, nil
+}
+
+// Logout revokes the current session.
+func (uc *AuthUsecase) Logout(ctx context.Context, sessionID uuid.UUID) error {
+	return uc.sessionRepo.Revoke(ctx, sessionID)
+}
+
+// LogoutAll revokes every session of the user, in every tenant and on every
+// device, including the current one.
+func (uc *AuthUsecase) LogoutAll(ctx context.Context, globalUserID uuid.UUID) error {
+	return uc.sessionRepo.RevokeAllForUser(ctx, globalUserID, uuid.Nil)
 }
 
 func (uc *AuthUsecase) GetProfile(ctx context.Context, userID uuid.UUID) (*entity.User, error) {
@@ -151,7 +476,9 @@ func (uc *AuthUsecase) UpdateProfile(ctx context.Context, userID uuid.UUID, name
 	return user, nil
 }
 
-func (uc *AuthUsecase) ChangePassword(ctx context.Context, userID uuid.UUID, oldPassword, newPassword string) error {
+// ChangePassword also signs the user out of every other session; the one that
+// made the change stays.
+func (uc *AuthUsecase) ChangePassword(ctx context.Context, userID, sessionID uuid.UUID, oldPassword, newPassword string) error {
 	user, err := uc.userRepo.FindByID(ctx, userID)
 	if err != nil {
 		return err
@@ -164,34 +491,59 @@ func (uc *AuthUsecase) ChangePassword(ctx context.Context, userID uuid.UUID, old
 		return err
 	}
 	user.PasswordHash = string(hash)
-	return uc.userRepo.Update(ctx, user)
+	if err := uc.userRepo.Update(ctx, user); err != nil {
+		return err
+	}
+
+	session, err := uc.sessionRepo.FindByID(ctx, sessionID)
+	if err != nil {
+		return err
+	}
+	return uc.sessionRepo.RevokeAllForUser(ctx, session.GlobalUserID, session.ID)
 }
 
-func (uc *AuthUsecase) generateToken(schemaUserID, tenantID, globalUserID uuid.UUID, role string) (string, error) {
+func (uc *AuthUsecase) generateToken(schemaUserID, tenantID, globalUserID uuid.UUID, role string, sessionID uuid.UUID) (string, error) {
 	claims := jwt.MapClaims{
 		"sub":            schemaUserID.String(),
 		"tenant_id":      tenantID.String(),
 		"global_user_id": globalUserID.String(),
 		"role":           role,
-		"exp":            time.Now().Add(72 * time.Hour).Unix(),
+		"sid":            sessionID.String(),
+		"exp":            time.Now().Add(accessTokenTTL).Unix(),
 		"iat":            time.Now().Unix(),
 	}
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
 	return token.SignedString([]byte(uc.jwtSecret))
 }
 
-func (uc *AuthUsecase) generateSelectorToken(globalUserID uuid.UUID) (string, error) {
+// generatePurposeToken issues a short-lived token that only carries the
+// global user from one login step to the next. Its jti lets a step accept the
+// token only once.
+func (uc *AuthUsecase) generatePurposeToken(globalUserID uuid.UUID, purpose string, ttl time.Duration) (string, error) {
 	claims := jwt.MapClaims{
 		"global_user_id": globalUserID.String(),
-		"purpose":        "select",
-		"exp":            time.Now().Add(5 * time.Minute).Unix(),
+		"purpose":        purpose,
+		"jti":            uuid.NewString(),
+		"exp":            time.Now().Add(ttl).Unix(),
 		"iat":            time.Now().Unix(),
 	}
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
 	return token.SignedString([]byte(uc.jwtSecret))
 }
 
-func (uc *AuthUsecase) parseSelectorToken(tokenStr string) (jwt.MapClaims, error) {
+// parsePurposeToken returns the global user of a token from
+// generatePurposeToken, which must have been issued for purpose.
+func (uc *AuthUsecase) parsePurposeToken(tokenStr, purpose string) (uuid.UUID, error) {
+	claims, err := uc.parsePurposeClaims(tokenStr, purpose)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	return purposeTokenUser(claims)
+}
+
+// parsePurposeClaims returns the claims of a valid token from
+// generatePurposeToken, which must have been issued for purpose.
+func (uc *AuthUsecase) parsePurposeClaims(tokenStr, purpose string) (jwt.MapClaims, error) {
 	token, err := jwt.Parse(tokenStr, func(t *jwt.Token) (interface{}, error) {
 		if _, ok := t.Method.(*jwt.SigningMethodHMAC); !ok {
 			return nil, jwt.ErrSignatureInvalid
@@ -205,5 +557,25 @@ func (uc *AuthUsecase) parseSelectorToken(tokenStr string) (jwt.MapClaims, error
 	if !ok {
 		return nil, domain.ErrInvalidCredentials
 	}
+	if p, _ := claims["purpose"].(string); p != purpose {
+		return nil, domain.ErrInvalidCredentials
+	}
 	return claims, nil
 }
+
+// purposeTokenUser returns the global user carried by a purpose token.
+func purposeTokenUser(claims jwt.MapClaims) (uuid.UUID, error) {
+	globalUserIDStr, _ := claims["global_user_id"].(string)
+	globalUserID, err := uuid.Parse(globalUserIDStr)
+	if err != nil {
+		return uuid.Nil, domain.ErrInvalidCredentials
+	}
+	return globalUserID, nil
+}
+
+// hashToken is how refresh tokens and recovery codes are stored, so a leaked
+// table cannot be replayed.
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
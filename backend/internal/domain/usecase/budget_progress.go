@@ -0,0 +1,232 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/dcunha/finance/backend/internal/domain/entity"
+	"github.com/google/uuid"
+)
+
+// evaluatedBudget is a budget with the periods counted towards its progress:
+// the current one, preceded by every earlier one when unspent amounts roll
+// over.
+type evaluatedBudget struct {
+	budget  entity.Budget
+	periods []entity.BudgetPeriod
+}
+
+// getBudgetsProgress returns the progress of every budget active in the given
+// month, each for the period containing the reference date: today in the
+// current month, the month's last day otherwise. A monthly budget is left out
+// when limits already has an expense limit for the same category, since the
+// explicit limit for a month takes precedence over the recurring one.
+func (uc *DashboardUsecase) getBudgetsProgress(ctx context.Context, month, year int, userID *uuid.UUID, limits []entity.LimitProgress) ([]entity.LimitProgress, error) {
+	budgets, err := uc.budgetRepo.FindAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	_, ref := monthBounds(month, year)
+	if today := time.Now(); today.Year() == year && int(today.Month()) == month {
+		ref = time.Date(year, time.Month(month), today.Day(), 0, 0, 0, 0, time.UTC)
+	}
+
+	limited := make(map[string]bool, len(limits))
+	for _, lp := range limits {
+		limited[categoryKey(lp.Limit.CategoryID)] = true
+	}
+
+	var evaluated []evaluatedBudget
+	for _, b := range budgets {
+		if userID != nil && b.UserID != *userID {
+			continue
+		}
+		if b.Period == "monthly" && limited[categoryKey(b.CategoryID)] {
+			continue
+		}
+		periods, err := budgetPeriods(b, ref)
+		if err != nil {
+			return nil, err
+		}
+		if len(periods) == 0 {
+			continue
+		}
+		if err := uc.budgetRepo.FillPeriodTotals(ctx, &b, periods, userID); err != nil {
+			return nil, err
+		}
+		evaluated = append(evaluated, evaluatedBudget{budget: b, periods: periods})
+	}
+	if len(evaluated) == 0 {
+		return []entity.LimitProgress{}, nil
+	}
+
+	if err := uc.addProjectedToBudgets(ctx, evaluated, userID); err != nil {
+		return nil, err
+	}
+
+	progress := make([]entity.LimitProgress, 0, len(evaluated))
+	for _, eb := range evaluated {
+		progress = append(progress, budgetProgress(eb, month, year))
+	}
+	return progress, nil
+}
+
+// addProjectedToBudgets counts projected recurring occurrences into the
+// periods they fall in, projecting once over the span of all periods.
+func (uc *DashboardUsecase) addProjectedToBudgets(ctx context.Context, evaluated []evaluatedBudget, userID *uuid.UUID) error {
+	from, to := evaluated[0].periods[0].Start, evaluated[0].periods[len(evaluated[0].periods)-1].End
+	for _, eb := range evaluated[1:] {
+		from = min(from, eb.periods[0].Start)
+		to = max(to, eb.periods[len(eb.periods)-1].End)
+	}
+	fromDate, _ := time.Parse("2006-01-02", from)
+	toDate, _ := time.Parse("2006-01-02", to)
+
+	projected, err := uc.projector.project(ctx, fromDate, toDate, userID)
+	if err != nil {
+		return err
+	}
+	projected, err = uc.currencies.toBase(ctx, projected)
+	if err != nil {
+		return err
+	}
+
+	for _, eb := range evaluated {
+		b := eb.budget
+		for _, tx := range projected {
+			var p *entity.BudgetPeriod
+			for i := range eb.periods {
+				if eb.periods[i].Start <= tx.Date && tx.Date <= eb.periods[i].End {
+					p = &eb.periods[i]
+					break
+				}
+			}
+			if p == nil {
+				continue
+			}
+			switch {
+			case tx.Type == "expense" && (b.CategoryID == nil || *b.CategoryID == tx.CategoryID):
+				p.Spent += tx.Amount
+			case tx.Type == "income" && (b.IncomeCategoryID == nil || *b.IncomeCategoryID == tx.CategoryID):
+				p.Income += tx.Amount
+			}
+		}
+	}
+	return nil
+}
+
+// budgetProgress carries what is left of each period into the next one, when
+// the budget rolls over, and reports the last period.
+func budgetProgress(eb evaluatedBudget, month, year int) entity.LimitProgress {
+	b := eb.budget
+	var carry, amount entity.Money
+	var current entity.BudgetPeriod
+	for i, p := range eb.periods {
+		amount = budgetAmount(b, p)
+		if i == len(eb.periods)-1 {
+			current = p
+			break
+		}
+		carry = max(amount+carry-p.Spent, 0)
+	}
+
+	lp := entity.LimitProgress{
+		Limit: entity.ExpenseLimit{
+			UserID:       b.UserID,
+			CategoryID:   b.CategoryID,
+			CategoryName: b.CategoryName,
+			Month:        month,
+			Year:         year,
+			Amount:       amount,
+		},
+		Budget:      &b,
+		PeriodStart: current.Start,
+		PeriodEnd:   current.End,
+		Rollover:    carry,
+		Spent:       current.Spent,
+		Remaining:   max(amount+carry-current.Spent, 0),
+	}
+	if available := amount + carry; available > 0 {
+		lp.Percentage = float64(current.Spent) / float64(available) * 100
+	}
+	return lp
+}
+
+// budgetAmount is the fixed amount of the budget or its share of the
+// period's income.
+func budgetAmount(b entity.Budget, p entity.BudgetPeriod) entity.Money {
+	if b.Amount != nil {
+		return *b.Amount
+	}
+	if b.IncomePercentage != nil {
+		return p.Income.MulRate(*b.IncomePercentage / 100)
+	}
+	return 0
+}
+
+// budgetPeriods returns the periods to evaluate for the period containing
+// ref, or none when the budget is not active then. Without rollover that is
+// only the current period; with it, every period since the one containing the
+// start date.
+func budgetPeriods(b entity.Budget, ref time.Time) ([]entity.BudgetPeriod, error) {
+	start, err := time.Parse("2006-01-02", b.StartDate)
+	if err != nil {
+		return nil, err
+	}
+	refStart, refEnd := periodBounds(b.Period, ref)
+	if start.After(refEnd) {
+		return nil, nil
+	}
+	if b.EndDate != nil {
+		end, err := time.Parse("2006-01-02", *b.EndDate)
+		if err != nil {
+			return nil, err
+		}
+		if end.Before(refStart) {
+			return nil, nil
+		}
+	}
+
+	first := refStart
+	if b.Rollover {
+		first, _ = periodBounds(b.Period, start)
+	}
+	var periods []entity.BudgetPeriod
+	for pStart := first; !pStart.After(refStart); {
+		_, pEnd := periodBounds(b.Period, pStart)
+		periods = append(periods, entity.BudgetPeriod{
+			Start: pStart.Format("2006-01-02"),
+			End:   pEnd.Format("2006-01-02"),
+		})
+		pStart = pEnd.AddDate(0, 0, 1)
+	}
+	return periods, nil
+}
+
+// periodBounds returns the first and last day of the period containing date.
+// Weeks start on Monday; quarters and years follow the calendar.
+func periodBounds(period string, date time.Time) (time.Time, time.Time) {
+	date = time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC)
+	switch period {
+	case "weekly":
+		first := date.AddDate(0, 0, -((int(date.Weekday()) + 6) % 7))
+		return first, first.AddDate(0, 0, 6)
+	case "quarterly":
+		first := time.Date(date.Year(), date.Month()-(date.Month()-1)%3, 1, 0, 0, 0, 0, time.UTC)
+		return first, first.AddDate(0, 3, -1)
+	case "yearly":
+		first := time.Date(date.Year(), time.January, 1, 0, 0, 0, 0, time.UTC)
+		return first, first.AddDate(1, 0, -1)
+	default:
+		return monthBounds(int(date.Month()), date.Year())
+	}
+}
+
+// categoryKey identifies a category in maps, with "" for all categories.
+func categoryKey(id *uuid.UUID) string {
+	if id == nil {
+		return ""
+	}
+	return id.String()
+}
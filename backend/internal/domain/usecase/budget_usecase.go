@@ -0,0 +1,92 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/dcunha/finance/backend/internal/domain"
+	"github.com/dcunha/finance/backend/internal/domain/entity"
+	"github.com/dcunha/finance/backend/internal/domain/repository"
+	"github.com/google/uuid"
+)
+
+type BudgetUsecase struct {
+	budgetRepo repository.BudgetRepository
+}
+
+func NewBudgetUsecase(budgetRepo repository.BudgetRepository) *BudgetUsecase {
+	return &BudgetUsecase{budgetRepo: budgetRepo}
+}
+
+func (uc *BudgetUsecase) List(ctx context.Context) ([]entity.Budget, error) {
+	return uc.budgetRepo.FindAll(ctx)
+}
+
+func (uc *BudgetUsecase) GetByID(ctx context.Context, id uuid.UUID) (*entity.Budget, error) {
+	return uc.budgetRepo.FindByID(ctx, id)
+}
+
+func (uc *BudgetUsecase) Create(ctx context.Context, budget *entity.Budget) error {
+	if err := validateBudget(budget); err != nil {
+		return err
+	}
+	budget.AlertThresholds = normalizeThresholds(budget.AlertThresholds)
+	if err := uc.budgetRepo.Create(ctx, budget); err != nil {
+		return err
+	}
+	return uc.reload(ctx, budget)
+}
+
+// Update replaces everything but the owner; nil alert thresholds keep the
+// current ones. Changing the amount, the period or
+// the start date also changes past periods and so any rollover carried from
+// them.
+func (uc *BudgetUsecase) Update(ctx context.Context, budget *entity.Budget) error {
+	if err := validateBudget(budget); err != nil {
+		return err
+	}
+	existing, err := uc.budgetRepo.FindByID(ctx, budget.ID)
+	if err != nil {
+		return err
+	}
+	budget.UserID = existing.UserID
+	budget.CreatedAt = existing.CreatedAt
+	if budget.AlertThresholds == nil {
+		budget.AlertThresholds = existing.AlertThresholds
+	} else {
+		budget.AlertThresholds = normalizeThresholds(budget.AlertThresholds)
+	}
+	if err := uc.budgetRepo.Update(ctx, budget); err != nil {
+		return err
+	}
+	return uc.reload(ctx, budget)
+}
+
+func (uc *BudgetUsecase) Delete(ctx context.Context, id uuid.UUID) error {
+	return uc.budgetRepo.Delete(ctx, id)
+}
+
+// reload fills in the category names after a write.
+func (uc *BudgetUsecase) reload(ctx context.Context, budget *entity.Budget) error {
+	stored, err := uc.budgetRepo.FindByID(ctx, budget.ID)
+	if err != nil {
+		return err
+	}
+	*budget = *stored
+	return nil
+}
+
+// validateBudget checks that exactly one of the amount and the income
+// percentage is set and that the budget does not end before it starts.
+func validateBudget(b *entity.Budget) error {
+	if (b.Amount == nil) == (b.IncomePercentage == nil) {
+		return domain.ErrInvalidBudget
+	}
+	if b.IncomePercentage == nil && b.IncomeCategoryID != nil {
+		return domain.ErrInvalidBudget
+	}
+	// Both dates are YYYY-MM-DD, so they compare as strings.
+	if b.EndDate != nil && *b.EndDate < b.StartDate {
+		return domain.ErrInvalidBudget
+	}
+	return nil
+}
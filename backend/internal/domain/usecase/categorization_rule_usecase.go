@@ -0,0 +1,169 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/dcunha/finance/backend/internal/domain"
+	"github.com/dcunha/finance/backend/internal/domain/entity"
+	"github.com/dcunha/finance/backend/internal/domain/repository"
+	"github.com/google/uuid"
+)
+
+type CategorizationRuleUsecase struct {
+	ruleRepo        repository.CategorizationRuleRepository
+	categoryRepo    repository.CategoryRepository
+	accountRepo     repository.AccountRepository
+	transactionRepo repository.TransactionRepository
+	rules           categorizer
+}
+
+func NewCategorizationRuleUsecase(
+	ruleRepo repository.CategorizationRuleRepository,
+	categoryRepo repository.CategoryRepository,
+	accountRepo repository.AccountRepository,
+	transactionRepo repository.TransactionRepository,
+) *CategorizationRuleUsecase {
+	return &CategorizationRuleUsecase{
+		ruleRepo:        ruleRepo,
+		categoryRepo:    categoryRepo,
+		accountRepo:     accountRepo,
+		transactionRepo: transactionRepo,
+		rules:           categorizer{ruleRepo: ruleRepo},
+	}
+}
+
+func (uc *CategorizationRuleUsecase) List(ctx context.Context) ([]entity.CategorizationRule, error) {
+	return uc.ruleRepo.FindAll(ctx)
+}
+
+func (uc *CategorizationRuleUsecase) GetByID(ctx context.Context, id uuid.UUID) (*entity.CategorizationRule, error) {
+	return uc.ruleRepo.FindByID(ctx, id)
+}
+
+func (uc *CategorizationRuleUsecase) Create(ctx context.Context, rule *entity.CategorizationRule) error {
+	if err := uc.validate(ctx, rule); err != nil {
+		return err
+	}
+	return uc.ruleRepo.Create(ctx, rule)
+}
+
+// Update replaces the conditions and target of a rule; creator and timestamps are kept.
+func (uc *CategorizationRuleUsecase) Update(ctx context.Context, id uuid.UUID, upd *entity.CategorizationRule) (*entity.CategorizationRule, error) {
+	rule, err := uc.ruleRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	upd.ID = rule.ID
+	upd.UserID = rule.UserID
+	upd.CreatedAt = rule.CreatedAt
+	if err := uc.validate(ctx, upd); err != nil {
+		return nil, err
+	}
+	if err := uc.ruleRepo.Update(ctx, upd); err != nil {
+		return nil, err
+	}
+	return upd, nil
+}
+
+func (uc *CategorizationRuleUsecase) Delete(ctx context.Context, id uuid.UUID) error {
+	return uc.ruleRepo.Delete(ctx, id)
+}
+
+// validate requires at least one condition and a category usable for the
+// rule's type. A rule without a type inherits it from an income-only or
+// expense-only category, so it never assigns an expense category to income.
+func (uc *CategorizationRuleUsecase) validate(ctx context.Context, rule *entity.CategorizationRule) error {
+	if rule.DescriptionContains == "" && rule.MinAmount == nil && rule.MaxAmount == nil && rule.AccountID == nil {
+		return domain.ErrInvalidRule
+	}
+	if rule.MinAmount != nil && rule.MaxAmount != nil && *rule.MinAmount > *rule.MaxAmount {
+		return domain.ErrInvalidRule
+	}
+
+	cat, err := uc.categoryRepo.FindByID(ctx, rule.CategoryID)
+	if err != nil {
+		return err
+	}
+	if cat.Type != "both" {
+		if rule.Type == "" {
+			rule.Type = cat.Type
+		} else if rule.Type != cat.Type {
+			return domain.ErrInvalidRule
+		}
+	}
+	rule.CategoryName = cat.Name
+
+	if rule.AccountID != nil {
+		if _, err := uc.accountRepo.FindByID(ctx, *rule.AccountID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Apply re-runs the rules over stored transactions in the date range (either
+// bound may be empty). A transaction already in the rule's category or in one
+// of its subcategories is left alone, so a manual refinement such as
+// "Transporte > Táxi" is not undone by a rule targeting "Transporte".
+// Transfers and occurrences of recurring templates are skipped; the template
+// decides their category.
+func (uc *CategorizationRuleUsecase) Apply(ctx context.Context, startDate, endDate string) (*entity.RuleApplyResult, error) {
+	rules, err := uc.rules.load(ctx)
+	if err != nil {
+		return nil, err
+	}
+	result := &entity.RuleApplyResult{}
+	if len(rules) == 0 {
+		return result, nil
+	}
+
+	cats, err := uc.categoryRepo.FindAll(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+	parents := make(map[uuid.UUID]*uuid.UUID, len(cats))
+	for _, c := range cats {
+		parents[c.ID] = c.ParentID
+	}
+
+	var changed []entity.Transaction
+	filter := entity.TransactionFilter{StartDate: startDate, EndDate: endDate}
+	err = uc.transactionRepo.Stream(ctx, filter, func(tx entity.Transaction) error {
+		if tx.Type == "transfer" || tx.RecurringID != nil {
+			return nil
+		}
+		rule := rules.match(tx.Type, tx.Description, tx.Amount, tx.AccountID)
+		if rule == nil || isWithinCategory(parents, tx.CategoryID, rule.CategoryID) {
+			return nil
+		}
+		tx.CategoryID = rule.CategoryID
+		changed = append(changed, tx)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(changed) > 0 {
+		if err := uc.transactionRepo.BulkUpdate(ctx, changed); err != nil {
+			return nil, err
+		}
+	}
+	result.Updated = len(changed)
+	return result, nil
+}
+
+// isWithinCategory reports whether id is ancestorID or one of its descendants.
+func isWithinCategory(parents map[uuid.UUID]*uuid.UUID, id, ancestorID uuid.UUID) bool {
+	for seen := 0; seen <= len(parents); seen++ {
+		if id == ancestorID {
+			return true
+		}
+		parent, ok := parents[id]
+		if !ok || parent == nil {
+			return false
+		}
+		id = *parent
+	}
+	return false
+}
@@ -0,0 +1,89 @@
+package usecase
+
+import (
+	"context"
+	"strings"
+
+	"github.com/dcunha/finance/backend/internal/domain/entity"
+	"github.com/dcunha/finance/backend/internal/domain/repository"
+	"github.com/google/uuid"
+)
+
+// categorizer picks categories from the tenant's categorization rules.
+type categorizer struct {
+	ruleRepo repository.CategorizationRuleRepository
+}
+
+// ruleSet holds the active rules in evaluation order.
+type ruleSet []entity.CategorizationRule
+
+func (c categorizer) load(ctx context.Context) (ruleSet, error) {
+	rules, err := c.ruleRepo.FindAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	active := make(ruleSet, 0, len(rules))
+	for _, r := range rules {
+		if r.IsActive {
+			r.DescriptionContains = normalizeText(r.DescriptionContains)
+			active = append(active, r)
+		}
+	}
+	return active, nil
+}
+
+// categorize fills in the category of tx from the first matching rule when it
+// has none. Transfers are left alone.
+func (c categorizer) categorize(ctx context.Context, tx *entity.Transaction) error {
+	if tx.CategoryID != uuid.Nil || tx.Type == "transfer" {
+		return nil
+	}
+	rules, err := c.load(ctx)
+	if err != nil {
+		return err
+	}
+	if rule := rules.match(tx.Type, tx.Description, tx.Amount, tx.AccountID); rule != nil {
+		tx.CategoryID = rule.CategoryID
+	}
+	return nil
+}
+
+// match returns the first rule whose conditions all hold, or nil.
+func (rs ruleSet) match(txType, description string, amount entity.Money, accountID uuid.UUID) *entity.CategorizationRule {
+	normalized := normalizeText(description)
+	for i := range rs {
+		r := &rs[i]
+		if r.Type != "" && r.Type != txType {
+			continue
+		}
+		if r.AccountID != nil && *r.AccountID != accountID {
+			continue
+		}
+		if r.MinAmount != nil && amount < *r.MinAmount {
+			continue
+		}
+		if r.MaxAmount != nil && amount > *r.MaxAmount {
+			continue
+		}
+		if r.DescriptionContains != "" && !strings.Contains(normalized, r.DescriptionContains) {
+			continue
+		}
+		return r
+	}
+	return nil
+}
+
+var accentReplacer = strings.NewReplacer(
+	"á", "a", "à", "a", "â", "a", "ã", "a", "ä", "a",
+	"é", "e", "è", "e", "ê", "e", "ë", "e",
+	"í", "i", "ì", "i", "î", "i", "ï", "i",
+	"ó", "o", "ò", "o", "ô", "o", "õ", "o", "ö", "o",
+	"ú", "u", "ù", "u", "û", "u", "ü", "u",
+	"ç", "c", "ñ", "n",
+)
+
+// normalizeText lowercases s and strips the accents used in Portuguese, since
+// bank statements usually drop them ("FARMACIA" should match "Farmácia").
+func normalizeText(s string) string {
+	return accentReplacer.Replace(strings.ToLower(strings.TrimSpace(s)))
+}
@@ -0,0 +1,172 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/dcunha/finance/backend/internal/domain"
+	"github.com/dcunha/finance/backend/internal/domain/entity"
+	"github.com/dcunha/finance/backend/internal/domain/repository"
+	"github.com/google/uuid"
+)
+
+type CreditCardUsecase struct {
+	creditCardRepo  repository.CreditCardRepository
+	accountRepo     repository.AccountRepository
+	transactionRepo repository.TransactionRepository
+	transactionUC   *TransactionUsecase
+	recurringUC     *RecurringTransactionUsecase
+	transferUC      *TransferUsecase
+}
+
+func NewCreditCardUsecase(
+	creditCardRepo repository.CreditCardRepository,
+	accountRepo repository.AccountRepository,
+	transactionRepo repository.TransactionRepository,
+	transactionUC *TransactionUsecase,
+	recurringUC *RecurringTransactionUsecase,
+	transferUC *TransferUsecase,
+) *CreditCardUsecase {
+	return &CreditCardUsecase{
+		creditCardRepo:  creditCardRepo,
+		accountRepo:     accountRepo,
+		transactionRepo: transactionRepo,
+		transactionUC:   transactionUC,
+		recurringUC:     recurringUC,
+		transferUC:      transferUC,
+	}
+}
+
+func (uc *CreditCardUsecase) List(ctx context.Context) ([]entity.CreditCard, error) {
+	return uc.creditCardRepo.FindAll(ctx)
+}
+
+func (uc *CreditCardUsecase) GetByID(ctx context.Context, id uuid.UUID) (*entity.CreditCard, error) {
+	return uc.creditCardRepo.FindByID(ctx, id)
+}
+
+func (uc *CreditCardUsecase) Create(ctx context.Context, card *entity.CreditCard, userID uuid.UUID) error {
+	return uc.creditCardRepo.Create(ctx, card, userID)
+}
+
+// Update changes the card. New closing/due days apply to statements created
+// from now on; existing statements keep their dates.
+func (uc *CreditCardUsecase) Update(ctx context.Context, card *entity.CreditCard) (*entity.CreditCard, error) {
+	if _, err := uc.creditCardRepo.FindByID(ctx, card.ID); err != nil {
+		return nil, err
+	}
+	if err := uc.creditCardRepo.Update(ctx, card); err != nil {
+		return nil, err
+	}
+	return uc.creditCardRepo.FindByID(ctx, card.ID)
+}
+
+// Delete removes the card and its backing account, which must have no purchases.
+func (uc *CreditCardUsecase) Delete(ctx context.Context, id uuid.UUID) error {
+	card, err := uc.creditCardRepo.FindByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	inUse, err := uc.accountRepo.IsInUse(ctx, card.AccountID)
+	if err != nil {
+		return err
+	}
+	if inUse {
+		return domain.ErrAccountInUse
+	}
+	return uc.accountRepo.Delete(ctx, card.AccountID)
+}
+
+// Purchase records a card purchase. With more than one installment it becomes
+// a monthly installment series whose installments all land on their statements.
+// It returns the stored transactions.
+func (uc *CreditCardUsecase) Purchase(ctx context.Context, cardID uuid.UUID, tx *entity.Transaction, installments int) ([]entity.Transaction, error) {
+	card, err := uc.creditCardRepo.FindByID(ctx, cardID)
+	if err != nil {
+		return nil, err
+	}
+	tx.AccountID = card.AccountID
+	tx.Type = "expense"
+
+	if installments <= 1 {
+		if err := uc.transactionUC.Create(ctx, tx); err != nil {
+			return nil, err
+		}
+		return []entity.Transaction{*tx}, nil
+	}
+
+	rt := &entity.RecurringTransaction{
+		UserID:         tx.UserID,
+		CategoryID:     tx.CategoryID,
+		AccountID:      card.AccountID,
+		Type:           tx.Type,
+		Amount:         tx.Amount,
+		Description:    tx.Description,
+		Frequency:      "monthly",
+		StartDate:      tx.Date,
+		MaxOccurrences: &installments,
+	}
+	if err := uc.recurringUC.Create(ctx, rt); err != nil {
+		return nil, err
+	}
+
+	start, _ := time.Parse("2006-01-02", rt.StartDate)
+	return uc.transactionRepo.FindByRecurringIDAndDateRange(ctx, rt.ID, rt.StartDate, start.AddDate(0, installments, 0).Format("2006-01-02"))
+}
+
+func (uc *CreditCardUsecase) ListStatements(ctx context.Context, cardID uuid.UUID) ([]entity.CreditCardStatement, error) {
+	if _, err := uc.creditCardRepo.FindByID(ctx, cardID); err != nil {
+		return nil, err
+	}
+	statements, err := uc.creditCardRepo.FindStatements(ctx, cardID)
+	if err != nil {
+		return nil, err
+	}
+	today := time.Now().Format("2006-01-02")
+	for i := range statements {
+		statements[i].Status = statementStatus(&statements[i], today)
+	}
+	return statements, nil
+}
+
+func (uc *CreditCardUsecase) GetStatement(ctx context.Context, cardID, statementID uuid.UUID) (*entity.CreditCardStatement, error) {
+	st, err := uc.creditCardRepo.FindStatementByID(ctx, statementID)
+	if err != nil {
+		return nil, err
+	}
+	if st.CreditCardID != cardID {
+		return nil, domain.ErrNotFound
+	}
+	st.Status = statementStatus(st, time.Now().Format("2006-01-02"))
+	return st, nil
+}
+
+// PayStatement records a payment of the statement as a transfer from the given
+// account into the card's account.
+func (uc *CreditCardUsecase) PayStatement(ctx context.Context, cardID, statementID, userID, fromAccountID uuid.UUID, amount entity.Money, date string) (*entity.CreditCardStatement, error) {
+	card, err := uc.creditCardRepo.FindByID(ctx, cardID)
+	if err != nil {
+		return nil, err
+	}
+	st, err := uc.GetStatement(ctx, cardID, statementID)
+	if err != nil {
+		return nil, err
+	}
+
+	transfer := &entity.Transfer{
+		UserID:        userID,
+		FromAccountID: fromAccountID,
+		ToAccountID:   card.AccountID,
+		Amount:        amount,
+		Description:   fmt.Sprintf("Pagamento fatura %s %02d/%d", card.Name, st.Month, st.Year),
+		Date:          date,
+	}
+	if err := uc.transferUC.Create(ctx, transfer); err != nil {
+		return nil, err
+	}
+	if err := uc.creditCardRepo.AddStatementPayment(ctx, statementID, amount); err != nil {
+		return nil, err
+	}
+	return uc.GetStatement(ctx, cardID, statementID)
+}
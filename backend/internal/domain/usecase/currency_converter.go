@@ -0,0 +1,60 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/dcunha/finance/backend/internal/domain"
+	"github.com/dcunha/finance/backend/internal/domain/entity"
+	"github.com/dcunha/finance/backend/internal/domain/repository"
+)
+
+// currencyConverter brings transactions computed outside SQL, such as
+// projected occurrences, into the base currency the way the aggregate queries
+// do: at the rate in effect on each transaction's date.
+type currencyConverter struct {
+	settingsRepo repository.SettingsRepository
+	rateRepo     repository.ExchangeRateRepository
+}
+
+// toBase returns copies of txs with their amounts in the base currency. A
+// currency without any stored rate fails with domain.ErrMissingRate.
+func (c currencyConverter) toBase(ctx context.Context, txs []entity.Transaction) ([]entity.Transaction, error) {
+	if len(txs) == 0 {
+		return txs, nil
+	}
+	settings, err := c.settingsRepo.Get(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Projections repeat the same few currencies and dates.
+	rates := make(map[string]*float64)
+	converted := make([]entity.Transaction, 0, len(txs))
+	for _, tx := range txs {
+		if tx.Currency == "" || tx.Currency == settings.BaseCurrency {
+			converted = append(converted, tx)
+			continue
+		}
+		key := tx.Currency + "|" + tx.Date
+		rate, ok := rates[key]
+		if !ok {
+			r, err := c.rateRepo.FindRate(ctx, tx.Currency, settings.BaseCurrency, tx.Date)
+			if err != nil && !errors.Is(err, domain.ErrNotFound) {
+				return nil, err
+			}
+			if err == nil {
+				rate = &r
+			}
+			rates[key] = rate
+		}
+		if rate == nil {
+			return nil, fmt.Errorf("%w: %s to %s", domain.ErrMissingRate, tx.Currency, settings.BaseCurrency)
+		}
+		tx.Amount = tx.Amount.MulRate(*rate)
+		tx.Currency = settings.BaseCurrency
+		converted = append(converted, tx)
+	}
+	return converted, nil
+}
@@ -2,6 +2,9 @@ package usecase
 
 import (
 	"context"
+	"math"
+	"sort"
+	"time"
 
 	"github.com/dcunha/finance/backend/internal/domain/entity"
 	"github.com/dcunha/finance/backend/internal/domain/repository"
@@ -10,27 +13,360 @@ import (
 
 type DashboardUsecase struct {
 	transactionRepo  repository.TransactionRepository
+	reportRepo       repository.ReportRepository
 	expenseLimitRepo repository.ExpenseLimitRepository
+	tagRepo          repository.TagRepository
+	goalRepo         repository.GoalRepository
+	budgetRepo       repository.BudgetRepository
+	projector        recurringProjector
+	currencies       currencyConverter
 }
 
 func NewDashboardUsecase(
 	transactionRepo repository.TransactionRepository,
+	reportRepo repository.ReportRepository,
 	expenseLimitRepo repository.ExpenseLimitRepository,
+	recurringRepo repository.RecurringTransactionRepository,
+	tagRepo repository.TagRepository,
+	goalRepo repository.GoalRepository,
+	budgetRepo repository.BudgetRepository,
+	settingsRepo repository.SettingsRepository,
+	rateRepo repository.ExchangeRateRepository,
 ) *DashboardUsecase {
 	return &DashboardUsecase{
 		transactionRepo:  transactionRepo,
+		reportRepo:       reportRepo,
 		expenseLimitRepo: expenseLimitRepo,
+		tagRepo:          tagRepo,
+		goalRepo:         goalRepo,
+		budgetRepo:       budgetRepo,
+		projector:        recurringProjector{recurringRepo: recurringRepo},
+		currencies:       currencyConverter{settingsRepo: settingsRepo, rateRepo: rateRepo},
 	}
 }
 
+// GetSummary returns the month summary, including projected recurring
+// occurrences for months beyond the materialized horizon. Totals are in the
+// base currency and account balances in each account's currency.
 func (uc *DashboardUsecase) GetSummary(ctx context.Context, month, year int, userID *uuid.UUID) (*entity.DashboardSummary, error) {
-	return uc.transactionRepo.GetSummary(ctx, month, year, userID)
+	summary, err := uc.transactionRepo.GetSummary(ctx, month, year, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	first, last := monthBounds(month, year)
+	inMonth, err := uc.projector.project(ctx, first, last, userID)
+	if err != nil {
+		return nil, err
+	}
+	accountIdx := make(map[string]int, len(summary.Accounts))
+	for i, ab := range summary.Accounts {
+		accountIdx[ab.AccountID] = i
+	}
+	applyToAccount := func(tx entity.Transaction) {
+		i, ok := accountIdx[tx.AccountID.String()]
+		if !ok {
+			return
+		}
+		if tx.Type == "income" {
+			summary.Accounts[i].Balance += tx.Amount
+		} else {
+			summary.Accounts[i].Balance -= tx.Amount
+		}
+	}
+
+	for _, tx := range inMonth {
+		if tx.Type == "income" {
+			summary.IncomeCount++
+		} else {
+			summary.ExpenseCount++
+		}
+		applyToAccount(tx)
+	}
+	inMonthBase, err := uc.currencies.toBase(ctx, inMonth)
+	if err != nil {
+		return nil, err
+	}
+	for _, tx := range inMonthBase {
+		if tx.Type == "income" {
+			summary.TotalIncome += tx.Amount
+		} else {
+			summary.TotalExpenses += tx.Amount
+		}
+	}
+
+	before, err := uc.projector.project(ctx, time.Time{}, first.AddDate(0, 0, -1), userID)
+	if err != nil {
+		return nil, err
+	}
+	for _, tx := range before {
+		applyToAccount(tx)
+	}
+	beforeBase, err := uc.currencies.toBase(ctx, before)
+	if err != nil {
+		return nil, err
+	}
+	for _, tx := range beforeBase {
+		if tx.Type == "income" {
+			summary.PreviousBalance += tx.Amount
+		} else {
+			summary.PreviousBalance -= tx.Amount
+		}
+	}
+
+	summary.Balance = summary.PreviousBalance + summary.TotalIncome - summary.TotalExpenses
+
+	return summary, nil
 }
 
 func (uc *DashboardUsecase) GetByCategory(ctx context.Context, month, year int, txType string, userID *uuid.UUID) ([]entity.CategoryTotal, error) {
-	return uc.transactionRepo.GetByCategory(ctx, month, year, txType, userID)
+	totals, err := uc.transactionRepo.GetByCategory(ctx, month, year, txType, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	first, last := monthBounds(month, year)
+	projected, err := uc.projector.project(ctx, first, last, userID)
+	if err != nil {
+		return nil, err
+	}
+	projected, err = uc.currencies.toBase(ctx, projected)
+	if err != nil {
+		return nil, err
+	}
+	if len(projected) == 0 {
+		return totals, nil
+	}
+
+	idx := make(map[string]int, len(totals))
+	for i, ct := range totals {
+		idx[ct.CategoryID] = i
+	}
+	for _, tx := range projected {
+		if tx.Type != txType {
+			continue
+		}
+		catID := tx.CategoryID.String()
+		if i, ok := idx[catID]; ok {
+			totals[i].Total += tx.Amount
+			continue
+		}
+		idx[catID] = len(totals)
+		totals = append(totals, entity.CategoryTotal{
+			CategoryID:   catID,
+			CategoryName: tx.CategoryName,
+			Total:        tx.Amount,
+		})
+	}
+
+	sort.SliceStable(totals, func(i, j int) bool { return totals[i].Total > totals[j].Total })
+	return totals, nil
+}
+
+// GetByTag returns the month's totals per tag, including projected recurring
+// occurrences, which carry their template's tags.
+func (uc *DashboardUsecase) GetByTag(ctx context.Context, month, year int, txType string, userID *uuid.UUID) ([]entity.TagTotal, error) {
+	totals, err := uc.transactionRepo.GetByTag(ctx, month, year, txType, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	first, last := monthBounds(month, year)
+	projected, err := uc.projector.project(ctx, first, last, userID)
+	if err != nil {
+		return nil, err
+	}
+	projected, err = uc.currencies.toBase(ctx, projected)
+	if err != nil {
+		return nil, err
+	}
+	if len(projected) == 0 {
+		return totals, nil
+	}
+
+	tags, err := uc.tagRepo.FindAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	names := make(map[string]string, len(tags))
+	for _, t := range tags {
+		names[t.ID.String()] = t.Name
+	}
+
+	idx := make(map[string]int, len(totals))
+	for i, tt := range totals {
+		idx[tt.TagID] = i
+	}
+	for _, tx := range projected {
+		if tx.Type != txType {
+			continue
+		}
+		for _, id := range tx.TagIDs {
+			tagID := id.String()
+			if i, ok := idx[tagID]; ok {
+				totals[i].Total += tx.Amount
+				continue
+			}
+			idx[tagID] = len(totals)
+			totals = append(totals, entity.TagTotal{
+				TagID:   tagID,
+				TagName: names[tagID],
+				Total:   tx.Amount,
+			})
+		}
+	}
+
+	sort.SliceStable(totals, func(i, j int) bool { return totals[i].Total > totals[j].Total })
+	return totals, nil
 }
 
+// GetLimitsProgress returns the month's expense limits followed by the
+// budgets active in it, each for its current period. Spending includes
+// projected recurring occurrences.
 func (uc *DashboardUsecase) GetLimitsProgress(ctx context.Context, month, year int, userID *uuid.UUID) ([]entity.LimitProgress, error) {
-	return uc.expenseLimitRepo.GetLimitsProgress(ctx, month, year, userID)
+	progress, err := uc.expenseLimitRepo.GetLimitsProgress(ctx, month, year, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	first, last := monthBounds(month, year)
+	projected, err := uc.projector.project(ctx, first, last, userID)
+	if err != nil {
+		return nil, err
+	}
+	projected, err = uc.currencies.toBase(ctx, projected)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range progress {
+		lp := &progress[i]
+		lp.PeriodStart = first.Format("2006-01-02")
+		lp.PeriodEnd = last.Format("2006-01-02")
+		for _, tx := range projected {
+			if tx.Type != "expense" {
+				continue
+			}
+			if lp.Limit.CategoryID == nil || *lp.Limit.CategoryID == tx.CategoryID {
+				lp.Spent += tx.Amount
+			}
+		}
+		lp.Remaining = lp.Limit.Amount - lp.Spent
+		if lp.Remaining < 0 {
+			lp.Remaining = 0
+		}
+		if lp.Limit.Amount > 0 {
+			lp.Percentage = float64(lp.Spent) / float64(lp.Limit.Amount) * 100
+		}
+	}
+
+	budgets, err := uc.getBudgetsProgress(ctx, month, year, userID, progress)
+	if err != nil {
+		return nil, err
+	}
+	return append(progress, budgets...), nil
+}
+
+// GetGoalsProgress returns every goal with its contributions so far and the
+// pace needed to meet its deadline. Only stored transactions count: projected
+// occurrences cannot be linked to a goal.
+func (uc *DashboardUsecase) GetGoalsProgress(ctx context.Context) ([]entity.GoalProgress, error) {
+	progress, err := uc.goalRepo.GetProgress(ctx)
+	if err != nil {
+		return nil, err
+	}
+	today := time.Now()
+	for i := range progress {
+		fillGoalProgress(&progress[i], today)
+	}
+	return progress, nil
+}
+
+// GetForecast projects the balance at the end of each of the next months,
+// starting from the current month's summary balance. With withAverage, the
+// average non-recurring spending per category over as many complete past
+// months as are forecast is also subtracted from every forecast month. Stored
+// totals for the forecast months come from one query and recurring templates
+// are projected once over the whole range.
+func (uc *DashboardUsecase) GetForecast(ctx context.Context, months int, withAverage bool, userID *uuid.UUID) (*entity.Forecast, error) {
+	now := time.Now()
+	current, err := uc.GetSummary(ctx, int(now.Month()), now.Year(), userID)
+	if err != nil {
+		return nil, err
+	}
+
+	forecast := &entity.Forecast{
+		Currency:        current.Currency,
+		StartingBalance: current.Balance,
+		Months:          make([]entity.ForecastMonth, 0, months),
+	}
+
+	var estimated entity.Money
+	if withAverage {
+		first, _ := monthBounds(int(now.Month()), now.Year())
+		totals, err := uc.transactionRepo.GetNonRecurringExpenses(ctx,
+			first.AddDate(0, -months, 0).Format("2006-01-02"),
+			first.AddDate(0, 0, -1).Format("2006-01-02"),
+			userID,
+		)
+		if err != nil {
+			return nil, err
+		}
+		for i := range totals {
+			totals[i].Total = entity.Money(math.Round(float64(totals[i].Total) / float64(months)))
+			estimated += totals[i].Total
+		}
+		forecast.AverageByCategory = totals
+	}
+
+	rangeFirst := time.Date(now.Year(), now.Month()+1, 1, 0, 0, 0, 0, time.UTC)
+	lastMonth := rangeFirst.AddDate(0, months-1, 0)
+	totals, err := uc.reportRepo.GetMonthlyTotals(ctx, rangeFirst.Format("2006-01-02"), lastMonth.Format("2006-01-02"), userID)
+	if err != nil {
+		return nil, err
+	}
+	projected, err := uc.projector.project(ctx, rangeFirst, lastMonth.AddDate(0, 1, -1), userID)
+	if err != nil {
+		return nil, err
+	}
+	projected, err = uc.currencies.toBase(ctx, projected)
+	if err != nil {
+		return nil, err
+	}
+	monthIdx := make(map[string]int, len(totals))
+	for i, mt := range totals {
+		monthIdx[monthKey(mt.Month, mt.Year)] = i
+	}
+	for _, tx := range projected {
+		i, ok := monthIdx[tx.Date[:7]]
+		if !ok {
+			continue
+		}
+		if tx.Type == "income" {
+			totals[i].Income += tx.Amount
+		} else {
+			totals[i].Expenses += tx.Amount
+		}
+	}
+
+	balance := current.Balance
+	for _, mt := range totals {
+		balance += mt.Income - mt.Expenses - estimated
+		forecast.Months = append(forecast.Months, entity.ForecastMonth{
+			Month:             mt.Month,
+			Year:              mt.Year,
+			Income:            mt.Income,
+			Expenses:          mt.Expenses,
+			EstimatedSpending: estimated,
+			Balance:           balance,
+			Negative:          balance < 0,
+		})
+	}
+	return forecast, nil
+}
+
+// monthBounds returns the first and last day of the given month.
+func monthBounds(month, year int) (time.Time, time.Time) {
+	first := time.Date(year, time.Month(month), 1, 0, 0, 0, 0, time.UTC)
+	return first, first.AddDate(0, 1, -1)
 }
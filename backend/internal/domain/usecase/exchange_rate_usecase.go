@@ -0,0 +1,121 @@
+package usecase
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/dcunha/finance/backend/internal/domain"
+	"github.com/dcunha/finance/backend/internal/domain/entity"
+	"github.com/dcunha/finance/backend/internal/domain/repository"
+	"github.com/dcunha/finance/backend/internal/infrastructure/exchangerate"
+	"github.com/google/uuid"
+)
+
+// manualRateSource marks rates entered by hand; fetched rates never replace them.
+const manualRateSource = "manual"
+
+type ExchangeRateUsecase struct {
+	rateRepo     repository.ExchangeRateRepository
+	settingsRepo repository.SettingsRepository
+	provider     exchangerate.Provider
+}
+
+// NewExchangeRateUsecase builds the usecase. provider may be nil, in which
+// case rates can only be entered by hand.
+func NewExchangeRateUsecase(
+	rateRepo repository.ExchangeRateRepository,
+	settingsRepo repository.SettingsRepository,
+	provider exchangerate.Provider,
+) *ExchangeRateUsecase {
+	return &ExchangeRateUsecase{
+		rateRepo:     rateRepo,
+		settingsRepo: settingsRepo,
+		provider:     provider,
+	}
+}
+
+func (uc *ExchangeRateUsecase) List(ctx context.Context, filter entity.ExchangeRateFilter) ([]entity.ExchangeRate, error) {
+	return uc.rateRepo.FindAll(ctx, filter)
+}
+
+func (uc *ExchangeRateUsecase) GetByID(ctx context.Context, id uuid.UUID) (*entity.ExchangeRate, error) {
+	return uc.rateRepo.FindByID(ctx, id)
+}
+
+func (uc *ExchangeRateUsecase) Create(ctx context.Context, er *entity.ExchangeRate) error {
+	if er.FromCurrency == er.ToCurrency {
+		return domain.ErrSameCurrency
+	}
+	er.Source = manualRateSource
+	return uc.rateRepo.Create(ctx, er)
+}
+
+// Update replaces the pair, date and rate. An edited rate counts as manual
+// from then on, so later syncs keep it.
+func (uc *ExchangeRateUsecase) Update(ctx context.Context, er *entity.ExchangeRate) error {
+	if er.FromCurrency == er.ToCurrency {
+		return domain.ErrSameCurrency
+	}
+	existing, err := uc.rateRepo.FindByID(ctx, er.ID)
+	if err != nil {
+		return err
+	}
+	er.Source = manualRateSource
+	er.CreatedAt = existing.CreatedAt
+	return uc.rateRepo.Update(ctx, er)
+}
+
+func (uc *ExchangeRateUsecase) Delete(ctx context.Context, id uuid.UUID) error {
+	return uc.rateRepo.Delete(ctx, id)
+}
+
+// Sync fetches, for the given date, the rate into the base currency of every
+// currency used by accounts or transactions. A currency the provider cannot
+// quote is logged and skipped. Returns the rates stored; manual rates for the
+// same day are kept and not counted.
+func (uc *ExchangeRateUsecase) Sync(ctx context.Context, date time.Time) ([]entity.ExchangeRate, error) {
+	if uc.provider == nil {
+		return nil, domain.ErrNoRateProvider
+	}
+	settings, err := uc.settingsRepo.Get(ctx)
+	if err != nil {
+		return nil, err
+	}
+	currencies, err := uc.rateRepo.CurrenciesInUse(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	stored := []entity.ExchangeRate{}
+	var lastErr error
+	for _, currency := range currencies {
+		if currency == settings.BaseCurrency {
+			continue
+		}
+		rate, err := uc.provider.Rate(ctx, currency, settings.BaseCurrency, date)
+		if err != nil {
+			log.Printf("Exchange rates: %s/%s on %s: %v", currency, settings.BaseCurrency, date.Format("2006-01-02"), err)
+			lastErr = err
+			continue
+		}
+		er := entity.ExchangeRate{
+			FromCurrency: currency,
+			ToCurrency:   settings.BaseCurrency,
+			Date:         date.Format("2006-01-02"),
+			Rate:         rate,
+			Source:       uc.provider.Name(),
+		}
+		if err := uc.rateRepo.Upsert(ctx, &er); err != nil {
+			return stored, err
+		}
+		if er.ID != uuid.Nil {
+			stored = append(stored, er)
+		}
+	}
+	// Only fail when nothing could be fetched at all.
+	if len(stored) == 0 && lastErr != nil {
+		return stored, lastErr
+	}
+	return stored, nil
+}
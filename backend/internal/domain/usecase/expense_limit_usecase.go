@@ -22,15 +22,21 @@ func (uc *ExpenseLimitUsecase) List(ctx context.Context, month, year int) ([]ent
 }
 
 func (uc *ExpenseLimitUsecase) Create(ctx context.Context, limit *entity.ExpenseLimit) error {
+	limit.AlertThresholds = normalizeThresholds(limit.AlertThresholds)
 	return uc.expenseLimitRepo.Upsert(ctx, limit)
 }
 
-func (uc *ExpenseLimitUsecase) Update(ctx context.Context, id uuid.UUID, amount float64) error {
+// Update changes the amount and, unless thresholds is nil, the alert
+// thresholds.
+func (uc *ExpenseLimitUsecase) Update(ctx context.Context, id uuid.UUID, amount entity.Money, thresholds []int) error {
 	limit, err := uc.expenseLimitRepo.FindByID(ctx, id)
 	if err != nil {
 		return err
 	}
 	limit.Amount = amount
+	if thresholds != nil {
+		limit.AlertThresholds = normalizeThresholds(thresholds)
+	}
 	return uc.expenseLimitRepo.Update(ctx, limit)
 }
 
@@ -54,11 +60,12 @@ func (uc *ExpenseLimitUsecase) CopyLimits(ctx context.Context, fromMonth, fromYe
 	}
 	for _, limit := range limits {
 		newLimit := &entity.ExpenseLimit{
-			UserID:     userID,
-			CategoryID: limit.CategoryID,
-			Month:      toMonth,
-			Year:       toYear,
-			Amount:     limit.Amount,
+			UserID:          userID,
+			CategoryID:      limit.CategoryID,
+			Month:           toMonth,
+			Year:            toYear,
+			Amount:          limit.Amount,
+			AlertThresholds: limit.AlertThresholds,
 		}
 		if err := uc.expenseLimitRepo.Upsert(ctx, newLimit); err != nil {
 			return 0, err
@@ -0,0 +1,162 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/dcunha/finance/backend/internal/domain"
+	"github.com/dcunha/finance/backend/internal/domain/entity"
+	"github.com/dcunha/finance/backend/internal/domain/repository"
+	"github.com/google/uuid"
+)
+
+type GoalUsecase struct {
+	goalRepo        repository.GoalRepository
+	transactionRepo repository.TransactionRepository
+}
+
+func NewGoalUsecase(goalRepo repository.GoalRepository, transactionRepo repository.TransactionRepository) *GoalUsecase {
+	return &GoalUsecase{goalRepo: goalRepo, transactionRepo: transactionRepo}
+}
+
+func (uc *GoalUsecase) List(ctx context.Context) ([]entity.Goal, error) {
+	return uc.goalRepo.FindAll(ctx)
+}
+
+func (uc *GoalUsecase) GetByID(ctx context.Context, id uuid.UUID) (*entity.Goal, error) {
+	return uc.goalRepo.FindByID(ctx, id)
+}
+
+func (uc *GoalUsecase) Create(ctx context.Context, goal *entity.Goal) error {
+	return uc.goalRepo.Create(ctx, goal)
+}
+
+// Update changes the name, target and deadline; contributions are kept.
+func (uc *GoalUsecase) Update(ctx context.Context, id uuid.UUID, name string, target entity.Money, deadline string) (*entity.Goal, error) {
+	goal, err := uc.goalRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	goal.Name = name
+	goal.TargetAmount = target
+	goal.Deadline = deadline
+	if err := uc.goalRepo.Update(ctx, goal); err != nil {
+		return nil, err
+	}
+	return goal, nil
+}
+
+// Delete removes a goal. Its contributions stop counting but the
+// transactions themselves are kept.
+func (uc *GoalUsecase) Delete(ctx context.Context, id uuid.UUID) error {
+	return uc.goalRepo.Delete(ctx, id)
+}
+
+func (uc *GoalUsecase) ListContributions(ctx context.Context, goalID uuid.UUID) ([]entity.GoalContribution, error) {
+	if _, err := uc.goalRepo.FindByID(ctx, goalID); err != nil {
+		return nil, err
+	}
+	return uc.goalRepo.FindContributions(ctx, goalID)
+}
+
+// AddContribution counts a transaction, or a transfer given by transferID,
+// towards the goal. Transfers always count through their incoming leg, so
+// linking either leg links the same contribution.
+func (uc *GoalUsecase) AddContribution(ctx context.Context, goalID uuid.UUID, transactionID, transferID *uuid.UUID) (*entity.GoalContribution, error) {
+	if _, err := uc.goalRepo.FindByID(ctx, goalID); err != nil {
+		return nil, err
+	}
+	legID, err := uc.contributionLeg(ctx, transactionID, transferID)
+	if err != nil {
+		return nil, err
+	}
+	if err := uc.goalRepo.AddContribution(ctx, goalID, legID); err != nil {
+		return nil, err
+	}
+
+	contributions, err := uc.goalRepo.FindContributions(ctx, goalID)
+	if err != nil {
+		return nil, err
+	}
+	for i := range contributions {
+		if contributions[i].TransactionID == legID {
+			return &contributions[i], nil
+		}
+	}
+	return nil, domain.ErrNotFound
+}
+
+func (uc *GoalUsecase) RemoveContribution(ctx context.Context, goalID, transactionID uuid.UUID) error {
+	return uc.goalRepo.RemoveContribution(ctx, goalID, transactionID)
+}
+
+// contributionLeg returns the id of the transaction to link.
+func (uc *GoalUsecase) contributionLeg(ctx context.Context, transactionID, transferID *uuid.UUID) (uuid.UUID, error) {
+	if transactionID != nil {
+		tx, err := uc.transactionRepo.FindByID(ctx, *transactionID)
+		if err != nil {
+			return uuid.Nil, err
+		}
+		if tx.TransferID == nil {
+			return tx.ID, nil
+		}
+		transferID = tx.TransferID
+	}
+	legs, err := uc.transactionRepo.FindByTransferID(ctx, *transferID)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	for _, leg := range legs {
+		if leg.TransferDirection == "in" {
+			return leg.ID, nil
+		}
+	}
+	return uuid.Nil, domain.ErrNotFound
+}
+
+// fillGoalProgress computes the pace of a goal from its contributions up to
+// today. The monthly contribution needed spreads what remains over the months
+// left, rounded up to the cent so paying it every month reaches the target;
+// once the deadline has passed, all of it is due. The projected completion is
+// the end of the month in which the average pace so far reaches the target.
+func fillGoalProgress(gp *entity.GoalProgress, today time.Time) {
+	target := gp.Goal.TargetAmount
+	gp.Remaining = max(target-gp.Contributed, 0)
+	if target > 0 {
+		gp.Percentage = float64(gp.Contributed) / float64(target) * 100
+	}
+
+	deadline, err := time.Parse("2006-01-02", gp.Goal.Deadline)
+	if err != nil {
+		return
+	}
+	if gp.Goal.Deadline >= today.Format("2006-01-02") {
+		gp.MonthsLeft = monthsBetween(today, deadline) + 1
+	}
+	if gp.Remaining > 0 {
+		gp.MonthlyNeeded = gp.Remaining
+		if gp.MonthsLeft > 0 {
+			left := entity.Money(gp.MonthsLeft)
+			gp.MonthlyNeeded = (gp.Remaining + left - 1) / left
+		}
+	}
+
+	if gp.StartedOn != "" {
+		started, err := time.Parse("2006-01-02", gp.StartedOn)
+		if err == nil {
+			gp.AverageMonthly = gp.Contributed / entity.Money(max(monthsBetween(started, today)+1, 1))
+		}
+	}
+	if gp.Remaining > 0 && gp.AverageMonthly > 0 {
+		months := int((gp.Remaining + gp.AverageMonthly - 1) / gp.AverageMonthly)
+		end := time.Date(today.Year(), today.Month()+time.Month(months)+1, 0, 0, 0, 0, 0, time.UTC)
+		gp.ProjectedCompletion = end.Format("2006-01-02")
+	}
+	gp.OnTrack = gp.Remaining == 0 ||
+		(gp.ProjectedCompletion != "" && gp.ProjectedCompletion <= gp.Goal.Deadline)
+}
+
+// monthsBetween counts the calendar months from from's month to to's month.
+func monthsBetween(from, to time.Time) int {
+	return (to.Year()-from.Year())*12 + int(to.Month()) - int(from.Month())
+}
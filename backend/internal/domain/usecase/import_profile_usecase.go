@@ -0,0 +1,48 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/dcunha/finance/backend/internal/domain/entity"
+	"github.com/dcunha/finance/backend/internal/domain/repository"
+	"github.com/google/uuid"
+)
+
+type ImportProfileUsecase struct {
+	profileRepo repository.ImportProfileRepository
+}
+
+func NewImportProfileUsecase(repo repository.ImportProfileRepository) *ImportProfileUsecase {
+	return &ImportProfileUsecase{profileRepo: repo}
+}
+
+func (uc *ImportProfileUsecase) List(ctx context.Context) ([]entity.ImportProfile, error) {
+	return uc.profileRepo.FindAll(ctx)
+}
+
+func (uc *ImportProfileUsecase) GetByID(ctx context.Context, id uuid.UUID) (*entity.ImportProfile, error) {
+	return uc.profileRepo.FindByID(ctx, id)
+}
+
+func (uc *ImportProfileUsecase) Create(ctx context.Context, profile *entity.ImportProfile) error {
+	return uc.profileRepo.Create(ctx, profile)
+}
+
+// Update replaces the mapping of an existing profile; creator and timestamps are kept.
+func (uc *ImportProfileUsecase) Update(ctx context.Context, id uuid.UUID, upd *entity.ImportProfile) (*entity.ImportProfile, error) {
+	profile, err := uc.profileRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	upd.ID = profile.ID
+	upd.UserID = profile.UserID
+	upd.CreatedAt = profile.CreatedAt
+	if err := uc.profileRepo.Update(ctx, upd); err != nil {
+		return nil, err
+	}
+	return upd, nil
+}
+
+func (uc *ImportProfileUsecase) Delete(ctx context.Context, id uuid.UUID) error {
+	return uc.profileRepo.Delete(ctx, id)
+}
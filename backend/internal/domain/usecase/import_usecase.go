@@ -0,0 +1,231 @@
+package usecase
+
+import (
+	"context"
+	"math"
+	"time"
+
+	"github.com/dcunha/finance/backend/internal/domain"
+	"github.com/dcunha/finance/backend/internal/domain/entity"
+	"github.com/dcunha/finance/backend/internal/domain/repository"
+	"github.com/google/uuid"
+)
+
+// duplicateWindowDays is how far apart, in days, a stored transaction with the
+// same amount may be dated and still be flagged as a possible duplicate. Banks
+// often post a few days after the purchase date entered by hand.
+const duplicateWindowDays = 3
+
+type ImportUsecase struct {
+	transactionRepo repository.TransactionRepository
+	accountRepo     repository.AccountRepository
+	statements      statementAssigner
+	rules           categorizer
+	alerts          *LimitAlertUsecase
+}
+
+func NewImportUsecase(
+	transactionRepo repository.TransactionRepository,
+	accountRepo repository.AccountRepository,
+	creditCardRepo repository.CreditCardRepository,
+	ruleRepo repository.CategorizationRuleRepository,
+	alerts *LimitAlertUsecase,
+) *ImportUsecase {
+	return &ImportUsecase{
+		transactionRepo: transactionRepo,
+		accountRepo:     accountRepo,
+		statements:      statementAssigner{creditCardRepo: creditCardRepo},
+		rules:           categorizer{ruleRepo: ruleRepo},
+		alerts:          alerts,
+	}
+}
+
+// Preview flags which candidates already exist in the account and picks their
+// categories from the categorization rules; the category a candidate arrives
+// with is only the fallback when no rule matches. Nothing is stored.
+func (uc *ImportUsecase) Preview(ctx context.Context, accountID uuid.UUID, candidates []entity.ImportCandidate) (*entity.ImportPreview, error) {
+	accountID, err := resolveAccountID(ctx, uc.accountRepo, accountID)
+	if err != nil {
+		return nil, err
+	}
+	if err := uc.detectDuplicates(ctx, accountID, candidates); err != nil {
+		return nil, err
+	}
+	rules, err := uc.rules.load(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for i := range candidates {
+		c := &candidates[i]
+		if rule := rules.match(c.Type, c.Description, c.Amount, accountID); rule != nil {
+			c.CategoryID = &rule.CategoryID
+			c.RuleID = &rule.ID
+		}
+	}
+
+	preview := &entity.ImportPreview{AccountID: accountID, Candidates: candidates}
+	for _, c := range candidates {
+		switch c.Status {
+		case entity.ImportStatusDuplicate:
+			preview.DuplicateCount++
+		case entity.ImportStatusPossibleDuplicate:
+			preview.PossibleDuplicate++
+		default:
+			preview.NewCount++
+		}
+	}
+	return preview, nil
+}
+
+// Commit stores the candidates in a single database transaction. Rows whose
+// external ID is already stored are skipped; possible duplicates are imported,
+// since the user chose to keep them after the preview. Rows without a category
+// get one from the categorization rules.
+func (uc *ImportUsecase) Commit(ctx context.Context, userID, accountID uuid.UUID, candidates []entity.ImportCandidate) (*entity.ImportResult, error) {
+	accountID, err := resolveAccountID(ctx, uc.accountRepo, accountID)
+	if err != nil {
+		return nil, err
+	}
+	if err := uc.detectDuplicates(ctx, accountID, candidates); err != nil {
+		return nil, err
+	}
+
+	rules, err := uc.rules.load(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &entity.ImportResult{}
+	var txs []entity.Transaction
+	for _, c := range candidates {
+		if c.Status == entity.ImportStatusDuplicate {
+			result.Skipped++
+			continue
+		}
+		if c.CategoryID == nil {
+			if rule := rules.match(c.Type, c.Description, c.Amount, accountID); rule != nil {
+				c.CategoryID = &rule.CategoryID
+			}
+		}
+		if c.CategoryID == nil {
+			return nil, domain.ErrMissingCategory
+		}
+		tx := entity.Transaction{
+			UserID:      userID,
+			CategoryID:  *c.CategoryID,
+			AccountID:   accountID,
+			Type:        c.Type,
+			Amount:      c.Amount,
+			Description: c.Description,
+			Date:        c.Date,
+		}
+		if c.ExternalID != "" {
+			externalID := c.ExternalID
+			tx.ExternalID = &externalID
+		}
+		txs = append(txs, tx)
+	}
+
+	if len(txs) == 0 {
+		return result, nil
+	}
+	if err := uc.statements.assignAll(ctx, txs); err != nil {
+		return nil, err
+	}
+	if err := uc.transactionRepo.BulkCreate(ctx, txs); err != nil {
+		return nil, err
+	}
+	result.Imported = len(txs)
+	uc.alerts.Check(ctx, txs...)
+	return result, nil
+}
+
+// detectDuplicates sets Status and DuplicateOfID on each candidate. A stored
+// transaction matches at most one candidate, so a file with two identical
+// purchases is not collapsed into one.
+func (uc *ImportUsecase) detectDuplicates(ctx context.Context, accountID uuid.UUID, candidates []entity.ImportCandidate) error {
+	if len(candidates) == 0 {
+		return nil
+	}
+
+	minDate, maxDate := candidates[0].Date, candidates[0].Date
+	for _, c := range candidates {
+		if c.Type != "income" && c.Type != "expense" || c.Amount <= 0 {
+			return domain.ErrInvalidImportRow
+		}
+		if _, err := time.Parse("2006-01-02", c.Date); err != nil {
+			return domain.ErrInvalidImportRow
+		}
+		if c.Date < minDate {
+			minDate = c.Date
+		}
+		if c.Date > maxDate {
+			maxDate = c.Date
+		}
+	}
+	from, _ := time.Parse("2006-01-02", minDate)
+	to, _ := time.Parse("2006-01-02", maxDate)
+
+	existing, err := uc.transactionRepo.FindByAccountAndDateRange(ctx, accountID,
+		from.AddDate(0, 0, -duplicateWindowDays).Format("2006-01-02"),
+		to.AddDate(0, 0, duplicateWindowDays).Format("2006-01-02"))
+	if err != nil {
+		return err
+	}
+
+	used := make([]bool, len(existing))
+	byExternalID := make(map[string]int)
+	for i, tx := range existing {
+		if tx.ExternalID != nil {
+			byExternalID[*tx.ExternalID] = i
+		}
+	}
+
+	// Exact external ID matches first, so fuzzy matching cannot take their rows.
+	for i := range candidates {
+		c := &candidates[i]
+		c.Status = entity.ImportStatusNew
+		c.DuplicateOfID = nil
+		if c.ExternalID == "" {
+			continue
+		}
+		if j, ok := byExternalID[c.ExternalID]; ok && !used[j] {
+			used[j] = true
+			c.Status = entity.ImportStatusDuplicate
+			c.DuplicateOfID = &existing[j].ID
+		}
+	}
+
+	for i := range candidates {
+		c := &candidates[i]
+		if c.Status != entity.ImportStatusNew {
+			continue
+		}
+		date, _ := time.Parse("2006-01-02", c.Date)
+		best, bestDistance := -1, duplicateWindowDays+1
+		for j, tx := range existing {
+			if used[j] || tx.Type != c.Type || tx.Amount != c.Amount {
+				continue
+			}
+			// Stored rows from another import of the same bank are different
+			// transactions if their external IDs differ.
+			if tx.ExternalID != nil && c.ExternalID != "" {
+				continue
+			}
+			txDate, err := time.Parse("2006-01-02", tx.Date)
+			if err != nil {
+				continue
+			}
+			distance := int(math.Abs(txDate.Sub(date).Hours() / 24))
+			if distance < bestDistance {
+				best, bestDistance = j, distance
+			}
+		}
+		if best >= 0 {
+			used[best] = true
+			c.Status = entity.ImportStatusPossibleDuplicate
+			c.DuplicateOfID = &existing[best].ID
+		}
+	}
+	return nil
+}
@@ -0,0 +1,215 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/dcunha/finance/backend/internal/domain/entity"
+	"github.com/dcunha/finance/backend/internal/domain/repository"
+	"github.com/dcunha/finance/backend/internal/infrastructure/database"
+	"github.com/dcunha/finance/backend/internal/infrastructure/email"
+	"github.com/dcunha/finance/backend/internal/tenant"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// alertCheckTimeout bounds a background alert check, email included.
+const alertCheckTimeout = time.Minute
+
+// budgetPeriodNames names budget periods in alert emails.
+var budgetPeriodNames = map[string]string{
+	"weekly":    "semanal",
+	"monthly":   "mensal",
+	"quarterly": "trimestral",
+	"yearly":    "anual",
+}
+
+// LimitAlertUsecase emails the owner of an expense limit or budget when
+// spending crosses one of its alert thresholds, once per threshold and period.
+type LimitAlertUsecase struct {
+	pool         *pgxpool.Pool
+	dashboard    *DashboardUsecase
+	alertRepo    repository.LimitAlertRepository
+	userRepo     repository.UserRepository
+	settingsRepo repository.SettingsRepository
+	emailSender  email.Sender
+	appURL       string
+}
+
+func NewLimitAlertUsecase(
+	pool *pgxpool.Pool,
+	dashboard *DashboardUsecase,
+	alertRepo repository.LimitAlertRepository,
+	userRepo repository.UserRepository,
+	settingsRepo repository.SettingsRepository,
+	emailSender email.Sender,
+	appURL string,
+) *LimitAlertUsecase {
+	return &LimitAlertUsecase{
+		pool:         pool,
+		dashboard:    dashboard,
+		alertRepo:    alertRepo,
+		userRepo:     userRepo,
+		settingsRepo: settingsRepo,
+		emailSender:  emailSender,
+		appURL:       appURL,
+	}
+}
+
+type alertMonth struct{ month, year int }
+
+// Check sends the alerts due after txs were stored, looking at the limits and
+// budgets of each month an expense falls in. The check runs in the background
+// on its own connection to the tenant schema in ctx, so the request does not
+// wait for the email. Failures are logged: the transactions are already stored
+// and a missed alert must not fail the request.
+func (uc *LimitAlertUsecase) Check(ctx context.Context, txs ...entity.Transaction) {
+	var months []alertMonth
+	seen := make(map[alertMonth]bool)
+	for _, tx := range txs {
+		if tx.Type != "expense" {
+			continue
+		}
+		date, err := time.Parse("2006-01-02", tx.Date)
+		if err != nil {
+			continue
+		}
+		m := alertMonth{int(date.Month()), date.Year()}
+		if seen[m] {
+			continue
+		}
+		seen[m] = true
+		months = append(months, m)
+	}
+	if len(months) == 0 {
+		return
+	}
+
+	schemaName := tenant.SchemaFromContext(ctx)
+	go uc.checkMonths(schemaName, months)
+}
+
+func (uc *LimitAlertUsecase) checkMonths(schemaName string, months []alertMonth) {
+	ctx, cancel := context.WithTimeout(context.Background(), alertCheckTimeout)
+	defer cancel()
+
+	schemaCtx := tenant.ContextWithSchema(ctx, schemaName)
+	conn, release, err := database.AcquireWithSchema(schemaCtx, uc.pool)
+	if err != nil {
+		log.Printf("Limit alerts: tenant %s: %v", schemaName, err)
+		return
+	}
+	defer release()
+	schemaCtx = database.ContextWithConn(schemaCtx, conn)
+
+	for _, m := range months {
+		if err := uc.checkMonth(schemaCtx, m.month, m.year); err != nil {
+			log.Printf("Limit alerts: tenant %s: %02d/%d: %v", schemaName, m.month, m.year, err)
+		}
+	}
+}
+
+func (uc *LimitAlertUsecase) checkMonth(ctx context.Context, month, year int) error {
+	progress, err := uc.dashboard.GetLimitsProgress(ctx, month, year, nil)
+	if err != nil {
+		return err
+	}
+
+	for _, lp := range progress {
+		thresholds := lp.Limit.AlertThresholds
+		if lp.Budget != nil {
+			thresholds = lp.Budget.AlertThresholds
+		}
+
+		// Thresholds are sorted, so the last one recorded is the highest.
+		var recorded []entity.LimitAlert
+		for _, threshold := range thresholds {
+			if lp.Percentage < float64(threshold) {
+				break
+			}
+			alert := entity.LimitAlert{PeriodStart: lp.PeriodStart, Threshold: threshold}
+			if lp.Budget != nil {
+				alert.BudgetID = &lp.Budget.ID
+			} else {
+				alert.ExpenseLimitID = &lp.Limit.ID
+			}
+			stored, err := uc.alertRepo.Record(ctx, &alert)
+			if err != nil {
+				return err
+			}
+			if stored {
+				recorded = append(recorded, alert)
+			}
+		}
+		if len(recorded) == 0 {
+			continue
+		}
+
+		if err := uc.send(ctx, lp, recorded[len(recorded)-1].Threshold); err != nil {
+			log.Printf("Limit alerts: sending alert for %s: %v", lp.PeriodStart, err)
+			// Forget the alerts so the next transaction tries again.
+			for _, alert := range recorded {
+				if err := uc.alertRepo.Delete(ctx, alert.ID); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// send emails the alert to the user who created the limit or budget.
+func (uc *LimitAlertUsecase) send(ctx context.Context, lp entity.LimitProgress, threshold int) error {
+	user, err := uc.userRepo.FindByID(ctx, lp.Limit.UserID)
+	if err != nil {
+		return err
+	}
+	settings, err := uc.settingsRepo.Get(ctx)
+	if err != nil {
+		return err
+	}
+
+	name := lp.Limit.CategoryName
+	if lp.Limit.CategoryID == nil {
+		name = "Todas as categorias"
+	}
+	if lp.Budget != nil {
+		name = fmt.Sprintf("%s (orçamento %s)", name, budgetPeriodNames[lp.Budget.Period])
+	}
+
+	subject, body := email.LimitAlertEmail(uc.appURL, name, threshold,
+		formatAlertMoney(lp.Spent, settings.BaseCurrency),
+		formatAlertMoney(lp.Limit.Amount+lp.Rollover, settings.BaseCurrency),
+		formatAlertDate(lp.PeriodStart), formatAlertDate(lp.PeriodEnd),
+	)
+	return uc.emailSender.Send(user.Email, subject, body)
+}
+
+// formatAlertMoney writes an amount the Brazilian way, e.g. "BRL 1234,56".
+func formatAlertMoney(m entity.Money, currency string) string {
+	return currency + " " + strings.Replace(m.String(), ".", ",", 1)
+}
+
+// formatAlertDate turns YYYY-MM-DD into DD/MM/YYYY.
+func formatAlertDate(date string) string {
+	t, err := time.Parse("2006-01-02", date)
+	if err != nil {
+		return date
+	}
+	return t.Format("02/01/2006")
+}
+
+// normalizeThresholds sorts the alert thresholds and drops repeats, so alerts
+// are checked from the lowest up.
+func normalizeThresholds(thresholds []int) []int {
+	sorted := slices.Clone(thresholds)
+	slices.Sort(sorted)
+	sorted = slices.Compact(sorted)
+	if sorted == nil {
+		sorted = []int{}
+	}
+	return sorted
+}
@@ -0,0 +1,107 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/dcunha/finance/backend/internal/domain/entity"
+	"github.com/dcunha/finance/backend/internal/domain/repository"
+	"github.com/google/uuid"
+)
+
+// recurringProjector computes virtual occurrences of active recurring templates
+// beyond their materialized horizon. Reads that reach past the horizon merge
+// these in, so listings and forecasts keep working without storing every
+// future row.
+type recurringProjector struct {
+	recurringRepo repository.RecurringTransactionRepository
+}
+
+// project returns the virtual occurrences dated within [from, to]. A zero from
+// means "right after each template's horizon". If userID is set, only templates
+// owned by that user are projected.
+func (p recurringProjector) project(ctx context.Context, from, to time.Time, userID *uuid.UUID) ([]entity.Transaction, error) {
+	templates, err := p.recurringRepo.FindActive(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var projected []entity.Transaction
+	for i := range templates {
+		rt := &templates[i]
+		if userID != nil && rt.UserID != *userID {
+			continue
+		}
+
+		horizonNext, err := time.Parse("2006-01-02", rt.StartDate)
+		if err != nil {
+			continue
+		}
+		if rt.MaterializedUntil != nil {
+			mu, parseErr := time.Parse("2006-01-02", *rt.MaterializedUntil)
+			if parseErr == nil && !mu.Before(horizonNext) {
+				horizonNext = mu.AddDate(0, 0, 1)
+			}
+		}
+
+		start := from
+		if start.Before(horizonNext) {
+			start = horizonNext
+		}
+		end := to
+		if rt.EndDate != nil {
+			endDate, parseErr := time.Parse("2006-01-02", *rt.EndDate)
+			if parseErr == nil && endDate.Before(end) {
+				end = endDate
+			}
+		}
+		if start.After(end) {
+			continue
+		}
+
+		dates := computeAllDates(rt, start, end)
+		if len(dates) == 0 {
+			continue
+		}
+
+		// Installments are numbered by their place in the schedule, as when
+		// they are stored.
+		var startOrdinal int
+		if rt.MaxOccurrences != nil {
+			startOrdinal = occurrenceOrdinal(rt, dates[0])
+			remaining := *rt.MaxOccurrences - startOrdinal
+			if remaining <= 0 {
+				continue
+			}
+			if len(dates) > remaining {
+				dates = dates[:remaining]
+			}
+		}
+
+		for j, d := range dates {
+			amt := rt.Amount
+			desc := rt.Description
+			if rt.MaxOccurrences != nil {
+				amt = installmentAmount(rt.Amount, *rt.MaxOccurrences, startOrdinal+j)
+				desc = installmentDescription(rt.Description, startOrdinal+j+1, *rt.MaxOccurrences)
+			}
+			projected = append(projected, entity.Transaction{
+				UserID:       rt.UserID,
+				CategoryID:   rt.CategoryID,
+				CategoryName: rt.CategoryName,
+				AccountID:    rt.AccountID,
+				AccountName:  rt.AccountName,
+				Type:         rt.Type,
+				Amount:       amt,
+				Currency:     rt.Currency,
+				Description:  desc,
+				Date:         d.Format("2006-01-02"),
+				RecurringID:  &rt.ID,
+				TagIDs:       rt.TagIDs,
+				IsProjected:  true,
+			})
+		}
+	}
+
+	return projected, nil
+}
@@ -3,7 +3,7 @@ package usecase
 import (
 	"context"
 	"fmt"
-	"math"
+	"sort"
 	"time"
 
 	"github.com/dcunha/finance/backend/internal/domain"
@@ -12,21 +12,47 @@ import (
 	"github.com/google/uuid"
 )
 
-const maxProjectionYears = 50
+// defaultHorizonMonths is used when no materialization horizon is configured.
+const defaultHorizonMonths = 3
 
 type RecurringTransactionUsecase struct {
 	recurringRepo   repository.RecurringTransactionRepository
 	transactionRepo repository.TransactionRepository
+	accountRepo     repository.AccountRepository
+	statements      statementAssigner
+	horizonMonths   int
 }
 
-func NewRecurringTransactionUsecase(recurringRepo repository.RecurringTransactionRepository, transactionRepo repository.TransactionRepository) *RecurringTransactionUsecase {
-	return &RecurringTransactionUsecase{recurringRepo: recurringRepo, transactionRepo: transactionRepo}
+// NewRecurringTransactionUsecase creates the usecase. horizonMonths controls how
+// far ahead occurrences are stored as transactions; later ones are projected on read.
+func NewRecurringTransactionUsecase(
+	recurringRepo repository.RecurringTransactionRepository,
+	transactionRepo repository.TransactionRepository,
+	accountRepo repository.AccountRepository,
+	creditCardRepo repository.CreditCardRepository,
+	horizonMonths int,
+) *RecurringTransactionUsecase {
+	if horizonMonths <= 0 {
+		horizonMonths = defaultHorizonMonths
+	}
+	return &RecurringTransactionUsecase{
+		recurringRepo:   recurringRepo,
+		transactionRepo: transactionRepo,
+		accountRepo:     accountRepo,
+		statements:      statementAssigner{creditCardRepo: creditCardRepo},
+		horizonMonths:   horizonMonths,
+	}
 }
 
 func (uc *RecurringTransactionUsecase) Create(ctx context.Context, rt *entity.RecurringTransaction) error {
 	if !isValidFrequency(rt.Frequency) {
 		return domain.ErrInvalidFrequency
 	}
+	accountID, err := resolveAccountID(ctx, uc.accountRepo, rt.AccountID)
+	if err != nil {
+		return err
+	}
+	rt.AccountID = accountID
 	rt.IsActive = true
 
 	if err := uc.recurringRepo.Create(ctx, rt); err != nil {
@@ -64,7 +90,15 @@ func (uc *RecurringTransactionUsecase) Pause(ctx context.Context, id uuid.UUID)
 		return err
 	}
 
-	return uc.recurringRepo.Pause(ctx, id, now)
+	if err := uc.recurringRepo.Pause(ctx, id, now); err != nil {
+		return err
+	}
+
+	cutoffDate, err := time.Parse("2006-01-02", cutoff)
+	if err != nil {
+		return err
+	}
+	return uc.recurringRepo.UpdateMaterializedUntil(ctx, id, cutoffDate.AddDate(0, 0, -1).Format("2006-01-02"))
 }
 
 func (uc *RecurringTransactionUsecase) Resume(ctx context.Context, id uuid.UUID, onConflict string) error {
@@ -101,14 +135,7 @@ func (uc *RecurringTransactionUsecase) Resume(ctx context.Context, id uuid.UUID,
 	rt.PausedAt = nil
 
 	if hasConflict && onConflict == "update" {
-		var startOrdinal int
-		if rt.MaxOccurrences != nil {
-			preCount, err := uc.transactionRepo.CountByRecurringIDBeforeDate(ctx, id, firstDay.Format("2006-01-02"))
-			if err != nil {
-				return err
-			}
-			startOrdinal = preCount
-		}
+		startOrdinal := occurrenceOrdinal(rt, firstDay)
 		if err := uc.updateExistingTransactions(ctx, rt, existing, firstDay, lastDay, startOrdinal); err != nil {
 			return err
 		}
@@ -146,11 +173,13 @@ func (uc *RecurringTransactionUsecase) updateExistingTransactions(ctx context.Co
 			ex.Amount = amt
 			ex.Description = desc
 			ex.CategoryID = rt.CategoryID
+			ex.AccountID = rt.AccountID
 			toUpdate = append(toUpdate, ex)
 		} else {
 			toCreate = append(toCreate, entity.Transaction{
 				UserID:      rt.UserID,
 				CategoryID:  rt.CategoryID,
+				AccountID:   rt.AccountID,
 				Type:        rt.Type,
 				Amount:      amt,
 				Description: desc,
@@ -160,6 +189,13 @@ func (uc *RecurringTransactionUsecase) updateExistingTransactions(ctx context.Co
 		}
 	}
 
+	if err := uc.statements.assignAll(ctx, toUpdate); err != nil {
+		return err
+	}
+	if err := uc.statements.assignAll(ctx, toCreate); err != nil {
+		return err
+	}
+
 	if len(toUpdate) > 0 {
 		if err := uc.transactionRepo.BulkUpdate(ctx, toUpdate); err != nil {
 			return err
@@ -173,18 +209,474 @@ func (uc *RecurringTransactionUsecase) updateExistingTransactions(ctx context.Co
 	return nil
 }
 
+// Update changes a recurring series. The scope selects which occurrences are
+// affected: a single occurrence, the selected occurrence and every later one,
+// or the whole series. It returns the template that now describes the changed
+// occurrences, which is a new template when a series is split.
+func (uc *RecurringTransactionUsecase) Update(ctx context.Context, id uuid.UUID, upd entity.RecurringTransactionUpdate) (*entity.RecurringTransaction, error) {
+	rt, err := uc.recurringRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if upd.Frequency != nil && !isValidFrequency(*upd.Frequency) {
+		return nil, domain.ErrInvalidFrequency
+	}
+	if upd.AccountID != nil {
+		if _, err := uc.accountRepo.FindByID(ctx, *upd.AccountID); err != nil {
+			return nil, err
+		}
+	}
+
+	switch upd.Scope {
+	case entity.UpdateScopeAll:
+		if err := uc.updateAll(ctx, rt, upd); err != nil {
+			return nil, err
+		}
+		return uc.recurringRepo.FindByID(ctx, rt.ID)
+	case entity.UpdateScopeThis, entity.UpdateScopeThisAndFuture:
+	default:
+		return nil, domain.ErrInvalidScope
+	}
+
+	date, err := time.Parse("2006-01-02", upd.OccurrenceDate)
+	if err != nil || len(computeAllDates(rt, date, date)) == 0 {
+		return nil, domain.ErrInvalidOccurrence
+	}
+	if rt.EndDate != nil && upd.OccurrenceDate > *rt.EndDate {
+		return nil, domain.ErrInvalidOccurrence
+	}
+
+	switch {
+	case upd.Scope == entity.UpdateScopeThis:
+		err = uc.updateOccurrence(ctx, rt, date, upd)
+	case rt.MaxOccurrences != nil:
+		err = uc.updateFutureInstallments(ctx, rt, date, upd)
+	case upd.OccurrenceDate <= rt.StartDate:
+		err = uc.updateAll(ctx, rt, upd)
+	default:
+		next, splitErr := uc.splitSeries(ctx, rt, date, upd)
+		if splitErr != nil {
+			return nil, splitErr
+		}
+		return uc.recurringRepo.FindByID(ctx, next.ID)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return uc.recurringRepo.FindByID(ctx, rt.ID)
+}
+
+// updateAll applies the change to the template and its stored occurrences.
+// A schedule change keeps the occurrences dated before today and regenerates
+// the rest from there. Otherwise each occurrence takes the new values, except
+// fields it no longer shares with the template: those were edited on that
+// occurrence alone and are kept.
+func (uc *RecurringTransactionUsecase) updateAll(ctx context.Context, rt *entity.RecurringTransaction, upd entity.RecurringTransactionUpdate) error {
+	limit := uc.materializedLimit(rt)
+	previous := *rt
+	scheduleChanged := applyUpdate(rt, upd)
+	if err := uc.recurringRepo.Update(ctx, rt); err != nil {
+		return err
+	}
+
+	startDate, err := time.Parse("2006-01-02", rt.StartDate)
+	if err != nil {
+		return err
+	}
+
+	if scheduleChanged {
+		now := time.Now()
+		cutoff := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+		if cutoff.Before(startDate) {
+			cutoff = startDate
+		}
+		if err := uc.transactionRepo.DeleteFutureByRecurringID(ctx, rt.ID, cutoff.Format("2006-01-02")); err != nil {
+			return err
+		}
+		return uc.generateThrough(ctx, rt, cutoff, limit)
+	}
+
+	existing, err := uc.transactionRepo.FindByRecurringIDAndDateRange(ctx, rt.ID, rt.StartDate, limit.Format("2006-01-02"))
+	if err != nil {
+		return err
+	}
+	if len(existing) == 0 {
+		return nil
+	}
+
+	// An occurrence's ordinal follows from its scheduled date, so gaps left by
+	// deleted or paused occurrences do not shift the ones after them. A row off
+	// the schedule had its date moved individually and keeps its values.
+	ordinals := make(map[string]int)
+	for i, d := range computeAllDates(rt, startDate, limit) {
+		ordinals[d.Format("2006-01-02")] = i
+	}
+	for i := range existing {
+		tx := &existing[i]
+		if ordinal, ok := ordinals[tx.Date]; ok {
+			oldAmount, oldDescription := occurrenceValues(&previous, ordinal)
+			newAmount, newDescription := occurrenceValues(rt, ordinal)
+			if tx.Amount == oldAmount {
+				tx.Amount = newAmount
+			}
+			if tx.Description == oldDescription {
+				tx.Description = newDescription
+			}
+		}
+		if tx.CategoryID == previous.CategoryID {
+			tx.CategoryID = rt.CategoryID
+		}
+		if tx.AccountID == previous.AccountID {
+			tx.AccountID = rt.AccountID
+		}
+	}
+	if err := uc.statements.assignAll(ctx, existing); err != nil {
+		return err
+	}
+	return uc.transactionRepo.BulkUpdate(ctx, existing)
+}
+
+// updateOccurrence changes a single occurrence, materializing it first if it
+// lies beyond the horizon. The template itself is left untouched, so tags set
+// here are added to the ones the occurrence inherits from it.
+func (uc *RecurringTransactionUsecase) updateOccurrence(ctx context.Context, rt *entity.RecurringTransaction, date time.Time, upd entity.RecurringTransactionUpdate) error {
+	if upd.Frequency != nil {
+		return domain.ErrInvalidScope
+	}
+	if err := uc.ensureMaterialized(ctx, rt, date); err != nil {
+		return err
+	}
+
+	dateStr := date.Format("2006-01-02")
+	rows, err := uc.transactionRepo.FindByRecurringIDAndDateRange(ctx, rt.ID, dateStr, dateStr)
+	if err != nil {
+		return err
+	}
+	if len(rows) == 0 {
+		return domain.ErrNotFound
+	}
+
+	tx := rows[0]
+	if upd.Amount != nil {
+		tx.Amount = *upd.Amount
+	}
+	if upd.Description != nil {
+		tx.Description = *upd.Description
+		if rt.MaxOccurrences != nil {
+			tx.Description = installmentDescription(*upd.Description, occurrenceOrdinal(rt, date)+1, *rt.MaxOccurrences)
+		}
+	}
+	if upd.CategoryID != nil {
+		tx.CategoryID = *upd.CategoryID
+	}
+	if upd.AccountID != nil {
+		tx.AccountID = *upd.AccountID
+	}
+	if upd.TagIDs != nil {
+		tx.TagIDs = upd.TagIDs
+	}
+	if upd.DayOfMonth != nil {
+		tx.Date = clampToMonth(date, *upd.DayOfMonth).Format("2006-01-02")
+	}
+	if err := uc.statements.assign(ctx, &tx); err != nil {
+		return err
+	}
+	return uc.transactionRepo.Update(ctx, &tx)
+}
+
+// updateFutureInstallments re-splits an installment series from the selected
+// installment on. Installments already recorded before it keep their amounts and
+// the rest of the total is divided among the remaining ones.
+func (uc *RecurringTransactionUsecase) updateFutureInstallments(ctx context.Context, rt *entity.RecurringTransaction, date time.Time, upd entity.RecurringTransactionUpdate) error {
+	if !rt.IsActive {
+		return domain.ErrRecurringPaused
+	}
+
+	// Installment series are finite, so store all of them and work on rows only.
+	n := *rt.MaxOccurrences
+	lastPossible := date.AddDate(n, 0, 0)
+	if err := uc.ensureMaterialized(ctx, rt, lastPossible); err != nil {
+		return err
+	}
+
+	dateStr := date.Format("2006-01-02")
+	prior, err := uc.transactionRepo.FindByRecurringIDAndDateRange(ctx, rt.ID, rt.StartDate, date.AddDate(0, 0, -1).Format("2006-01-02"))
+	if err != nil {
+		return err
+	}
+	future, err := uc.transactionRepo.FindByRecurringIDAndDateRange(ctx, rt.ID, dateStr, lastPossible.Format("2006-01-02"))
+	if err != nil {
+		return err
+	}
+
+	var priorTotal entity.Money
+	for _, tx := range prior {
+		priorTotal += tx.Amount
+	}
+
+	scheduleChanged := applyUpdate(rt, upd)
+	remainder := rt.Amount - priorTotal
+	remaining := n - len(prior)
+	if remainder <= 0 || remaining <= 0 {
+		return domain.ErrInstallmentTotal
+	}
+
+	if scheduleChanged {
+		if err := uc.transactionRepo.DeleteFutureByRecurringID(ctx, rt.ID, dateStr); err != nil {
+			return err
+		}
+		future = nil
+	}
+
+	// Exactly `remaining` rows must carry the rest of the total: extra stored
+	// rows are dropped and scheduled dates without a row are filled in.
+	for len(future) > remaining {
+		last := future[len(future)-1]
+		if err := uc.transactionRepo.Delete(ctx, last.ID); err != nil {
+			return err
+		}
+		future = future[:len(future)-1]
+	}
+	stored := make(map[string]bool, len(future))
+	for _, tx := range future {
+		stored[tx.Date] = true
+	}
+	for _, d := range computeAllDates(rt, date, lastPossible) {
+		if len(future) == remaining {
+			break
+		}
+		if stored[d.Format("2006-01-02")] {
+			continue
+		}
+		future = append(future, entity.Transaction{
+			UserID:      rt.UserID,
+			Type:        rt.Type,
+			Date:        d.Format("2006-01-02"),
+			RecurringID: &rt.ID,
+		})
+	}
+	sort.SliceStable(future, func(i, j int) bool { return future[i].Date < future[j].Date })
+
+	for i := range future {
+		future[i].Amount = installmentAmount(remainder, remaining, i)
+		future[i].Description = installmentDescription(rt.Description, len(prior)+i+1, n)
+		future[i].CategoryID = rt.CategoryID
+		future[i].AccountID = rt.AccountID
+	}
+	if err := uc.statements.assignAll(ctx, future); err != nil {
+		return err
+	}
+	var created, updated []entity.Transaction
+	for _, tx := range future {
+		if tx.ID == uuid.Nil {
+			created = append(created, tx)
+		} else {
+			updated = append(updated, tx)
+		}
+	}
+
+	if err := uc.recurringRepo.Update(ctx, rt); err != nil {
+		return err
+	}
+	if len(updated) > 0 {
+		if err := uc.transactionRepo.BulkUpdate(ctx, updated); err != nil {
+			return err
+		}
+	}
+	if len(created) > 0 {
+		return uc.transactionRepo.BulkCreate(ctx, created)
+	}
+	return nil
+}
+
+// splitSeries ends rt the day before date and continues the series from date
+// as a new template carrying the change. Past occurrences keep pointing at rt.
+func (uc *RecurringTransactionUsecase) splitSeries(ctx context.Context, rt *entity.RecurringTransaction, date time.Time, upd entity.RecurringTransactionUpdate) (*entity.RecurringTransaction, error) {
+	if !rt.IsActive {
+		return nil, domain.ErrRecurringPaused
+	}
+
+	dateStr := date.Format("2006-01-02")
+	next := *rt
+	next.ID = uuid.Nil
+	next.StartDate = dateStr
+	next.MaterializedUntil = nil
+	applyUpdate(&next, upd)
+
+	if err := uc.transactionRepo.DeleteFutureByRecurringID(ctx, rt.ID, dateStr); err != nil {
+		return nil, err
+	}
+	prevEnd := date.AddDate(0, 0, -1).Format("2006-01-02")
+	rt.EndDate = &prevEnd
+	if err := uc.recurringRepo.Update(ctx, rt); err != nil {
+		return nil, err
+	}
+	if err := uc.recurringRepo.UpdateMaterializedUntil(ctx, rt.ID, prevEnd); err != nil {
+		return nil, err
+	}
+
+	if err := uc.Create(ctx, &next); err != nil {
+		return nil, err
+	}
+	return &next, nil
+}
+
+// ensureMaterialized stores the occurrences of rt up to date if its horizon does
+// not reach that far yet.
+func (uc *RecurringTransactionUsecase) ensureMaterialized(ctx context.Context, rt *entity.RecurringTransaction, date time.Time) error {
+	from, err := time.Parse("2006-01-02", rt.StartDate)
+	if err != nil {
+		return err
+	}
+	if rt.MaterializedUntil != nil {
+		mu, err := time.Parse("2006-01-02", *rt.MaterializedUntil)
+		if err != nil {
+			return err
+		}
+		if !mu.Before(date) {
+			return nil
+		}
+		from = mu.AddDate(0, 0, 1)
+	}
+	if !rt.IsActive {
+		return domain.ErrRecurringPaused
+	}
+	return uc.generateThrough(ctx, rt, from, date)
+}
+
+// materializedLimit returns the date through which rt's occurrences are stored.
+func (uc *RecurringTransactionUsecase) materializedLimit(rt *entity.RecurringTransaction) time.Time {
+	limit := uc.horizonEnd(time.Now())
+	if rt.MaterializedUntil != nil {
+		mu, err := time.Parse("2006-01-02", *rt.MaterializedUntil)
+		if err == nil && (!rt.IsActive || mu.After(limit)) {
+			limit = mu
+		}
+	}
+	return limit
+}
+
+// applyUpdate copies the set fields of upd onto rt and reports whether the
+// schedule (frequency, or day of month of a monthly template) changed.
+func applyUpdate(rt *entity.RecurringTransaction, upd entity.RecurringTransactionUpdate) bool {
+	if upd.Amount != nil {
+		rt.Amount = *upd.Amount
+	}
+	if upd.Description != nil {
+		rt.Description = *upd.Description
+	}
+	if upd.CategoryID != nil {
+		rt.CategoryID = *upd.CategoryID
+	}
+	if upd.AccountID != nil {
+		rt.AccountID = *upd.AccountID
+	}
+	if upd.TagIDs != nil {
+		rt.TagIDs = upd.TagIDs
+	}
+
+	scheduleChanged := false
+	if upd.Frequency != nil && *upd.Frequency != rt.Frequency {
+		rt.Frequency = *upd.Frequency
+		scheduleChanged = true
+	}
+	// The day of month only places occurrences of monthly templates.
+	if upd.DayOfMonth != nil && (rt.DayOfMonth == nil || *rt.DayOfMonth != *upd.DayOfMonth) {
+		day := *upd.DayOfMonth
+		rt.DayOfMonth = &day
+		if rt.Frequency == "monthly" {
+			scheduleChanged = true
+		}
+	}
+	return scheduleChanged
+}
+
+// occurrenceValues returns the amount and description of the occurrence at the
+// given 0-based ordinal.
+func occurrenceValues(rt *entity.RecurringTransaction, ordinal int) (entity.Money, string) {
+	if rt.MaxOccurrences == nil {
+		return rt.Amount, rt.Description
+	}
+	return installmentAmount(rt.Amount, *rt.MaxOccurrences, ordinal),
+		installmentDescription(rt.Description, ordinal+1, *rt.MaxOccurrences)
+}
+
+// clampToMonth returns the given day in date's month, clamped to its last day.
+func clampToMonth(date time.Time, day int) time.Time {
+	lastDay := time.Date(date.Year(), date.Month()+1, 0, 0, 0, 0, 0, time.UTC).Day()
+	if day > lastDay {
+		day = lastDay
+	}
+	return time.Date(date.Year(), date.Month(), day, 0, 0, 0, 0, time.UTC)
+}
+
 func (uc *RecurringTransactionUsecase) List(ctx context.Context, userID uuid.UUID, filter entity.RecurringTransactionFilter) (*entity.PaginatedRecurringTransactions, error) {
 	return uc.recurringRepo.FindAll(ctx, userID, filter)
 }
 
+// ExtendHorizon materializes occurrences of every active template whose horizon
+// lags behind the configured window. Returns the number of templates extended.
+func (uc *RecurringTransactionUsecase) ExtendHorizon(ctx context.Context) (int, error) {
+	horizon := uc.horizonEnd(time.Now())
+	templates, err := uc.recurringRepo.FindActive(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	extended := 0
+	for i := range templates {
+		rt := &templates[i]
+		from := rt.StartDate
+		if rt.MaterializedUntil != nil {
+			mu, err := time.Parse("2006-01-02", *rt.MaterializedUntil)
+			if err != nil {
+				return extended, err
+			}
+			if !mu.Before(horizon) || (rt.EndDate != nil && *rt.EndDate <= *rt.MaterializedUntil) {
+				continue
+			}
+			from = mu.AddDate(0, 0, 1).Format("2006-01-02")
+		}
+		if err := uc.generateTransactions(ctx, rt, from); err != nil {
+			return extended, fmt.Errorf("extending recurring transaction %s: %w", rt.ID, err)
+		}
+		extended++
+	}
+	return extended, nil
+}
+
+// horizonEnd returns the last date that should be materialized as of now.
+func (uc *RecurringTransactionUsecase) horizonEnd(now time.Time) time.Time {
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	return today.AddDate(0, uc.horizonMonths, 0)
+}
+
+// generateTransactions stores the occurrences from fromDateStr up to the
+// materialization horizon. Credit card installments are stored in full, since
+// every future statement must show them.
 func (uc *RecurringTransactionUsecase) generateTransactions(ctx context.Context, rt *entity.RecurringTransaction, fromDateStr string) error {
 	fromDate, err := time.Parse("2006-01-02", fromDateStr)
 	if err != nil {
 		return err
 	}
 
-	toDate := fromDate.AddDate(maxProjectionYears, 0, 0)
+	toDate := uc.horizonEnd(time.Now())
+	if rt.MaxOccurrences != nil {
+		isCard, err := uc.statements.isCard(ctx, rt.AccountID)
+		if err != nil {
+			return err
+		}
+		if lastPossible := fromDate.AddDate(*rt.MaxOccurrences, 0, 0); isCard && lastPossible.After(toDate) {
+			toDate = lastPossible
+		}
+	}
 
+	return uc.generateThrough(ctx, rt, fromDate, toDate)
+}
+
+// generateThrough stores the occurrences within [fromDate, toDate] and records
+// toDate as the template's materialized horizon.
+func (uc *RecurringTransactionUsecase) generateThrough(ctx context.Context, rt *entity.RecurringTransaction, fromDate, toDate time.Time) error {
 	// Respect end_date
 	if rt.EndDate != nil {
 		endDate, parseErr := time.Parse("2006-01-02", *rt.EndDate)
@@ -193,17 +685,27 @@ func (uc *RecurringTransactionUsecase) generateTransactions(ctx context.Context,
 		}
 	}
 
+	if err := uc.materialize(ctx, rt, fromDate, toDate); err != nil {
+		return err
+	}
+
+	materializedUntil := toDate.Format("2006-01-02")
+	rt.MaterializedUntil = &materializedUntil
+	return uc.recurringRepo.UpdateMaterializedUntil(ctx, rt.ID, materializedUntil)
+}
+
+func (uc *RecurringTransactionUsecase) materialize(ctx context.Context, rt *entity.RecurringTransaction, fromDate, toDate time.Time) error {
 	dates := computeAllDates(rt, fromDate, toDate)
 
+	if len(dates) == 0 {
+		return nil
+	}
+
 	// Respect max_occurrences
 	var startOrdinal int
 	if rt.MaxOccurrences != nil {
-		existingCount, err := uc.transactionRepo.CountByRecurringID(ctx, rt.ID)
-		if err != nil {
-			return err
-		}
-		startOrdinal = existingCount
-		remaining := *rt.MaxOccurrences - existingCount
+		startOrdinal = occurrenceOrdinal(rt, dates[0])
+		remaining := *rt.MaxOccurrences - startOrdinal
 		if remaining <= 0 {
 			return nil
 		}
@@ -212,10 +714,6 @@ func (uc *RecurringTransactionUsecase) generateTransactions(ctx context.Context,
 		}
 	}
 
-	if len(dates) == 0 {
-		return nil
-	}
-
 	txs := make([]entity.Transaction, len(dates))
 	for i, d := range dates {
 		amt := rt.Amount
@@ -227,6 +725,7 @@ func (uc *RecurringTransactionUsecase) generateTransactions(ctx context.Context,
 		txs[i] = entity.Transaction{
 			UserID:      rt.UserID,
 			CategoryID:  rt.CategoryID,
+			AccountID:   rt.AccountID,
 			Type:        rt.Type,
 			Amount:      amt,
 			Description: desc,
@@ -235,17 +734,19 @@ func (uc *RecurringTransactionUsecase) generateTransactions(ctx context.Context,
 		}
 	}
 
+	if err := uc.statements.assignAll(ctx, txs); err != nil {
+		return err
+	}
 	return uc.transactionRepo.BulkCreate(ctx, txs)
 }
 
-// installmentAmount calculates the amount for a specific installment.
-// absoluteIndex is 0-based. The last installment absorbs rounding differences.
-func installmentAmount(total float64, n, absoluteIndex int) float64 {
-	base := math.Round((total/float64(n))*100) / 100
-	if absoluteIndex == n-1 {
-		return math.Round((total-base*float64(n-1))*100) / 100
-	}
-	return base
+// installmentAmount returns the amount of the installment at absoluteIndex
+// (0-based) out of n. The total is divided in whole cents: each installment
+// gets total/n truncated to the cent and the first total%n installments carry
+// one cent more, so the installments add up to the total exactly and differ
+// by at most one cent (R$ 100,00 in 3 is 33,34 + 33,33 + 33,33).
+func installmentAmount(total entity.Money, n, absoluteIndex int) entity.Money {
+	return total.Part(n, absoluteIndex)
 }
 
 // installmentDescription returns "base - Parcela X/N".
@@ -257,6 +758,17 @@ func installmentDescription(base string, ordinal, total int) string {
 	return fmt.Sprintf("%s - %s", base, label)
 }
 
+// occurrenceOrdinal returns the 0-based position of date in rt's schedule,
+// counted from the start date whether or not the earlier occurrences are
+// still stored.
+func occurrenceOrdinal(rt *entity.RecurringTransaction, date time.Time) int {
+	startDate, err := time.Parse("2006-01-02", rt.StartDate)
+	if err != nil || !date.After(startDate) {
+		return 0
+	}
+	return len(computeAllDates(rt, startDate, date.AddDate(0, 0, -1)))
+}
+
 func computeAllDates(rt *entity.RecurringTransaction, from, to time.Time) []time.Time {
 	startDate, err := time.Parse("2006-01-02", rt.StartDate)
 	if err != nil {
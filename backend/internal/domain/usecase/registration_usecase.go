@@ -27,6 +27,7 @@ type RegistrationUsecase struct {
 	membershipRepo repository.MembershipRepository
 	tenantRepo     repository.TenantRepository
 	userRepo       repository.UserRepository
+	sessionRepo    repository.SessionRepository
 	schemaManager  *database.SchemaManager
 	tenantCache    *database.TenantCache
 	pool           *pgxpool.Pool
@@ -41,6 +42,7 @@ func NewRegistrationUsecase(
 	membershipRepo repository.MembershipRepository,
 	tenantRepo repository.TenantRepository,
 	userRepo repository.UserRepository,
+	sessionRepo repository.SessionRepository,
 	schemaManager *database.SchemaManager,
 	tenantCache *database.TenantCache,
 	pool *pgxpool.Pool,
@@ -52,6 +54,7 @@ func NewRegistrationUsecase(
 		membershipRepo: membershipRepo,
 		tenantRepo:     tenantRepo,
 		userRepo:       userRepo,
+		sessionRepo:    sessionRepo,
 		schemaManager:  schemaManager,
 		tenantCache:    tenantCache,
 		pool:           pool,
@@ -190,6 +193,138 @@ func (uc *RegistrationUsecase) VerifyEmail(ctx context.Context, token string) er
 	return uc.globalUserRepo.Update(ctx, user)
 }
 
+// passwordResetTTL is how long a forgot-password link stays valid.
+const passwordResetTTL = time.Hour
+
+// ForgotPassword emails a password reset link. An unknown email is not an
+// error, so the response does not reveal which emails are registered.
+func (uc *RegistrationUsecase) ForgotPassword(ctx context.Context, emailAddr string) error {
+	user, err := uc.globalUserRepo.FindByEmail(ctx, emailAddr)
+	if err != nil {
+		if err == domain.ErrNotFound {
+			return nil
+		}
+		return err
+	}
+
+	token, err := generateRandomToken()
+	if err != nil {
+		return err
+	}
+	expiresAt := time.Now().Add(passwordResetTTL)
+	user.PasswordResetToken = &token
+	user.PasswordResetExpiresAt = &expiresAt
+	if err := uc.globalUserRepo.Update(ctx, user); err != nil {
+		return err
+	}
+
+	subject, body := email.PasswordResetEmail(uc.appURL, token)
+	if err := uc.emailSender.Send(user.Email, subject, body); err != nil {
+		fmt.Printf("Warning: failed to send password reset email to %s: %v\n", user.Email, err)
+	}
+	return nil
+}
+
+// AccountExists reports whether a global user has the given email.
+func (uc *RegistrationUsecase) AccountExists(ctx context.Context, emailAddr string) (bool, error) {
+	_, err := uc.globalUserRepo.FindByEmail(ctx, emailAddr)
+	if err == domain.ErrNotFound {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+// SendLockoutNotice tells the owner of emailAddr that logins are blocked
+// until the given time. Nothing is sent for an unknown email.
+func (uc *RegistrationUsecase) SendLockoutNotice(ctx context.Context, emailAddr string, until time.Time) error {
+	user, err := uc.globalUserRepo.FindByEmail(ctx, emailAddr)
+	if err != nil {
+		if err == domain.ErrNotFound {
+			return nil
+		}
+		return err
+	}
+
+	subject, body := email.AccountLockedEmail(uc.appURL, until.UTC().Format("02/01/2006 15:04 (UTC)"))
+	return uc.emailSender.Send(user.Email, subject, body)
+}
+
+// ResetPassword sets a new password using a token from ForgotPassword. The
+// token is consumed before anything else changes, so it works once even when
+// the link is submitted twice at the same time. Following the link proves the email is
+// the user's, so it also counts as verifying it. The new hash is copied to
+// the user's row in every tenant schema, and every session is revoked.
+func (uc *RegistrationUsecase) ResetPassword(ctx context.Context, token, newPassword string) error {
+	userID, err := uc.globalUserRepo.ConsumePasswordResetToken(ctx, token)
+	if err == domain.ErrNotFound {
+		// An expired token is still stored; report it apart from an unknown one.
+		if _, err := uc.globalUserRepo.FindByPasswordResetToken(ctx, token); err == nil {
+			return domain.ErrResetTokenExpired
+		}
+		return domain.ErrNotFound
+	}
+	if err != nil {
+		return err
+	}
+
+	user, err := uc.globalUserRepo.FindByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	hash, err := bcrypt.GenerateFromPassword([]byte(newPassword), bcrypt.DefaultCost)
+	if err != nil {
+		return err
+	}
+	user.PasswordHash = string(hash)
+	user.EmailVerified = true
+	if err := uc.globalUserRepo.Update(ctx, user); err != nil {
+		return err
+	}
+	if err := uc.sessionRepo.RevokeAllForUser(ctx, user.ID, uuid.Nil); err != nil {
+		return err
+	}
+
+	memberships, err := uc.membershipRepo.FindByGlobalUser(ctx, user.ID)
+	if err != nil {
+		return err
+	}
+	for _, m := range memberships {
+		if err := uc.updateSchemaPassword(ctx, user.ID, m.TenantID, string(hash)); err != nil {
+			// The global password already changed, which is what login checks.
+			fmt.Printf("Warning: failed to update password in tenant %s for %s: %v\n", m.TenantID, user.Email, err)
+		}
+	}
+	return nil
+}
+
+// updateSchemaPassword sets the password hash of the global user's row in the
+// tenant's schema.
+func (uc *RegistrationUsecase) updateSchemaPassword(ctx context.Context, globalUserID, tenantID uuid.UUID, passwordHash string) error {
+	membership, err := uc.membershipRepo.FindByGlobalUserAndTenant(ctx, globalUserID, tenantID)
+	if err != nil {
+		return err
+	}
+	t, err := uc.tenantRepo.FindByID(ctx, tenantID)
+	if err != nil {
+		return err
+	}
+
+	schemaCtx := tenant.ContextWithSchema(ctx, t.SchemaName)
+	conn, release, err := database.AcquireWithSchema(schemaCtx, uc.pool)
+	if err != nil {
+		return fmt.Errorf("acquiring schema connection: %w", err)
+	}
+	defer release()
+	schemaCtx = database.ContextWithConn(schemaCtx, conn)
+
+	schemaUser, err := uc.userRepo.FindByID(schemaCtx, membership.SchemaUserID)
+	if err != nil {
+		return err
+	}
+	schemaUser.PasswordHash = passwordHash
+	return uc.userRepo.Update(schemaCtx, schemaUser)
+}
+
 func (uc *RegistrationUsecase) ensureUniqueSchema(ctx context.Context, schemaName, slug string) (string, string, error) {
 	base := schemaName
 	baseSlug := slug
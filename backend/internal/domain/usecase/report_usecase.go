@@ -0,0 +1,213 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/dcunha/finance/backend/internal/domain/entity"
+	"github.com/dcunha/finance/backend/internal/domain/repository"
+	"github.com/google/uuid"
+)
+
+// ReportUsecase builds time series over ranges of whole months. Like the
+// dashboard, it includes projected recurring occurrences and reports in the
+// base currency.
+type ReportUsecase struct {
+	reportRepo repository.ReportRepository
+	projector  recurringProjector
+	currencies currencyConverter
+}
+
+func NewReportUsecase(
+	reportRepo repository.ReportRepository,
+	transactionRepo repository.TransactionRepository,
+	recurringRepo repository.RecurringTransactionRepository,
+	settingsRepo repository.SettingsRepository,
+	rateRepo repository.ExchangeRateRepository,
+) *ReportUsecase {
+	return &ReportUsecase{
+		reportRepo: reportRepo,
+		projector:  recurringProjector{recurringRepo: recurringRepo},
+		currencies: currencyConverter{settingsRepo: settingsRepo, rateRepo: rateRepo},
+	}
+}
+
+// Monthly returns income, expenses, net and end-of-month balance for every
+// month from start to end; both are any day of their month.
+func (uc *ReportUsecase) Monthly(ctx context.Context, start, end time.Time, userID *uuid.UUID) ([]entity.MonthlyTotals, error) {
+	first, _ := monthBounds(int(start.Month()), start.Year())
+	lastMonth, last := monthBounds(int(end.Month()), end.Year())
+
+	totals, err := uc.reportRepo.GetMonthlyTotals(ctx, first.Format("2006-01-02"), lastMonth.Format("2006-01-02"), userID)
+	if err != nil {
+		return nil, err
+	}
+	balance, err := uc.reportRepo.GetBalanceBefore(ctx, first.Format("2006-01-02"), userID)
+	if err != nil {
+		return nil, err
+	}
+
+	projected, err := uc.projectBase(ctx, first, last, userID)
+	if err != nil {
+		return nil, err
+	}
+	idx := make(map[string]int, len(totals))
+	for i, mt := range totals {
+		idx[monthKey(mt.Month, mt.Year)] = i
+	}
+	for _, tx := range projected {
+		i, ok := idx[tx.Date[:7]]
+		if !ok {
+			continue
+		}
+		switch tx.Type {
+		case "income":
+			totals[i].Income += tx.Amount
+		case "expense":
+			totals[i].Expenses += tx.Amount
+		}
+	}
+
+	before, err := uc.projectBase(ctx, time.Time{}, first.AddDate(0, 0, -1), userID)
+	if err != nil {
+		return nil, err
+	}
+	for _, tx := range before {
+		if tx.Type == "income" {
+			balance += tx.Amount
+		} else {
+			balance -= tx.Amount
+		}
+	}
+
+	for i := range totals {
+		totals[i].Net = totals[i].Income - totals[i].Expenses
+		balance += totals[i].Net
+		totals[i].Balance = balance
+	}
+	return totals, nil
+}
+
+// ByCategory returns, per category, the monthly totals of the given type from
+// start to end, ordered by the total over the range.
+func (uc *ReportUsecase) ByCategory(ctx context.Context, start, end time.Time, txType string, userID *uuid.UUID) ([]entity.CategorySeries, error) {
+	first, _ := monthBounds(int(start.Month()), start.Year())
+	lastMonth, last := monthBounds(int(end.Month()), end.Year())
+
+	rows, err := uc.reportRepo.GetCategoryMonthlyTotals(ctx, first.Format("2006-01-02"), lastMonth.Format("2006-01-02"), txType, userID)
+	if err != nil {
+		return nil, err
+	}
+	projected, err := uc.projectBase(ctx, first, last, userID)
+	if err != nil {
+		return nil, err
+	}
+	for _, tx := range projected {
+		if tx.Type != txType {
+			continue
+		}
+		date, _ := time.Parse("2006-01-02", tx.Date)
+		rows = append(rows, entity.CategoryMonthTotal{
+			CategoryID:   tx.CategoryID.String(),
+			CategoryName: tx.CategoryName,
+			Month:        int(date.Month()),
+			Year:         date.Year(),
+			Total:        tx.Amount,
+		})
+	}
+
+	monthIdx := make(map[string]int)
+	var months []entity.MonthTotal
+	for m := first; !m.After(lastMonth); m = m.AddDate(0, 1, 0) {
+		monthIdx[monthKey(int(m.Month()), m.Year())] = len(months)
+		months = append(months, entity.MonthTotal{Month: int(m.Month()), Year: m.Year()})
+	}
+
+	series := []entity.CategorySeries{}
+	seriesIdx := make(map[string]int)
+	for _, row := range rows {
+		mi, ok := monthIdx[monthKey(row.Month, row.Year)]
+		if !ok {
+			continue
+		}
+		si, ok := seriesIdx[row.CategoryID]
+		if !ok {
+			si = len(series)
+			seriesIdx[row.CategoryID] = si
+			series = append(series, entity.CategorySeries{
+				CategoryID:   row.CategoryID,
+				CategoryName: row.CategoryName,
+				Months:       append([]entity.MonthTotal(nil), months...),
+			})
+		}
+		series[si].Months[mi].Total += row.Total
+		series[si].Total += row.Total
+	}
+
+	sort.SliceStable(series, func(i, j int) bool { return series[i].Total > series[j].Total })
+	return series, nil
+}
+
+// YearOverYear compares every month of year with the same month of
+// compareYear.
+func (uc *ReportUsecase) YearOverYear(ctx context.Context, year, compareYear int, userID *uuid.UUID) (*entity.YearOverYear, error) {
+	current, err := uc.Monthly(ctx, time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC), time.Date(year, time.December, 1, 0, 0, 0, 0, time.UTC), userID)
+	if err != nil {
+		return nil, err
+	}
+	previous, err := uc.Monthly(ctx, time.Date(compareYear, time.January, 1, 0, 0, 0, 0, time.UTC), time.Date(compareYear, time.December, 1, 0, 0, 0, 0, time.UTC), userID)
+	if err != nil {
+		return nil, err
+	}
+
+	yoy := &entity.YearOverYear{
+		Year:        year,
+		CompareYear: compareYear,
+		Months:      make([]entity.MonthComparison, 0, len(current)),
+	}
+	var cur, prev entity.MonthlyTotals
+	for i := range current {
+		yoy.Months = append(yoy.Months, compareMonths(current[i], previous[i]))
+		cur.Income += current[i].Income
+		cur.Expenses += current[i].Expenses
+		cur.Net += current[i].Net
+		prev.Income += previous[i].Income
+		prev.Expenses += previous[i].Expenses
+		prev.Net += previous[i].Net
+	}
+	yoy.Totals = compareMonths(cur, prev)
+	return yoy, nil
+}
+
+func (uc *ReportUsecase) projectBase(ctx context.Context, from, to time.Time, userID *uuid.UUID) ([]entity.Transaction, error) {
+	projected, err := uc.projector.project(ctx, from, to, userID)
+	if err != nil {
+		return nil, err
+	}
+	return uc.currencies.toBase(ctx, projected)
+}
+
+func compareMonths(current, previous entity.MonthlyTotals) entity.MonthComparison {
+	return entity.MonthComparison{
+		Month:    current.Month,
+		Income:   compare(current.Income, previous.Income),
+		Expenses: compare(current.Expenses, previous.Expenses),
+		Net:      compare(current.Net, previous.Net),
+	}
+}
+
+func compare(current, previous entity.Money) entity.Comparison {
+	c := entity.Comparison{Current: current, Previous: previous, Delta: current - previous}
+	if previous != 0 {
+		change := float64(c.Delta) / float64(previous.Abs()) * 100
+		c.PercentageChange = &change
+	}
+	return c
+}
+
+// monthKey matches the YYYY-MM prefix of a transaction date.
+func monthKey(month, year int) string {
+	return fmt.Sprintf("%04d-%02d", year, month)
+}
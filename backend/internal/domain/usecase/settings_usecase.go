@@ -0,0 +1,30 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/dcunha/finance/backend/internal/domain/entity"
+	"github.com/dcunha/finance/backend/internal/domain/repository"
+)
+
+type SettingsUsecase struct {
+	settingsRepo repository.SettingsRepository
+}
+
+func NewSettingsUsecase(repo repository.SettingsRepository) *SettingsUsecase {
+	return &SettingsUsecase{settingsRepo: repo}
+}
+
+func (uc *SettingsUsecase) Get(ctx context.Context) (*entity.Settings, error) {
+	return uc.settingsRepo.Get(ctx)
+}
+
+// Update changes the base currency. Stored amounts are untouched; dashboard
+// totals are converted on every read, so they follow the new currency at once.
+func (uc *SettingsUsecase) Update(ctx context.Context, baseCurrency string) (*entity.Settings, error) {
+	settings := &entity.Settings{BaseCurrency: baseCurrency}
+	if err := uc.settingsRepo.Update(ctx, settings); err != nil {
+		return nil, err
+	}
+	return settings, nil
+}
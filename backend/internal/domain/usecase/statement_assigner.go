@@ -0,0 +1,112 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/dcunha/finance/backend/internal/domain"
+	"github.com/dcunha/finance/backend/internal/domain/entity"
+	"github.com/dcunha/finance/backend/internal/domain/repository"
+	"github.com/google/uuid"
+)
+
+// statementAssigner links credit card purchases to the statement (fatura) they
+// fall on. Transactions on other accounts, and transfers, get no statement.
+type statementAssigner struct {
+	creditCardRepo repository.CreditCardRepository
+}
+
+// assign sets StatementID on each transaction, creating statements as needed.
+func (a statementAssigner) assign(ctx context.Context, txs ...*entity.Transaction) error {
+	cards := make(map[uuid.UUID]*entity.CreditCard)
+	for _, tx := range txs {
+		tx.StatementID = nil
+		if tx.Type == "transfer" {
+			continue
+		}
+
+		card, ok := cards[tx.AccountID]
+		if !ok {
+			var err error
+			card, err = a.creditCardRepo.FindByAccountID(ctx, tx.AccountID)
+			if err != nil && !errors.Is(err, domain.ErrNotFound) {
+				return err
+			}
+			cards[tx.AccountID] = card
+		}
+		if card == nil {
+			continue
+		}
+
+		date, err := time.Parse("2006-01-02", tx.Date)
+		if err != nil {
+			return err
+		}
+		st := statementFor(card, date)
+		if err := a.creditCardRepo.FindOrCreateStatement(ctx, &st); err != nil {
+			return err
+		}
+		tx.StatementID = &st.ID
+	}
+	return nil
+}
+
+// assignAll is assign for a slice of transactions.
+func (a statementAssigner) assignAll(ctx context.Context, txs []entity.Transaction) error {
+	ptrs := make([]*entity.Transaction, len(txs))
+	for i := range txs {
+		ptrs[i] = &txs[i]
+	}
+	return a.assign(ctx, ptrs...)
+}
+
+// isCard reports whether the account backs a credit card.
+func (a statementAssigner) isCard(ctx context.Context, accountID uuid.UUID) (bool, error) {
+	_, err := a.creditCardRepo.FindByAccountID(ctx, accountID)
+	if errors.Is(err, domain.ErrNotFound) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+// statementFor returns the statement a purchase made on date belongs to. A
+// purchase on the closing day already goes to the next statement. The due date
+// is the first due day after the closing date.
+func statementFor(card *entity.CreditCard, date time.Time) entity.CreditCardStatement {
+	closing := clampToMonth(date, card.ClosingDay)
+	if !date.Before(closing) {
+		closing = clampToMonth(firstOfNextMonth(date), card.ClosingDay)
+	}
+	due := clampToMonth(closing, card.DueDay)
+	if !due.After(closing) {
+		due = clampToMonth(firstOfNextMonth(closing), card.DueDay)
+	}
+	return entity.CreditCardStatement{
+		CreditCardID: card.ID,
+		Month:        int(due.Month()),
+		Year:         due.Year(),
+		ClosingDate:  closing.Format("2006-01-02"),
+		DueDate:      due.Format("2006-01-02"),
+	}
+}
+
+func firstOfNextMonth(date time.Time) time.Time {
+	return time.Date(date.Year(), date.Month()+1, 1, 0, 0, 0, 0, time.UTC)
+}
+
+// statementStatus derives the status of a statement on the given day.
+func statementStatus(st *entity.CreditCardStatement, today string) string {
+	switch {
+	case st.Total > 0 && st.PaidAmount >= st.Total:
+		return entity.StatementStatusPaid
+	case today < st.ClosingDate:
+		return entity.StatementStatusOpen
+	case st.Total <= 0:
+		return entity.StatementStatusPaid
+	case today > st.DueDate:
+		return entity.StatementStatusOverdue
+	default:
+		return entity.StatementStatusClosed
+	}
+}
@@ -0,0 +1,47 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/dcunha/finance/backend/internal/domain/entity"
+	"github.com/dcunha/finance/backend/internal/domain/repository"
+	"github.com/google/uuid"
+)
+
+type TagUsecase struct {
+	tagRepo repository.TagRepository
+}
+
+func NewTagUsecase(repo repository.TagRepository) *TagUsecase {
+	return &TagUsecase{tagRepo: repo}
+}
+
+func (uc *TagUsecase) List(ctx context.Context) ([]entity.Tag, error) {
+	return uc.tagRepo.FindAll(ctx)
+}
+
+func (uc *TagUsecase) GetByID(ctx context.Context, id uuid.UUID) (*entity.Tag, error) {
+	return uc.tagRepo.FindByID(ctx, id)
+}
+
+func (uc *TagUsecase) Create(ctx context.Context, tag *entity.Tag) error {
+	return uc.tagRepo.Create(ctx, tag)
+}
+
+// Update renames a tag; creator and timestamps are kept.
+func (uc *TagUsecase) Update(ctx context.Context, id uuid.UUID, name string) (*entity.Tag, error) {
+	tag, err := uc.tagRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	tag.Name = name
+	if err := uc.tagRepo.Update(ctx, tag); err != nil {
+		return nil, err
+	}
+	return tag, nil
+}
+
+// Delete removes a tag. Transactions and templates that carried it lose it.
+func (uc *TagUsecase) Delete(ctx context.Context, id uuid.UUID) error {
+	return uc.tagRepo.Delete(ctx, id)
+}
@@ -2,22 +2,70 @@ package usecase
 
 import (
 	"context"
+	"time"
 
+	"github.com/dcunha/finance/backend/internal/domain"
 	"github.com/dcunha/finance/backend/internal/domain/entity"
 	"github.com/dcunha/finance/backend/internal/domain/repository"
+	"github.com/dcunha/finance/backend/internal/infrastructure/storage"
 	"github.com/google/uuid"
 )
 
 type TransactionUsecase struct {
 	transactionRepo repository.TransactionRepository
+	accountRepo     repository.AccountRepository
+	statements      statementAssigner
+	projector       recurringProjector
+	rules           categorizer
+	attachments     attachmentCleaner
+	alerts          *LimitAlertUsecase
 }
 
-func NewTransactionUsecase(repo repository.TransactionRepository) *TransactionUsecase {
-	return &TransactionUsecase{transactionRepo: repo}
+func NewTransactionUsecase(
+	repo repository.TransactionRepository,
+	recurringRepo repository.RecurringTransactionRepository,
+	accountRepo repository.AccountRepository,
+	creditCardRepo repository.CreditCardRepository,
+	ruleRepo repository.CategorizationRuleRepository,
+	attachmentRepo repository.AttachmentRepository,
+	store storage.Storage,
+	alerts *LimitAlertUsecase,
+) *TransactionUsecase {
+	return &TransactionUsecase{
+		transactionRepo: repo,
+		accountRepo:     accountRepo,
+		statements:      statementAssigner{creditCardRepo: creditCardRepo},
+		projector:       recurringProjector{recurringRepo: recurringRepo},
+		rules:           categorizer{ruleRepo: ruleRepo},
+		attachments:     attachmentCleaner{attachmentRepo: attachmentRepo, storage: store},
+		alerts:          alerts,
+	}
 }
 
+// List returns a page of transactions. When the filter has an end date, virtual
+// occurrences of recurring templates past their horizon are merged in.
 func (uc *TransactionUsecase) List(ctx context.Context, filter entity.TransactionFilter) (*entity.PaginatedTransactions, error) {
-	return uc.transactionRepo.FindAll(ctx, filter)
+	var projected []entity.Transaction
+	if filter.EndDate != "" {
+		to, err := time.Parse("2006-01-02", filter.EndDate)
+		if err == nil {
+			var from time.Time
+			if filter.StartDate != "" {
+				from, _ = time.Parse("2006-01-02", filter.StartDate)
+			}
+			projected, err = uc.projector.project(ctx, from, to, nil)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+	return uc.transactionRepo.FindAll(ctx, filter, projected)
+}
+
+// Export calls fn for every stored transaction matching the filter, without
+// pagination. Projected occurrences are not exported.
+func (uc *TransactionUsecase) Export(ctx context.Context, filter entity.TransactionFilter, fn func(entity.Transaction) error) error {
+	return uc.transactionRepo.Stream(ctx, filter, fn)
 }
 
 func (uc *TransactionUsecase) GetByID(ctx context.Context, id uuid.UUID) (*entity.Transaction, error) {
@@ -25,19 +73,136 @@ func (uc *TransactionUsecase) GetByID(ctx context.Context, id uuid.UUID) (*entit
 }
 
 func (uc *TransactionUsecase) Create(ctx context.Context, tx *entity.Transaction) error {
-	return uc.transactionRepo.Create(ctx, tx)
+	accountID, err := resolveAccountID(ctx, uc.accountRepo, tx.AccountID)
+	if err != nil {
+		return err
+	}
+	tx.AccountID = accountID
+	if len(tx.Splits) > 0 {
+		if err := applySplits(tx); err != nil {
+			return err
+		}
+	} else if err := uc.rules.categorize(ctx, tx); err != nil {
+		return err
+	}
+	if tx.CategoryID == uuid.Nil {
+		return domain.ErrMissingCategory
+	}
+	if err := uc.statements.assign(ctx, tx); err != nil {
+		return err
+	}
+	if err := uc.transactionRepo.Create(ctx, tx); err != nil {
+		return err
+	}
+	uc.alerts.Check(ctx, *tx)
+	return nil
 }
 
+// Update replaces the transaction's fields. A nil account, category, tag list
+// or split list keeps the current one; an empty split list merges the lines
+// back into a single category.
 func (uc *TransactionUsecase) Update(ctx context.Context, tx *entity.Transaction) error {
-	if _, err := uc.transactionRepo.FindByID(ctx, tx.ID); err != nil {
+	existing, err := uc.transactionRepo.FindByID(ctx, tx.ID)
+	if err != nil {
+		return err
+	}
+	if existing.TransferID != nil {
+		return domain.ErrTransferLeg
+	}
+	tx.RecurringID = existing.RecurringID
+	keepSplits := tx.Splits == nil && len(existing.Splits) > 0
+	if keepSplits {
+		tx.Splits = existing.Splits
+	}
+	if len(tx.Splits) > 0 {
+		if err := applySplits(tx); err != nil {
+			return err
+		}
+	}
+	if keepSplits {
+		tx.Splits = nil
+	}
+	if tx.CategoryID == uuid.Nil {
+		tx.CategoryID = existing.CategoryID
+	}
+	if tx.AccountID == uuid.Nil {
+		tx.AccountID = existing.AccountID
+	} else if _, err := uc.accountRepo.FindByID(ctx, tx.AccountID); err != nil {
 		return err
 	}
-	return uc.transactionRepo.Update(ctx, tx)
+	if err := uc.statements.assign(ctx, tx); err != nil {
+		return err
+	}
+	if err := uc.transactionRepo.Update(ctx, tx); err != nil {
+		return err
+	}
+	if tx.TagIDs == nil {
+		tx.TagIDs = existing.TagIDs
+	}
+	if keepSplits {
+		tx.Splits = existing.Splits
+	}
+	uc.alerts.Check(ctx, *tx)
+	return nil
 }
 
+// applySplits checks the split lines of tx and sets its category to the one of
+// its largest line. A split has at least two lines adding up to the amount;
+// recurring occurrences are not split, since the series rewrites them.
+func applySplits(tx *entity.Transaction) error {
+	if len(tx.Splits) < 2 || tx.RecurringID != nil {
+		return domain.ErrInvalidSplit
+	}
+	var total entity.Money
+	largest := 0
+	for i, sp := range tx.Splits {
+		if sp.Amount <= 0 || sp.CategoryID == uuid.Nil {
+			return domain.ErrInvalidSplit
+		}
+		total += sp.Amount
+		if sp.Amount > tx.Splits[largest].Amount {
+			largest = i
+		}
+	}
+	if total != tx.Amount {
+		return domain.ErrInvalidSplit
+	}
+	tx.CategoryID = tx.Splits[largest].CategoryID
+	return nil
+}
+
+// Delete removes a transaction and its attachments. Deleting a transfer leg
+// removes the whole transfer.
 func (uc *TransactionUsecase) Delete(ctx context.Context, id uuid.UUID) error {
-	if _, err := uc.transactionRepo.FindByID(ctx, id); err != nil {
+	tx, err := uc.transactionRepo.FindByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	ids := []uuid.UUID{id}
+	if tx.TransferID != nil {
+		legs, err := uc.transactionRepo.FindByTransferID(ctx, *tx.TransferID)
+		if err != nil {
+			return err
+		}
+		ids = ids[:0]
+		for _, leg := range legs {
+			ids = append(ids, leg.ID)
+		}
+	}
+	keys, err := uc.attachments.keys(ctx, ids...)
+	if err != nil {
+		return err
+	}
+
+	if tx.TransferID != nil {
+		err = uc.transactionRepo.DeleteByTransferID(ctx, *tx.TransferID)
+	} else {
+		err = uc.transactionRepo.Delete(ctx, id)
+	}
+	if err != nil {
 		return err
 	}
-	return uc.transactionRepo.Delete(ctx, id)
+	uc.attachments.remove(ctx, keys)
+	return nil
 }
@@ -0,0 +1,130 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/dcunha/finance/backend/internal/domain"
+	"github.com/dcunha/finance/backend/internal/domain/entity"
+	"github.com/dcunha/finance/backend/internal/domain/repository"
+	"github.com/google/uuid"
+)
+
+type TransferUsecase struct {
+	transactionRepo repository.TransactionRepository
+	accountRepo     repository.AccountRepository
+}
+
+func NewTransferUsecase(transactionRepo repository.TransactionRepository, accountRepo repository.AccountRepository) *TransferUsecase {
+	return &TransferUsecase{transactionRepo: transactionRepo, accountRepo: accountRepo}
+}
+
+func (uc *TransferUsecase) GetByID(ctx context.Context, id uuid.UUID) (*entity.Transfer, error) {
+	legs, err := uc.transactionRepo.FindByTransferID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return transferFromLegs(id, legs)
+}
+
+func (uc *TransferUsecase) Create(ctx context.Context, t *entity.Transfer) error {
+	if err := uc.validateAccounts(ctx, t); err != nil {
+		return err
+	}
+
+	t.ID = uuid.New()
+	legs := []entity.Transaction{
+		{UserID: t.UserID, AccountID: t.FromAccountID, TransferDirection: "out"},
+		{UserID: t.UserID, AccountID: t.ToAccountID, TransferDirection: "in"},
+	}
+	for i := range legs {
+		legs[i].Amount = t.Amount
+		legs[i].Description = t.Description
+		legs[i].Date = t.Date
+		legs[i].TransferID = &t.ID
+	}
+	if err := uc.transactionRepo.CreateTransfer(ctx, legs); err != nil {
+		return err
+	}
+
+	t.CreatedAt = legs[0].CreatedAt
+	t.UpdatedAt = legs[0].UpdatedAt
+	return nil
+}
+
+func (uc *TransferUsecase) Update(ctx context.Context, t *entity.Transfer) error {
+	legs, err := uc.transactionRepo.FindByTransferID(ctx, t.ID)
+	if err != nil {
+		return err
+	}
+	current, err := transferFromLegs(t.ID, legs)
+	if err != nil {
+		return err
+	}
+	if err := uc.validateAccounts(ctx, t); err != nil {
+		return err
+	}
+
+	for i := range legs {
+		if legs[i].TransferDirection == "out" {
+			legs[i].AccountID = t.FromAccountID
+		} else {
+			legs[i].AccountID = t.ToAccountID
+		}
+		legs[i].Amount = t.Amount
+		legs[i].Description = t.Description
+		legs[i].Date = t.Date
+	}
+	if err := uc.transactionRepo.UpdateTransfer(ctx, legs); err != nil {
+		return err
+	}
+
+	t.UserID = current.UserID
+	t.CreatedAt = current.CreatedAt
+	t.UpdatedAt = legs[0].UpdatedAt
+	return nil
+}
+
+func (uc *TransferUsecase) Delete(ctx context.Context, id uuid.UUID) error {
+	return uc.transactionRepo.DeleteByTransferID(ctx, id)
+}
+
+func (uc *TransferUsecase) validateAccounts(ctx context.Context, t *entity.Transfer) error {
+	if t.FromAccountID == t.ToAccountID {
+		return domain.ErrSameAccount
+	}
+	from, err := uc.accountRepo.FindByID(ctx, t.FromAccountID)
+	if err != nil {
+		return err
+	}
+	to, err := uc.accountRepo.FindByID(ctx, t.ToAccountID)
+	if err != nil {
+		return err
+	}
+	// Both legs carry the same amount, so they must share a currency.
+	if from.Currency != to.Currency {
+		return domain.ErrCurrencyMismatch
+	}
+	return nil
+}
+
+// transferFromLegs rebuilds a transfer from its stored legs.
+func transferFromLegs(id uuid.UUID, legs []entity.Transaction) (*entity.Transfer, error) {
+	if len(legs) != 2 {
+		return nil, domain.ErrNotFound
+	}
+	t := &entity.Transfer{ID: id}
+	for _, leg := range legs {
+		if leg.TransferDirection == "out" {
+			t.FromAccountID = leg.AccountID
+		} else {
+			t.ToAccountID = leg.AccountID
+		}
+		t.UserID = leg.UserID
+		t.Amount = leg.Amount
+		t.Description = leg.Description
+		t.Date = leg.Date
+		t.CreatedAt = leg.CreatedAt
+		t.UpdatedAt = leg.UpdatedAt
+	}
+	return t, nil
+}
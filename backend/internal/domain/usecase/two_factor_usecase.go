@@ -0,0 +1,323 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/dcunha/finance/backend/internal/domain"
+	"github.com/dcunha/finance/backend/internal/domain/entity"
+	"github.com/dcunha/finance/backend/internal/domain/repository"
+	"github.com/dcunha/finance/backend/internal/infrastructure/database"
+	"github.com/dcunha/finance/backend/internal/infrastructure/ratelimit"
+	"github.com/dcunha/finance/backend/internal/infrastructure/totp"
+	"github.com/google/uuid"
+	"golang.org/x/crypto/bcrypt"
+)
+
+const (
+	// totpIssuer is the account name authenticator apps show.
+	totpIssuer = "DNA Fami"
+	// totpSkew accepts the codes of one step before and after the current
+	// one, for phones whose clock is a little off.
+	totpSkew = 1
+
+	recoveryCodeCount = 10
+	// Lowercase letters and digits without the look-alikes 0/o and 1/l/i.
+	recoveryCodeAlphabet = "abcdefghjkmnpqrstuvwxyz23456789"
+)
+
+type TwoFactorUsecase struct {
+	globalUserRepo   repository.GlobalUserRepository
+	recoveryCodeRepo repository.RecoveryCodeRepository
+	membershipRepo   repository.MembershipRepository
+	tenantRepo       repository.TenantRepository
+	sessionRepo      repository.SessionRepository
+	tenantCache      *database.TenantCache
+	limiter          *ratelimit.Limiter
+}
+
+func NewTwoFactorUsecase(
+	globalUserRepo repository.GlobalUserRepository,
+	recoveryCodeRepo repository.RecoveryCodeRepository,
+	membershipRepo repository.MembershipRepository,
+	tenantRepo repository.TenantRepository,
+	sessionRepo repository.SessionRepository,
+	tenantCache *database.TenantCache,
+	limiter *ratelimit.Limiter,
+) *TwoFactorUsecase {
+	return &TwoFactorUsecase{
+		globalUserRepo:   globalUserRepo,
+		recoveryCodeRepo: recoveryCodeRepo,
+		membershipRepo:   membershipRepo,
+		tenantRepo:       tenantRepo,
+		sessionRepo:      sessionRepo,
+		tenantCache:      tenantCache,
+		limiter:          limiter,
+	}
+}
+
+func (uc *TwoFactorUsecase) Status(ctx context.Context, globalUserID uuid.UUID) (*entity.TwoFactorStatus, error) {
+	user, err := uc.globalUserRepo.FindByID(ctx, globalUserID)
+	if err != nil {
+		return nil, err
+	}
+	required, err := uc.required(ctx, globalUserID)
+	if err != nil {
+		return nil, err
+	}
+	status := &entity.TwoFactorStatus{Enabled: user.TOTPEnabled, Required: required}
+	if user.TOTPEnabled {
+		if status.RecoveryCodesLeft, err = uc.recoveryCodeRepo.CountUnused(ctx, globalUserID); err != nil {
+			return nil, err
+		}
+	}
+	return status, nil
+}
+
+// Setup starts enrollment with a new secret. Until Enable confirms a code
+// from it, login does not ask for one. Calling it again replaces the secret.
+func (uc *TwoFactorUsecase) Setup(ctx context.Context, globalUserID uuid.UUID) (*entity.TwoFactorSetup, error) {
+	user, err := uc.globalUserRepo.FindByID(ctx, globalUserID)
+	if err != nil {
+		return nil, err
+	}
+	if user.TOTPEnabled {
+		return nil, domain.ErrTwoFactorEnabled
+	}
+
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		return nil, err
+	}
+	user.TOTPSecret = &secret
+	if err := uc.globalUserRepo.Update(ctx, user); err != nil {
+		return nil, err
+	}
+
+	return &entity.TwoFactorSetup{
+		Secret:          secret,
+		ProvisioningURI: totp.ProvisioningURI(totpIssuer, user.Email, secret),
+	}, nil
+}
+
+// Enable confirms enrollment with a code from the authenticator and returns
+// the recovery codes. They are shown only this once.
+func (uc *TwoFactorUsecase) Enable(ctx context.Context, globalUserID uuid.UUID, code string) ([]string, error) {
+	user, err := uc.globalUserRepo.FindByID(ctx, globalUserID)
+	if err != nil {
+		return nil, err
+	}
+	if user.TOTPEnabled {
+		return nil, domain.ErrTwoFactorEnabled
+	}
+	if user.TOTPSecret == nil {
+		return nil, domain.ErrTwoFactorDisabled
+	}
+
+	step, ok := totp.Validate(*user.TOTPSecret, normalizeCode(code), time.Now(), totpSkew)
+	if !ok {
+		return nil, domain.ErrInvalidTOTPCode
+	}
+	user.TOTPEnabled = true
+	user.TOTPLastStep = step
+	if err := uc.globalUserRepo.Update(ctx, user); err != nil {
+		return nil, err
+	}
+
+	return uc.newRecoveryCodes(ctx, globalUserID)
+}
+
+// Disable turns two-factor authentication off. It asks for the password and
+// a current code, or only the code for users who sign in with Google and have
+// no password, and is refused while a tenant of the user requires it.
+func (uc *TwoFactorUsecase) Disable(ctx context.Context, globalUserID uuid.UUID, password, code string) error {
+	user, err := uc.globalUserRepo.FindByID(ctx, globalUserID)
+	if err != nil {
+		return err
+	}
+	if !user.TOTPEnabled {
+		return domain.ErrTwoFactorDisabled
+	}
+	if user.PasswordHash != "" {
+		if err := bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(password)); err != nil {
+			return domain.ErrInvalidPassword
+		}
+	}
+	required, err := uc.required(ctx, globalUserID)
+	if err != nil {
+		return err
+	}
+	if required {
+		return domain.ErrTwoFactorRequired
+	}
+	if err := uc.verify(ctx, user, code); err != nil {
+		return err
+	}
+
+	user.TOTPSecret = nil
+	user.TOTPEnabled = false
+	if err := uc.globalUserRepo.Update(ctx, user); err != nil {
+		return err
+	}
+	return uc.recoveryCodeRepo.Replace(ctx, globalUserID, nil)
+}
+
+// RegenerateRecoveryCodes replaces all recovery codes, used or not.
+func (uc *TwoFactorUsecase) RegenerateRecoveryCodes(ctx context.Context, globalUserID uuid.UUID, code string) ([]string, error) {
+	user, err := uc.globalUserRepo.FindByID(ctx, globalUserID)
+	if err != nil {
+		return nil, err
+	}
+	if err := uc.verify(ctx, user, code); err != nil {
+		return nil, err
+	}
+	return uc.newRecoveryCodes(ctx, globalUserID)
+}
+
+// SetTenantRequirement turns the tenant's two-factor requirement on or off.
+// Turning it on signs out the members who have not enabled it, so their next
+// login goes through enrollment.
+func (uc *TwoFactorUsecase) SetTenantRequirement(ctx context.Context, tenantID uuid.UUID, required bool) (*entity.Tenant, error) {
+	t, err := uc.tenantRepo.FindByID(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	t.RequireTwoFactor = required
+	if err := uc.tenantRepo.Update(ctx, t); err != nil {
+		return nil, err
+	}
+	uc.tenantCache.Add(t)
+
+	if required {
+		if err := uc.sessionRepo.RevokeWithoutTwoFactor(ctx, tenantID); err != nil {
+			return nil, err
+		}
+	}
+	return t, nil
+}
+
+// verify accepts either a current TOTP code or an unused recovery code, and
+// burns whichever was used. Wrong codes are counted per user; too many lock
+// the user out of codes for as long as a two-factor token lives, so the
+// tokens issued before the lockout expire during it and the login has to
+// start over from the password. Limiter errors let the check through.
+func (uc *TwoFactorUsecase) verify(ctx context.Context, user *entity.GlobalUser, code string) error {
+	if !user.TOTPEnabled || user.TOTPSecret == nil {
+		return domain.ErrTwoFactorDisabled
+	}
+
+	key := user.ID.String()
+	wait, err := uc.limiter.CodeLockedFor(ctx, key)
+	if err != nil {
+		log.Printf("Rate limit: two-factor: %v", err)
+	}
+	if wait > 0 {
+		return domain.ErrTooManyAttempts
+	}
+
+	err = uc.checkCode(ctx, user, normalizeCode(code))
+	var limitErr error
+	switch {
+	case errors.Is(err, domain.ErrInvalidTOTPCode):
+		_, limitErr = uc.limiter.RecordCodeFailure(ctx, key, twoFactorTokenTTL)
+	case err == nil:
+		limitErr = uc.limiter.RecordCodeSuccess(ctx, key)
+	}
+	if limitErr != nil {
+		log.Printf("Rate limit: two-factor: %v", limitErr)
+	}
+	return err
+}
+
+func (uc *TwoFactorUsecase) checkCode(ctx context.Context, user *entity.GlobalUser, code string) error {
+	if len(code) == totp.Digits && isDigits(code) {
+		step, ok := totp.Validate(*user.TOTPSecret, code, time.Now(), totpSkew)
+		if !ok {
+			return domain.ErrInvalidTOTPCode
+		}
+		fresh, err := uc.globalUserRepo.RecordTOTPStep(ctx, user.ID, step)
+		if err != nil {
+			return err
+		}
+		if !fresh {
+			return domain.ErrInvalidTOTPCode
+		}
+		return nil
+	}
+
+	used, err := uc.recoveryCodeRepo.Use(ctx, user.ID, hashToken(code))
+	if err != nil {
+		return err
+	}
+	if !used {
+		return domain.ErrInvalidTOTPCode
+	}
+	return nil
+}
+
+// required reports whether any tenant of the user requires two-factor
+// authentication.
+func (uc *TwoFactorUsecase) required(ctx context.Context, globalUserID uuid.UUID) (bool, error) {
+	memberships, err := uc.membershipRepo.FindByGlobalUser(ctx, globalUserID)
+	if err != nil {
+		return false, err
+	}
+	return requiresTwoFactor(memberships), nil
+}
+
+func (uc *TwoFactorUsecase) newRecoveryCodes(ctx context.Context, globalUserID uuid.UUID) ([]string, error) {
+	codes := make([]string, recoveryCodeCount)
+	hashes := make([]string, recoveryCodeCount)
+	for i := range codes {
+		code, err := generateRecoveryCode()
+		if err != nil {
+			return nil, err
+		}
+		codes[i] = code
+		hashes[i] = hashToken(normalizeCode(code))
+	}
+	if err := uc.recoveryCodeRepo.Replace(ctx, globalUserID, hashes); err != nil {
+		return nil, err
+	}
+	return codes, nil
+}
+
+func requiresTwoFactor(memberships []entity.TenantMembership) bool {
+	for _, m := range memberships {
+		if m.RequireTwoFactor {
+			return true
+		}
+	}
+	return false
+}
+
+// generateRecoveryCode returns a code such as "k7m2p-xq9ta".
+func generateRecoveryCode() (string, error) {
+	b := make([]byte, 10)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	for i := range b {
+		b[i] = recoveryCodeAlphabet[int(b[i])%len(recoveryCodeAlphabet)]
+	}
+	return string(b[:5]) + "-" + string(b[5:]), nil
+}
+
+// normalizeCode drops the spaces and dashes people type or paste with codes.
+func normalizeCode(code string) string {
+	code = strings.ToLower(strings.TrimSpace(code))
+	return strings.NewReplacer(" ", "", "-", "").Replace(code)
+}
+
+func isDigits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return s != ""
+}
@@ -0,0 +1,228 @@
+// Package csvimport reads bank and card statements exported as CSV, using an
+// entity.ImportProfile to locate the columns and interpret dates and amounts.
+package csvimport
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/dcunha/finance/backend/internal/domain/entity"
+)
+
+// Row is a statement entry. Amount is negative for money leaving the account,
+// after the profile's NegateAmounts has been applied.
+type Row struct {
+	ExternalID  string
+	Date        time.Time
+	Amount      entity.Money
+	Description string
+}
+
+var ErrNoRows = errors.New("csv: no transactions found")
+
+var dateLayouts = map[string]string{
+	"dd/mm/yyyy": "02/01/2006",
+	"mm/dd/yyyy": "01/02/2006",
+	"yyyy-mm-dd": "2006-01-02",
+	"dd-mm-yyyy": "02-01-2006",
+	"dd.mm.yyyy": "02.01.2006",
+}
+
+type columns struct {
+	date, description, amount, externalID int
+}
+
+// Parse reads a CSV statement. Blank lines are ignored, as are the first
+// SkipRows lines (bank exports often start with a preamble before the header).
+// Files that are not valid UTF-8 are decoded as Latin-1.
+func Parse(r io.Reader, profile *entity.ImportProfile) ([]Row, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	data = bytes.TrimPrefix(data, []byte("\xef\xbb\xbf"))
+	if !utf8.Valid(data) {
+		data = latin1ToUTF8(data)
+	}
+
+	layout, ok := dateLayouts[profile.DateFormat]
+	if !ok {
+		return nil, fmt.Errorf("csv: unsupported date format %q", profile.DateFormat)
+	}
+	delimiter, _ := utf8.DecodeRuneInString(profile.Delimiter)
+
+	lines := bytes.SplitAfter(data, []byte("\n"))
+	if profile.SkipRows >= len(lines) {
+		return nil, ErrNoRows
+	}
+	skipped := profile.SkipRows
+
+	reader := csv.NewReader(bytes.NewReader(bytes.Join(lines[skipped:], nil)))
+	reader.Comma = delimiter
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+	reader.TrimLeadingSpace = true
+
+	var header []string
+	if profile.HasHeader {
+		header, err = reader.Read()
+		if err == io.EOF {
+			return nil, ErrNoRows
+		}
+		if err != nil {
+			return nil, fmt.Errorf("csv: %w", err)
+		}
+	}
+	cols, err := resolveColumns(profile, header)
+	if err != nil {
+		return nil, err
+	}
+
+	var rows []Row
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("csv: %w", err)
+		}
+		if isBlank(record) {
+			continue
+		}
+		line, _ := reader.FieldPos(0)
+		line += skipped
+
+		dateValue := field(record, cols.date)
+		date, err := time.Parse(layout, dateValue)
+		if err != nil {
+			return nil, fmt.Errorf("csv: line %d: invalid date %q", line, dateValue)
+		}
+		amountValue := field(record, cols.amount)
+		amount, err := parseAmount(amountValue, profile.DecimalSeparator)
+		if err != nil {
+			return nil, fmt.Errorf("csv: line %d: invalid amount %q", line, amountValue)
+		}
+		if profile.NegateAmounts {
+			amount = -amount
+		}
+
+		row := Row{
+			Date:        date,
+			Amount:      amount,
+			Description: field(record, cols.description),
+		}
+		if cols.externalID >= 0 {
+			row.ExternalID = field(record, cols.externalID)
+		}
+		rows = append(rows, row)
+	}
+
+	if len(rows) == 0 {
+		return nil, ErrNoRows
+	}
+	return rows, nil
+}
+
+func resolveColumns(profile *entity.ImportProfile, header []string) (columns, error) {
+	cols := columns{externalID: -1}
+	var err error
+	if cols.date, err = columnIndex(profile.DateColumn, header); err != nil {
+		return cols, err
+	}
+	if cols.description, err = columnIndex(profile.DescriptionColumn, header); err != nil {
+		return cols, err
+	}
+	if cols.amount, err = columnIndex(profile.AmountColumn, header); err != nil {
+		return cols, err
+	}
+	if profile.ExternalIDColumn != nil {
+		if cols.externalID, err = columnIndex(*profile.ExternalIDColumn, header); err != nil {
+			return cols, err
+		}
+	}
+	return cols, nil
+}
+
+// columnIndex finds a column by header name (case-insensitive) or by its
+// 1-based position.
+func columnIndex(column string, header []string) (int, error) {
+	name := strings.TrimSpace(column)
+	for i, h := range header {
+		if strings.EqualFold(strings.TrimSpace(h), name) {
+			return i, nil
+		}
+	}
+	if pos, err := strconv.Atoi(name); err == nil && pos > 0 {
+		return pos - 1, nil
+	}
+	return 0, fmt.Errorf("csv: column %q not found", column)
+}
+
+func field(record []string, i int) string {
+	if i >= len(record) {
+		return ""
+	}
+	return strings.TrimSpace(record[i])
+}
+
+func isBlank(record []string) bool {
+	for _, v := range record {
+		if strings.TrimSpace(v) != "" {
+			return false
+		}
+	}
+	return true
+}
+
+// parseAmount reads values such as "-1.234,56", "R$ 10,00", "(45,90)" or
+// "45,90-". The separator that is not the decimal one is taken as the
+// thousands separator and dropped.
+func parseAmount(value, decimalSeparator string) (entity.Money, error) {
+	v := strings.TrimSpace(value)
+	negative := false
+	if strings.HasPrefix(v, "(") && strings.HasSuffix(v, ")") {
+		negative = true
+		v = v[1 : len(v)-1]
+	}
+	if strings.HasSuffix(v, "-") {
+		negative = true
+		v = strings.TrimSuffix(v, "-")
+	}
+
+	var b strings.Builder
+	for _, r := range v {
+		switch {
+		case r >= '0' && r <= '9':
+			b.WriteRune(r)
+		case string(r) == decimalSeparator:
+			b.WriteByte('.')
+		case r == '-':
+			negative = !negative
+		}
+	}
+	amount, err := entity.ParseMoney(b.String())
+	if err != nil {
+		return 0, err
+	}
+	if negative {
+		amount = -amount
+	}
+	return amount, nil
+}
+
+func latin1ToUTF8(data []byte) []byte {
+	var buf bytes.Buffer
+	buf.Grow(len(data))
+	for _, b := range data {
+		buf.WriteRune(rune(b))
+	}
+	return buf.Bytes()
+}
@@ -0,0 +1,174 @@
+package database
+
+import (
+	"context"
+	"errors"
+
+	"github.com/dcunha/finance/backend/internal/domain"
+	"github.com/dcunha/finance/backend/internal/domain/entity"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+type AccountRepo struct{}
+
+func NewAccountRepo() *AccountRepo {
+	return &AccountRepo{}
+}
+
+// accountSelect returns accounts with their balance as of today, in the
+// account's currency. Transactions in another currency are converted at the
+// rate of their date.
+const accountSelect = `SELECT a.id, a.user_id, a.name, a.type, a.currency, a.initial_balance,
+		        a.initial_balance + COALESCE((
+		            SELECT SUM(CASE WHEN t.type = 'income' OR t.transfer_direction = 'in' THEN 1 ELSE -1 END
+		                       * convert_amount(t.amount, t.currency, a.currency, t.date))
+		            FROM transactions t
+		            WHERE t.account_id = a.id AND t.date <= CURRENT_DATE
+		        ), 0) AS balance,
+		        a.is_default, a.created_at, a.updated_at
+		 FROM accounts a`
+
+func (r *AccountRepo) Create(ctx context.Context, account *entity.Account) error {
+	conn, err := ConnFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	err = conn.QueryRow(ctx,
+		`INSERT INTO accounts (user_id, name, type, currency, initial_balance)
+		 VALUES ($1, $2, $3, COALESCE(NULLIF($4, ''), (SELECT base_currency FROM settings)), $5)
+		 RETURNING id, currency, created_at, updated_at`,
+		account.UserID, account.Name, account.Type, account.Currency, account.InitialBalance,
+	).Scan(&account.ID, &account.Currency, &account.CreatedAt, &account.UpdatedAt)
+	if err != nil {
+		if isDuplicateKey(err) {
+			return domain.ErrDuplicateAccount
+		}
+		return err
+	}
+	account.Balance = account.InitialBalance
+	return nil
+}
+
+func (r *AccountRepo) Update(ctx context.Context, account *entity.Account) error {
+	conn, err := ConnFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	err = conn.QueryRow(ctx,
+		`UPDATE accounts SET name = $1, type = $2, currency = $3, initial_balance = $4, updated_at = NOW()
+		 WHERE id = $5
+		 RETURNING updated_at`,
+		account.Name, account.Type, account.Currency, account.InitialBalance, account.ID,
+	).Scan(&account.UpdatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return domain.ErrNotFound
+		}
+		if isDuplicateKey(err) {
+			return domain.ErrDuplicateAccount
+		}
+		return err
+	}
+	return nil
+}
+
+func (r *AccountRepo) Delete(ctx context.Context, id uuid.UUID) error {
+	conn, err := ConnFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	result, err := conn.Exec(ctx, `DELETE FROM accounts WHERE id = $1`, id)
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+func (r *AccountRepo) FindByID(ctx context.Context, id uuid.UUID) (*entity.Account, error) {
+	conn, err := ConnFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var a entity.Account
+	err = conn.QueryRow(ctx, accountSelect+` WHERE a.id = $1`, id).Scan(
+		&a.ID, &a.UserID, &a.Name, &a.Type, &a.Currency, &a.InitialBalance, &a.Balance, &a.IsDefault, &a.CreatedAt, &a.UpdatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, rateError(err)
+	}
+	return &a, nil
+}
+
+func (r *AccountRepo) FindDefault(ctx context.Context) (*entity.Account, error) {
+	conn, err := ConnFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var a entity.Account
+	err = conn.QueryRow(ctx, accountSelect+` WHERE a.is_default = true`).Scan(
+		&a.ID, &a.UserID, &a.Name, &a.Type, &a.Currency, &a.InitialBalance, &a.Balance, &a.IsDefault, &a.CreatedAt, &a.UpdatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, rateError(err)
+	}
+	return &a, nil
+}
+
+func (r *AccountRepo) FindAll(ctx context.Context) ([]entity.Account, error) {
+	conn, err := ConnFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := conn.Query(ctx, accountSelect+` ORDER BY a.is_default DESC, a.name ASC`)
+	if err != nil {
+		return nil, rateError(err)
+	}
+	defer rows.Close()
+
+	var accounts []entity.Account
+	for rows.Next() {
+		var a entity.Account
+		if err := rows.Scan(&a.ID, &a.UserID, &a.Name, &a.Type, &a.Currency, &a.InitialBalance, &a.Balance, &a.IsDefault, &a.CreatedAt, &a.UpdatedAt); err != nil {
+			return nil, err
+		}
+		accounts = append(accounts, a)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, rateError(err)
+	}
+	if accounts == nil {
+		accounts = []entity.Account{}
+	}
+	return accounts, nil
+}
+
+func (r *AccountRepo) IsInUse(ctx context.Context, id uuid.UUID) (bool, error) {
+	conn, err := ConnFromContext(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	var exists bool
+	err = conn.QueryRow(ctx,
+		`SELECT EXISTS(SELECT 1 FROM transactions WHERE account_id = $1)
+		     OR EXISTS(SELECT 1 FROM recurring_transactions WHERE account_id = $1)`, id,
+	).Scan(&exists)
+	if err != nil {
+		return false, err
+	}
+	return exists, nil
+}
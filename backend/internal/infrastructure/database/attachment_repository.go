@@ -0,0 +1,99 @@
+package database
+
+import (
+	"context"
+	"errors"
+
+	"github.com/dcunha/finance/backend/internal/domain"
+	"github.com/dcunha/finance/backend/internal/domain/entity"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+type AttachmentRepo struct{}
+
+func NewAttachmentRepo() *AttachmentRepo {
+	return &AttachmentRepo{}
+}
+
+const attachmentSelect = `SELECT id, transaction_id, user_id, file_name, content_type, size, storage_key, created_at
+		 FROM attachments`
+
+func scanAttachment(row pgx.Row, a *entity.Attachment) error {
+	return row.Scan(&a.ID, &a.TransactionID, &a.UserID, &a.FileName, &a.ContentType, &a.Size, &a.StorageKey, &a.CreatedAt)
+}
+
+func (r *AttachmentRepo) Create(ctx context.Context, a *entity.Attachment) error {
+	conn, err := ConnFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	return conn.QueryRow(ctx,
+		`INSERT INTO attachments (transaction_id, user_id, file_name, content_type, size, storage_key)
+		 VALUES ($1, $2, $3, $4, $5, $6)
+		 RETURNING id, created_at`,
+		a.TransactionID, a.UserID, a.FileName, a.ContentType, a.Size, a.StorageKey,
+	).Scan(&a.ID, &a.CreatedAt)
+}
+
+func (r *AttachmentRepo) Delete(ctx context.Context, id uuid.UUID) error {
+	conn, err := ConnFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	result, err := conn.Exec(ctx, `DELETE FROM attachments WHERE id = $1`, id)
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+func (r *AttachmentRepo) FindByID(ctx context.Context, id uuid.UUID) (*entity.Attachment, error) {
+	conn, err := ConnFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var a entity.Attachment
+	if err := scanAttachment(conn.QueryRow(ctx, attachmentSelect+` WHERE id = $1`, id), &a); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, err
+	}
+	return &a, nil
+}
+
+func (r *AttachmentRepo) FindByTransactionID(ctx context.Context, transactionID uuid.UUID) ([]entity.Attachment, error) {
+	conn, err := ConnFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := conn.Query(ctx, attachmentSelect+` WHERE transaction_id = $1 ORDER BY created_at`, transactionID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var attachments []entity.Attachment
+	for rows.Next() {
+		var a entity.Attachment
+		if err := scanAttachment(rows, &a); err != nil {
+			return nil, err
+		}
+		attachments = append(attachments, a)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if attachments == nil {
+		attachments = []entity.Attachment{}
+	}
+	return attachments, nil
+}
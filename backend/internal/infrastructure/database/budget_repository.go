@@ -0,0 +1,215 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/dcunha/finance/backend/internal/domain"
+	"github.com/dcunha/finance/backend/internal/domain/entity"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+type BudgetRepo struct{}
+
+func NewBudgetRepo() *BudgetRepo {
+	return &BudgetRepo{}
+}
+
+const budgetSelect = `SELECT b.id, b.user_id, b.category_id, c.name, b.period, b.amount, b.income_percentage::float8,
+		        b.income_category_id, ic.name, b.rollover, b.start_date::text, b.end_date::text, b.alert_thresholds,
+		        b.created_at, b.updated_at
+		 FROM budgets b
+		 LEFT JOIN categories c ON c.id = b.category_id
+		 LEFT JOIN categories ic ON ic.id = b.income_category_id`
+
+func scanBudget(row pgx.Row, b *entity.Budget) error {
+	var categoryName, incomeCategoryName *string
+	if err := row.Scan(
+		&b.ID, &b.UserID, &b.CategoryID, &categoryName, &b.Period, &b.Amount, &b.IncomePercentage,
+		&b.IncomeCategoryID, &incomeCategoryName, &b.Rollover, &b.StartDate, &b.EndDate, &b.AlertThresholds,
+		&b.CreatedAt, &b.UpdatedAt,
+	); err != nil {
+		return err
+	}
+	if categoryName != nil {
+		b.CategoryName = *categoryName
+	}
+	if incomeCategoryName != nil {
+		b.IncomeCategoryName = *incomeCategoryName
+	}
+	return nil
+}
+
+func (r *BudgetRepo) Create(ctx context.Context, b *entity.Budget) error {
+	conn, err := ConnFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	err = conn.QueryRow(ctx,
+		`INSERT INTO budgets (user_id, category_id, period, amount, income_percentage, income_category_id,
+		                      rollover, start_date, end_date, alert_thresholds)
+		 VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		 RETURNING id, created_at, updated_at`,
+		b.UserID, b.CategoryID, b.Period, b.Amount, b.IncomePercentage, b.IncomeCategoryID,
+		b.Rollover, b.StartDate, b.EndDate, b.AlertThresholds,
+	).Scan(&b.ID, &b.CreatedAt, &b.UpdatedAt)
+	if err != nil {
+		if isDuplicateKey(err) {
+			return domain.ErrDuplicateBudget
+		}
+		if isForeignKeyViolation(err) {
+			return domain.ErrNotFound
+		}
+		return err
+	}
+	return nil
+}
+
+func (r *BudgetRepo) Update(ctx context.Context, b *entity.Budget) error {
+	conn, err := ConnFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	err = conn.QueryRow(ctx,
+		`UPDATE budgets
+		 SET category_id = $1, period = $2, amount = $3, income_percentage = $4, income_category_id = $5,
+		     rollover = $6, start_date = $7, end_date = $8, alert_thresholds = $9, updated_at = NOW()
+		 WHERE id = $10
+		 RETURNING updated_at`,
+		b.CategoryID, b.Period, b.Amount, b.IncomePercentage, b.IncomeCategoryID,
+		b.Rollover, b.StartDate, b.EndDate, b.AlertThresholds, b.ID,
+	).Scan(&b.UpdatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return domain.ErrNotFound
+		}
+		if isDuplicateKey(err) {
+			return domain.ErrDuplicateBudget
+		}
+		if isForeignKeyViolation(err) {
+			return domain.ErrNotFound
+		}
+		return err
+	}
+	return nil
+}
+
+func (r *BudgetRepo) Delete(ctx context.Context, id uuid.UUID) error {
+	conn, err := ConnFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	result, err := conn.Exec(ctx, `DELETE FROM budgets WHERE id = $1`, id)
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+func (r *BudgetRepo) FindByID(ctx context.Context, id uuid.UUID) (*entity.Budget, error) {
+	conn, err := ConnFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var b entity.Budget
+	if err := scanBudget(conn.QueryRow(ctx, budgetSelect+` WHERE b.id = $1`, id), &b); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, err
+	}
+	return &b, nil
+}
+
+func (r *BudgetRepo) FindAll(ctx context.Context) ([]entity.Budget, error) {
+	conn, err := ConnFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := conn.Query(ctx, budgetSelect+` ORDER BY b.created_at`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var budgets []entity.Budget
+	for rows.Next() {
+		var b entity.Budget
+		if err := scanBudget(rows, &b); err != nil {
+			return nil, err
+		}
+		budgets = append(budgets, b)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if budgets == nil {
+		budgets = []entity.Budget{}
+	}
+	return budgets, nil
+}
+
+// FillPeriodTotals reads, for every period at once, the expenses in the
+// budget's category (all expenses for a global budget) and the income it is
+// based on. Amounts are converted to the base currency at each transaction's
+// date and split transactions count per line, as in the expense limits.
+func (r *BudgetRepo) FillPeriodTotals(ctx context.Context, b *entity.Budget, periods []entity.BudgetPeriod, userID *uuid.UUID) error {
+	conn, err := ConnFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	starts := make([]string, len(periods))
+	ends := make([]string, len(periods))
+	for i, p := range periods {
+		starts[i] = p.Start
+		ends[i] = p.End
+	}
+
+	userFilter := ""
+	args := []any{starts, ends, b.CategoryID, b.IncomeCategoryID}
+	if userID != nil {
+		args = append(args, *userID)
+		userFilter = fmt.Sprintf(" AND t.user_id = $%d", len(args))
+	}
+
+	query := fmt.Sprintf(
+		`SELECT p.idx,
+		        COALESCE(SUM(base_amount(t.amount, t.currency, t.date))
+		                 FILTER (WHERE t.type = 'expense' AND ($3::uuid IS NULL OR t.category_id = $3)), 0),
+		        COALESCE(SUM(base_amount(t.amount, t.currency, t.date))
+		                 FILTER (WHERE t.type = 'income' AND ($4::uuid IS NULL OR t.category_id = $4)), 0)
+		 FROM unnest($1::date[], $2::date[]) WITH ORDINALITY AS p(start_date, end_date, idx)
+		 LEFT JOIN transaction_lines t
+		   ON t.date BETWEEN p.start_date AND p.end_date
+		  AND t.type IN ('expense', 'income')%s
+		 GROUP BY p.idx
+		 ORDER BY p.idx`, userFilter)
+
+	rows, err := conn.Query(ctx, query, args...)
+	if err != nil {
+		return rateError(err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var idx int
+		var spent, income entity.Money
+		if err := rows.Scan(&idx, &spent, &income); err != nil {
+			return err
+		}
+		periods[idx-1].Spent = spent
+		periods[idx-1].Income = income
+	}
+	return rateError(rows.Err())
+}
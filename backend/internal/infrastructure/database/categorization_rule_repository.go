@@ -0,0 +1,130 @@
+package database
+
+import (
+	"context"
+	"errors"
+
+	"github.com/dcunha/finance/backend/internal/domain"
+	"github.com/dcunha/finance/backend/internal/domain/entity"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+type CategorizationRuleRepo struct{}
+
+func NewCategorizationRuleRepo() *CategorizationRuleRepo {
+	return &CategorizationRuleRepo{}
+}
+
+const ruleSelect = `SELECT r.id, r.user_id, r.name, r.priority, COALESCE(r.description_contains, ''), COALESCE(r.type, ''),
+		        r.min_amount, r.max_amount, r.account_id, r.category_id, c.name, r.is_active, r.created_at, r.updated_at
+		 FROM categorization_rules r
+		 JOIN categories c ON r.category_id = c.id`
+
+func scanRule(row pgx.Row, rule *entity.CategorizationRule) error {
+	return row.Scan(&rule.ID, &rule.UserID, &rule.Name, &rule.Priority, &rule.DescriptionContains, &rule.Type,
+		&rule.MinAmount, &rule.MaxAmount, &rule.AccountID, &rule.CategoryID, &rule.CategoryName, &rule.IsActive,
+		&rule.CreatedAt, &rule.UpdatedAt)
+}
+
+func (r *CategorizationRuleRepo) Create(ctx context.Context, rule *entity.CategorizationRule) error {
+	conn, err := ConnFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	return conn.QueryRow(ctx,
+		`INSERT INTO categorization_rules (user_id, name, priority, description_contains, type,
+		        min_amount, max_amount, account_id, category_id, is_active)
+		 VALUES ($1, $2, $3, NULLIF($4, ''), NULLIF($5, ''), $6, $7, $8, $9, $10)
+		 RETURNING id, created_at, updated_at`,
+		rule.UserID, rule.Name, rule.Priority, rule.DescriptionContains, rule.Type,
+		rule.MinAmount, rule.MaxAmount, rule.AccountID, rule.CategoryID, rule.IsActive,
+	).Scan(&rule.ID, &rule.CreatedAt, &rule.UpdatedAt)
+}
+
+func (r *CategorizationRuleRepo) Update(ctx context.Context, rule *entity.CategorizationRule) error {
+	conn, err := ConnFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	err = conn.QueryRow(ctx,
+		`UPDATE categorization_rules SET name = $1, priority = $2, description_contains = NULLIF($3, ''),
+		        type = NULLIF($4, ''), min_amount = $5, max_amount = $6, account_id = $7, category_id = $8,
+		        is_active = $9, updated_at = NOW()
+		 WHERE id = $10
+		 RETURNING updated_at`,
+		rule.Name, rule.Priority, rule.DescriptionContains, rule.Type, rule.MinAmount, rule.MaxAmount,
+		rule.AccountID, rule.CategoryID, rule.IsActive, rule.ID,
+	).Scan(&rule.UpdatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return domain.ErrNotFound
+		}
+		return err
+	}
+	return nil
+}
+
+func (r *CategorizationRuleRepo) Delete(ctx context.Context, id uuid.UUID) error {
+	conn, err := ConnFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	result, err := conn.Exec(ctx, `DELETE FROM categorization_rules WHERE id = $1`, id)
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+func (r *CategorizationRuleRepo) FindByID(ctx context.Context, id uuid.UUID) (*entity.CategorizationRule, error) {
+	conn, err := ConnFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var rule entity.CategorizationRule
+	if err := scanRule(conn.QueryRow(ctx, ruleSelect+` WHERE r.id = $1`, id), &rule); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, err
+	}
+	return &rule, nil
+}
+
+// FindAll returns the rules in evaluation order.
+func (r *CategorizationRuleRepo) FindAll(ctx context.Context) ([]entity.CategorizationRule, error) {
+	conn, err := ConnFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := conn.Query(ctx, ruleSelect+` ORDER BY r.priority ASC, r.created_at ASC`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var rules []entity.CategorizationRule
+	for rows.Next() {
+		var rule entity.CategorizationRule
+		if err := scanRule(rows, &rule); err != nil {
+			return nil, err
+		}
+		rules = append(rules, rule)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if rules == nil {
+		rules = []entity.CategorizationRule{}
+	}
+	return rules, nil
+}
@@ -167,7 +167,8 @@ func (r *CategoryRepo) IsInUse(ctx context.Context, id uuid.UUID) (bool, error)
 
 	var exists bool
 	err = conn.QueryRow(ctx,
-		`SELECT EXISTS(SELECT 1 FROM transactions WHERE category_id = $1)`, id,
+		`SELECT EXISTS(SELECT 1 FROM transactions WHERE category_id = $1)
+		     OR EXISTS(SELECT 1 FROM transaction_splits WHERE category_id = $1)`, id,
 	).Scan(&exists)
 	if err != nil {
 		return false, err
@@ -188,7 +189,8 @@ func (r *CategoryRepo) IsSubtreeInUse(ctx context.Context, id uuid.UUID) (bool,
 			UNION ALL
 			SELECT c.id FROM categories c INNER JOIN subtree s ON c.parent_id = s.id
 		)
-		SELECT EXISTS(SELECT 1 FROM transactions WHERE category_id IN (SELECT id FROM subtree))`, id,
+		SELECT EXISTS(SELECT 1 FROM transactions WHERE category_id IN (SELECT id FROM subtree))
+		    OR EXISTS(SELECT 1 FROM transaction_splits WHERE category_id IN (SELECT id FROM subtree))`, id,
 	).Scan(&exists)
 	if err != nil {
 		return false, err
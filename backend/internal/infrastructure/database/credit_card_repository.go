@@ -0,0 +1,255 @@
+package database
+
+import (
+	"context"
+	"errors"
+
+	"github.com/dcunha/finance/backend/internal/domain"
+	"github.com/dcunha/finance/backend/internal/domain/entity"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+type CreditCardRepo struct{}
+
+func NewCreditCardRepo() *CreditCardRepo {
+	return &CreditCardRepo{}
+}
+
+// Balances and statement totals are in the card account's currency; purchases
+// in another currency are converted at the rate of their date.
+const creditCardSelect = `SELECT cc.id, cc.account_id, a.name, cc.closing_day, cc.due_day, cc.credit_limit,
+		        a.initial_balance + COALESCE((
+		            SELECT SUM(CASE WHEN t.type = 'income' OR t.transfer_direction = 'in' THEN 1 ELSE -1 END
+		                       * convert_amount(t.amount, t.currency, a.currency, t.date))
+		            FROM transactions t
+		            WHERE t.account_id = a.id
+		        ), 0) AS balance,
+		        cc.created_at, cc.updated_at
+		 FROM credit_cards cc
+		 JOIN accounts a ON cc.account_id = a.id`
+
+const statementSelect = `SELECT s.id, s.credit_card_id, s.month, s.year, s.closing_date::text, s.due_date::text,
+		        COALESCE(SUM(CASE WHEN t.type = 'expense' THEN 1 ELSE -1 END
+		                     * convert_amount(t.amount, t.currency, a.currency, t.date)), 0) AS total,
+		        s.paid_amount, s.paid_at, s.created_at, s.updated_at
+		 FROM credit_card_statements s
+		 LEFT JOIN transactions t ON t.statement_id = s.id
+		 LEFT JOIN accounts a ON a.id = t.account_id`
+
+// Create inserts the card together with its backing account.
+func (r *CreditCardRepo) Create(ctx context.Context, card *entity.CreditCard, userID uuid.UUID) error {
+	conn, err := ConnFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	dbTx, err := conn.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer dbTx.Rollback(ctx)
+
+	err = dbTx.QueryRow(ctx,
+		`INSERT INTO accounts (user_id, name, type, currency)
+		 VALUES ($1, $2, 'credit_card', (SELECT base_currency FROM settings))
+		 RETURNING id`,
+		userID, card.Name,
+	).Scan(&card.AccountID)
+	if err != nil {
+		if isDuplicateKey(err) {
+			return domain.ErrDuplicateAccount
+		}
+		return err
+	}
+
+	err = dbTx.QueryRow(ctx,
+		`INSERT INTO credit_cards (account_id, closing_day, due_day, credit_limit)
+		 VALUES ($1, $2, $3, $4)
+		 RETURNING id, created_at, updated_at`,
+		card.AccountID, card.ClosingDay, card.DueDay, card.CreditLimit,
+	).Scan(&card.ID, &card.CreatedAt, &card.UpdatedAt)
+	if err != nil {
+		return err
+	}
+
+	return dbTx.Commit(ctx)
+}
+
+func (r *CreditCardRepo) Update(ctx context.Context, card *entity.CreditCard) error {
+	conn, err := ConnFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	dbTx, err := conn.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer dbTx.Rollback(ctx)
+
+	err = dbTx.QueryRow(ctx,
+		`UPDATE credit_cards SET closing_day = $1, due_day = $2, credit_limit = $3, updated_at = NOW()
+		 WHERE id = $4
+		 RETURNING account_id, updated_at`,
+		card.ClosingDay, card.DueDay, card.CreditLimit, card.ID,
+	).Scan(&card.AccountID, &card.UpdatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return domain.ErrNotFound
+		}
+		return err
+	}
+
+	if _, err := dbTx.Exec(ctx,
+		`UPDATE accounts SET name = $1, updated_at = NOW() WHERE id = $2`, card.Name, card.AccountID,
+	); err != nil {
+		if isDuplicateKey(err) {
+			return domain.ErrDuplicateAccount
+		}
+		return err
+	}
+
+	return dbTx.Commit(ctx)
+}
+
+func (r *CreditCardRepo) FindByID(ctx context.Context, id uuid.UUID) (*entity.CreditCard, error) {
+	return r.findOne(ctx, creditCardSelect+` WHERE cc.id = $1`, id)
+}
+
+func (r *CreditCardRepo) FindByAccountID(ctx context.Context, accountID uuid.UUID) (*entity.CreditCard, error) {
+	return r.findOne(ctx, creditCardSelect+` WHERE cc.account_id = $1`, accountID)
+}
+
+func (r *CreditCardRepo) findOne(ctx context.Context, query string, arg any) (*entity.CreditCard, error) {
+	conn, err := ConnFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var cc entity.CreditCard
+	err = conn.QueryRow(ctx, query, arg).Scan(
+		&cc.ID, &cc.AccountID, &cc.Name, &cc.ClosingDay, &cc.DueDay, &cc.CreditLimit, &cc.Balance, &cc.CreatedAt, &cc.UpdatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, rateError(err)
+	}
+	return &cc, nil
+}
+
+func (r *CreditCardRepo) FindAll(ctx context.Context) ([]entity.CreditCard, error) {
+	conn, err := ConnFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := conn.Query(ctx, creditCardSelect+` ORDER BY a.name ASC`)
+	if err != nil {
+		return nil, rateError(err)
+	}
+	defer rows.Close()
+
+	var cards []entity.CreditCard
+	for rows.Next() {
+		var cc entity.CreditCard
+		if err := rows.Scan(&cc.ID, &cc.AccountID, &cc.Name, &cc.ClosingDay, &cc.DueDay, &cc.CreditLimit, &cc.Balance, &cc.CreatedAt, &cc.UpdatedAt); err != nil {
+			return nil, err
+		}
+		cards = append(cards, cc)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, rateError(err)
+	}
+	if cards == nil {
+		cards = []entity.CreditCard{}
+	}
+	return cards, nil
+}
+
+// FindOrCreateStatement loads the card's statement for st.Month/st.Year,
+// creating it with st's dates if it does not exist yet.
+func (r *CreditCardRepo) FindOrCreateStatement(ctx context.Context, st *entity.CreditCardStatement) error {
+	conn, err := ConnFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	return conn.QueryRow(ctx,
+		`INSERT INTO credit_card_statements (credit_card_id, month, year, closing_date, due_date)
+		 VALUES ($1, $2, $3, $4, $5)
+		 ON CONFLICT (credit_card_id, year, month) DO UPDATE SET credit_card_id = EXCLUDED.credit_card_id
+		 RETURNING id, closing_date::text, due_date::text, paid_amount, paid_at, created_at, updated_at`,
+		st.CreditCardID, st.Month, st.Year, st.ClosingDate, st.DueDate,
+	).Scan(&st.ID, &st.ClosingDate, &st.DueDate, &st.PaidAmount, &st.PaidAt, &st.CreatedAt, &st.UpdatedAt)
+}
+
+func (r *CreditCardRepo) FindStatementByID(ctx context.Context, id uuid.UUID) (*entity.CreditCardStatement, error) {
+	conn, err := ConnFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var st entity.CreditCardStatement
+	err = conn.QueryRow(ctx, statementSelect+` WHERE s.id = $1 GROUP BY s.id`, id).Scan(
+		&st.ID, &st.CreditCardID, &st.Month, &st.Year, &st.ClosingDate, &st.DueDate,
+		&st.Total, &st.PaidAmount, &st.PaidAt, &st.CreatedAt, &st.UpdatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, rateError(err)
+	}
+	return &st, nil
+}
+
+func (r *CreditCardRepo) FindStatements(ctx context.Context, cardID uuid.UUID) ([]entity.CreditCardStatement, error) {
+	conn, err := ConnFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := conn.Query(ctx,
+		statementSelect+` WHERE s.credit_card_id = $1 GROUP BY s.id ORDER BY s.year DESC, s.month DESC`, cardID)
+	if err != nil {
+		return nil, rateError(err)
+	}
+	defer rows.Close()
+
+	var statements []entity.CreditCardStatement
+	for rows.Next() {
+		var st entity.CreditCardStatement
+		if err := rows.Scan(&st.ID, &st.CreditCardID, &st.Month, &st.Year, &st.ClosingDate, &st.DueDate,
+			&st.Total, &st.PaidAmount, &st.PaidAt, &st.CreatedAt, &st.UpdatedAt); err != nil {
+			return nil, err
+		}
+		statements = append(statements, st)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, rateError(err)
+	}
+	if statements == nil {
+		statements = []entity.CreditCardStatement{}
+	}
+	return statements, nil
+}
+
+func (r *CreditCardRepo) AddStatementPayment(ctx context.Context, id uuid.UUID, amount entity.Money) error {
+	conn, err := ConnFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	result, err := conn.Exec(ctx,
+		`UPDATE credit_card_statements
+		 SET paid_amount = paid_amount + $1, paid_at = NOW(), updated_at = NOW()
+		 WHERE id = $2`, amount, id)
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
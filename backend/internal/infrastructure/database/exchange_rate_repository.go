@@ -0,0 +1,218 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/dcunha/finance/backend/internal/domain"
+	"github.com/dcunha/finance/backend/internal/domain/entity"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+type ExchangeRateRepo struct{}
+
+func NewExchangeRateRepo() *ExchangeRateRepo {
+	return &ExchangeRateRepo{}
+}
+
+const exchangeRateSelect = `SELECT id, from_currency, to_currency, date::text, rate, source, created_at, updated_at
+		 FROM exchange_rates`
+
+func scanExchangeRate(row pgx.Row, er *entity.ExchangeRate) error {
+	return row.Scan(&er.ID, &er.FromCurrency, &er.ToCurrency, &er.Date, &er.Rate, &er.Source, &er.CreatedAt, &er.UpdatedAt)
+}
+
+func (r *ExchangeRateRepo) Create(ctx context.Context, er *entity.ExchangeRate) error {
+	conn, err := ConnFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	err = conn.QueryRow(ctx,
+		`INSERT INTO exchange_rates (from_currency, to_currency, date, rate, source)
+		 VALUES ($1, $2, $3, $4, $5)
+		 RETURNING id, created_at, updated_at`,
+		er.FromCurrency, er.ToCurrency, er.Date, er.Rate, er.Source,
+	).Scan(&er.ID, &er.CreatedAt, &er.UpdatedAt)
+	if err != nil {
+		if isDuplicateKey(err) {
+			return domain.ErrDuplicateRate
+		}
+		return err
+	}
+	return nil
+}
+
+func (r *ExchangeRateRepo) Update(ctx context.Context, er *entity.ExchangeRate) error {
+	conn, err := ConnFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	err = conn.QueryRow(ctx,
+		`UPDATE exchange_rates
+		 SET from_currency = $1, to_currency = $2, date = $3, rate = $4, source = $5, updated_at = NOW()
+		 WHERE id = $6
+		 RETURNING updated_at`,
+		er.FromCurrency, er.ToCurrency, er.Date, er.Rate, er.Source, er.ID,
+	).Scan(&er.UpdatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return domain.ErrNotFound
+		}
+		if isDuplicateKey(err) {
+			return domain.ErrDuplicateRate
+		}
+		return err
+	}
+	return nil
+}
+
+func (r *ExchangeRateRepo) Delete(ctx context.Context, id uuid.UUID) error {
+	conn, err := ConnFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	result, err := conn.Exec(ctx, `DELETE FROM exchange_rates WHERE id = $1`, id)
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+func (r *ExchangeRateRepo) FindByID(ctx context.Context, id uuid.UUID) (*entity.ExchangeRate, error) {
+	conn, err := ConnFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var er entity.ExchangeRate
+	if err := scanExchangeRate(conn.QueryRow(ctx, exchangeRateSelect+` WHERE id = $1`, id), &er); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, err
+	}
+	return &er, nil
+}
+
+func (r *ExchangeRateRepo) FindAll(ctx context.Context, filter entity.ExchangeRateFilter) ([]entity.ExchangeRate, error) {
+	conn, err := ConnFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	where := ` WHERE 1=1`
+	args := []any{}
+	argIdx := 1
+	if filter.Currency != "" {
+		where += fmt.Sprintf(` AND (from_currency = $%[1]d OR to_currency = $%[1]d)`, argIdx)
+		args = append(args, filter.Currency)
+		argIdx++
+	}
+	if filter.StartDate != "" {
+		where += fmt.Sprintf(` AND date >= $%d`, argIdx)
+		args = append(args, filter.StartDate)
+		argIdx++
+	}
+	if filter.EndDate != "" {
+		where += fmt.Sprintf(` AND date <= $%d`, argIdx)
+		args = append(args, filter.EndDate)
+	}
+
+	rows, err := conn.Query(ctx, exchangeRateSelect+where+` ORDER BY date DESC, from_currency, to_currency`, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var rates []entity.ExchangeRate
+	for rows.Next() {
+		var er entity.ExchangeRate
+		if err := scanExchangeRate(rows, &er); err != nil {
+			return nil, err
+		}
+		rates = append(rates, er)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if rates == nil {
+		rates = []entity.ExchangeRate{}
+	}
+	return rates, nil
+}
+
+// Upsert stores a fetched rate. A manual rate for the same pair and date wins;
+// the fetched one is then discarded and er keeps no ID.
+func (r *ExchangeRateRepo) Upsert(ctx context.Context, er *entity.ExchangeRate) error {
+	conn, err := ConnFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	err = conn.QueryRow(ctx,
+		`INSERT INTO exchange_rates (from_currency, to_currency, date, rate, source)
+		 VALUES ($1, $2, $3, $4, $5)
+		 ON CONFLICT (from_currency, to_currency, date) DO UPDATE
+		 SET rate = EXCLUDED.rate, source = EXCLUDED.source, updated_at = NOW()
+		 WHERE exchange_rates.source <> 'manual'
+		 RETURNING id, created_at, updated_at`,
+		er.FromCurrency, er.ToCurrency, er.Date, er.Rate, er.Source,
+	).Scan(&er.ID, &er.CreatedAt, &er.UpdatedAt)
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return err
+	}
+	return nil
+}
+
+// FindRate evaluates the exchange_rate SQL function, so Go-side conversions
+// pick the same rate as the aggregates.
+func (r *ExchangeRateRepo) FindRate(ctx context.Context, from, to, date string) (float64, error) {
+	conn, err := ConnFromContext(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	var rate *float64
+	if err := conn.QueryRow(ctx, `SELECT exchange_rate($1, $2, $3::date)::float8`, from, to, date).Scan(&rate); err != nil {
+		return 0, err
+	}
+	if rate == nil {
+		return 0, domain.ErrNotFound
+	}
+	return *rate, nil
+}
+
+func (r *ExchangeRateRepo) CurrenciesInUse(ctx context.Context) ([]string, error) {
+	conn, err := ConnFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := conn.Query(ctx,
+		`SELECT currency FROM accounts
+		 UNION
+		 SELECT currency FROM transactions
+		 ORDER BY currency`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	currencies := []string{}
+	for rows.Next() {
+		var c string
+		if err := rows.Scan(&c); err != nil {
+			return nil, err
+		}
+		currencies = append(currencies, c)
+	}
+	return currencies, rows.Err()
+}
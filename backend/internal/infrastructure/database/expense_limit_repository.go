@@ -25,12 +25,12 @@ func (r *ExpenseLimitRepo) Upsert(ctx context.Context, limit *entity.ExpenseLimi
 
 	if limit.CategoryID != nil {
 		err := conn.QueryRow(ctx,
-			`INSERT INTO expense_limits (user_id, category_id, month, year, amount)
-			 VALUES ($1, $2, $3, $4, $5)
+			`INSERT INTO expense_limits (user_id, category_id, month, year, amount, alert_thresholds)
+			 VALUES ($1, $2, $3, $4, $5, $6)
 			 ON CONFLICT (category_id, month, year)
-			 DO UPDATE SET amount = EXCLUDED.amount, updated_at = NOW()
+			 DO UPDATE SET amount = EXCLUDED.amount, alert_thresholds = EXCLUDED.alert_thresholds, updated_at = NOW()
 			 RETURNING id, created_at, updated_at`,
-			limit.UserID, limit.CategoryID, limit.Month, limit.Year, limit.Amount,
+			limit.UserID, limit.CategoryID, limit.Month, limit.Year, limit.Amount, limit.AlertThresholds,
 		).Scan(&limit.ID, &limit.CreatedAt, &limit.UpdatedAt)
 		if err != nil {
 			return err
@@ -45,20 +45,20 @@ func (r *ExpenseLimitRepo) Upsert(ctx context.Context, limit *entity.ExpenseLimi
 		),
 		updated AS (
 			UPDATE expense_limits
-			SET amount = $3, updated_at = NOW()
+			SET amount = $3, alert_thresholds = $5, updated_at = NOW()
 			WHERE id = (SELECT id FROM existing)
 			RETURNING id, created_at, updated_at
 		),
 		inserted AS (
-			INSERT INTO expense_limits (user_id, category_id, month, year, amount)
-			SELECT $4, NULL, $1, $2, $3
+			INSERT INTO expense_limits (user_id, category_id, month, year, amount, alert_thresholds)
+			SELECT $4, NULL, $1, $2, $3, $5
 			WHERE NOT EXISTS (SELECT 1 FROM existing)
 			RETURNING id, created_at, updated_at
 		)
 		SELECT id, created_at, updated_at FROM updated
 		UNION ALL
 		SELECT id, created_at, updated_at FROM inserted`,
-		limit.Month, limit.Year, limit.Amount, limit.UserID,
+		limit.Month, limit.Year, limit.Amount, limit.UserID, limit.AlertThresholds,
 	).Scan(&limit.ID, &limit.CreatedAt, &limit.UpdatedAt)
 	if err != nil {
 		return err
@@ -73,10 +73,10 @@ func (r *ExpenseLimitRepo) Update(ctx context.Context, limit *entity.ExpenseLimi
 	}
 
 	err = conn.QueryRow(ctx,
-		`UPDATE expense_limits SET amount = $1, updated_at = NOW()
-		 WHERE id = $2
+		`UPDATE expense_limits SET amount = $1, alert_thresholds = $2, updated_at = NOW()
+		 WHERE id = $3
 		 RETURNING updated_at`,
-		limit.Amount, limit.ID,
+		limit.Amount, limit.AlertThresholds, limit.ID,
 	).Scan(&limit.UpdatedAt)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
@@ -113,12 +113,12 @@ func (r *ExpenseLimitRepo) FindByID(ctx context.Context, id uuid.UUID) (*entity.
 	var categoryName *string
 	err = conn.QueryRow(ctx,
 		`SELECT el.id, el.user_id, el.category_id, c.name AS category_name,
-		        el.month, el.year, el.amount, el.created_at, el.updated_at
+		        el.month, el.year, el.amount, el.alert_thresholds, el.created_at, el.updated_at
 		 FROM expense_limits el
 		 LEFT JOIN categories c ON el.category_id = c.id
 		 WHERE el.id = $1`, id,
 	).Scan(&limit.ID, &limit.UserID, &limit.CategoryID, &categoryName,
-		&limit.Month, &limit.Year, &limit.Amount, &limit.CreatedAt, &limit.UpdatedAt)
+		&limit.Month, &limit.Year, &limit.Amount, &limit.AlertThresholds, &limit.CreatedAt, &limit.UpdatedAt)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, domain.ErrNotFound
@@ -139,7 +139,7 @@ func (r *ExpenseLimitRepo) FindAll(ctx context.Context, month, year int) ([]enti
 
 	rows, err := conn.Query(ctx,
 		`SELECT el.id, el.user_id, el.category_id, c.name AS category_name,
-		        el.month, el.year, el.amount, el.created_at, el.updated_at
+		        el.month, el.year, el.amount, el.alert_thresholds, el.created_at, el.updated_at
 		 FROM expense_limits el
 		 LEFT JOIN categories c ON el.category_id = c.id
 		 WHERE el.month = $1 AND el.year = $2
@@ -156,7 +156,7 @@ func (r *ExpenseLimitRepo) FindAll(ctx context.Context, month, year int) ([]enti
 		var limit entity.ExpenseLimit
 		var categoryName *string
 		if err := rows.Scan(&limit.ID, &limit.UserID, &limit.CategoryID, &categoryName,
-			&limit.Month, &limit.Year, &limit.Amount, &limit.CreatedAt, &limit.UpdatedAt); err != nil {
+			&limit.Month, &limit.Year, &limit.Amount, &limit.AlertThresholds, &limit.CreatedAt, &limit.UpdatedAt); err != nil {
 			return nil, err
 		}
 		if categoryName != nil {
@@ -176,6 +176,9 @@ func (r *ExpenseLimitRepo) FindAll(ctx context.Context, month, year int) ([]enti
 	return limits, nil
 }
 
+// GetLimitsProgress returns each limit of the month with what was spent against
+// it, in the base currency. Spending is read per category line, so each line
+// of a split transaction counts towards its own category.
 func (r *ExpenseLimitRepo) GetLimitsProgress(ctx context.Context, month, year int, userID *uuid.UUID) ([]entity.LimitProgress, error) {
 	conn, err := ConnFromContext(ctx)
 	if err != nil {
@@ -194,13 +197,13 @@ func (r *ExpenseLimitRepo) GetLimitsProgress(ctx context.Context, month, year in
 
 	query := fmt.Sprintf(
 		`SELECT el.id, el.user_id, el.category_id, c.name AS category_name,
-		        el.month, el.year, el.amount, el.created_at, el.updated_at,
+		        el.month, el.year, el.amount, el.alert_thresholds, el.created_at, el.updated_at,
 		        COALESCE(spent.total, 0) AS spent
 		 FROM expense_limits el
 		 LEFT JOIN categories c ON el.category_id = c.id
 		 LEFT JOIN LATERAL (
-			SELECT SUM(t.amount) AS total
-			FROM transactions t
+			SELECT SUM(base_amount(t.amount, t.currency, t.date)) AS total
+			FROM transaction_lines t
 			WHERE t.type = 'expense'
 			  AND EXTRACT(MONTH FROM t.date::date) = el.month
 			  AND EXTRACT(YEAR FROM t.date::date) = el.year
@@ -216,7 +219,7 @@ func (r *ExpenseLimitRepo) GetLimitsProgress(ctx context.Context, month, year in
 
 	rows, err := conn.Query(ctx, query, args...)
 	if err != nil {
-		return nil, err
+		return nil, rateError(err)
 	}
 	defer rows.Close()
 
@@ -226,7 +229,7 @@ func (r *ExpenseLimitRepo) GetLimitsProgress(ctx context.Context, month, year in
 		var categoryName *string
 		if err := rows.Scan(
 			&lp.Limit.ID, &lp.Limit.UserID, &lp.Limit.CategoryID, &categoryName,
-			&lp.Limit.Month, &lp.Limit.Year, &lp.Limit.Amount,
+			&lp.Limit.Month, &lp.Limit.Year, &lp.Limit.Amount, &lp.Limit.AlertThresholds,
 			&lp.Limit.CreatedAt, &lp.Limit.UpdatedAt,
 			&lp.Spent,
 		); err != nil {
@@ -240,13 +243,13 @@ func (r *ExpenseLimitRepo) GetLimitsProgress(ctx context.Context, month, year in
 			lp.Remaining = 0
 		}
 		if lp.Limit.Amount > 0 {
-			lp.Percentage = (lp.Spent / lp.Limit.Amount) * 100
+			lp.Percentage = float64(lp.Spent) / float64(lp.Limit.Amount) * 100
 		}
 		progress = append(progress, lp)
 	}
 
 	if err := rows.Err(); err != nil {
-		return nil, err
+		return nil, rateError(err)
 	}
 
 	if progress == nil {
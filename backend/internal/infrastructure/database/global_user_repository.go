@@ -19,6 +19,25 @@ func NewGlobalUserRepo(pool *pgxpool.Pool) *GlobalUserRepo {
 	return &GlobalUserRepo{pool: pool}
 }
 
+const globalUserSelect = `SELECT id, name, email, password_hash, email_verified, email_token, email_token_expires_at,
+		        password_reset_token, password_reset_expires_at, totp_secret, totp_enabled, totp_last_step,
+		        max_owned_tenants, created_at, updated_at
+		 FROM global_users`
+
+func scanGlobalUser(row pgx.Row) (*entity.GlobalUser, error) {
+	var u entity.GlobalUser
+	err := row.Scan(&u.ID, &u.Name, &u.Email, &u.PasswordHash, &u.EmailVerified, &u.EmailToken, &u.EmailTokenExpiresAt,
+		&u.PasswordResetToken, &u.PasswordResetExpiresAt, &u.TOTPSecret, &u.TOTPEnabled, &u.TOTPLastStep,
+		&u.MaxOwnedTenants, &u.CreatedAt, &u.UpdatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, err
+	}
+	return &u, nil
+}
+
 func (r *GlobalUserRepo) Create(ctx context.Context, user *entity.GlobalUser) error {
 	err := r.pool.QueryRow(ctx,
 		`INSERT INTO global_users (name, email, password_hash, email_verified, email_token, email_token_expires_at, max_owned_tenants)
@@ -36,43 +55,27 @@ func (r *GlobalUserRepo) Create(ctx context.Context, user *entity.GlobalUser) er
 }
 
 func (r *GlobalUserRepo) FindByEmail(ctx context.Context, email string) (*entity.GlobalUser, error) {
-	var u entity.GlobalUser
-	err := r.pool.QueryRow(ctx,
-		`SELECT id, name, email, password_hash, email_verified, email_token, email_token_expires_at, max_owned_tenants, created_at, updated_at
-		 FROM global_users WHERE email = $1`, email,
-	).Scan(&u.ID, &u.Name, &u.Email, &u.PasswordHash, &u.EmailVerified, &u.EmailToken, &u.EmailTokenExpiresAt, &u.MaxOwnedTenants, &u.CreatedAt, &u.UpdatedAt)
-	if err != nil {
-		if errors.Is(err, pgx.ErrNoRows) {
-			return nil, domain.ErrNotFound
-		}
-		return nil, err
-	}
-	return &u, nil
+	return scanGlobalUser(r.pool.QueryRow(ctx, globalUserSelect+` WHERE email = $1`, email))
 }
 
 func (r *GlobalUserRepo) FindByID(ctx context.Context, id uuid.UUID) (*entity.GlobalUser, error) {
-	var u entity.GlobalUser
-	err := r.pool.QueryRow(ctx,
-		`SELECT id, name, email, password_hash, email_verified, email_token, email_token_expires_at, max_owned_tenants, created_at, updated_at
-		 FROM global_users WHERE id = $1`, id,
-	).Scan(&u.ID, &u.Name, &u.Email, &u.PasswordHash, &u.EmailVerified, &u.EmailToken, &u.EmailTokenExpiresAt, &u.MaxOwnedTenants, &u.CreatedAt, &u.UpdatedAt)
-	if err != nil {
-		if errors.Is(err, pgx.ErrNoRows) {
-			return nil, domain.ErrNotFound
-		}
-		return nil, err
-	}
-	return &u, nil
+	return scanGlobalUser(r.pool.QueryRow(ctx, globalUserSelect+` WHERE id = $1`, id))
 }
 
 func (r *GlobalUserRepo) Update(ctx context.Context, user *entity.GlobalUser) error {
 	err := r.pool.QueryRow(ctx,
 		`UPDATE global_users SET name = $1, email = $2, password_hash = $3, email_verified = $4,
-		 email_token = $5, email_token_expires_at = $6, max_owned_tenants = $7, updated_at = NOW()
-		 WHERE id = $8
+		 email_token = $5, email_token_expires_at = $6,
+		 password_reset_token = $7, password_reset_expires_at = $8,
+		 totp_secret = $9, totp_enabled = $10, totp_last_step = GREATEST(totp_last_step, $11),
+		 max_owned_tenants = $12, updated_at = NOW()
+		 WHERE id = $13
 		 RETURNING updated_at`,
 		user.Name, user.Email, user.PasswordHash, user.EmailVerified,
-		user.EmailToken, user.EmailTokenExpiresAt, user.MaxOwnedTenants, user.ID,
+		user.EmailToken, user.EmailTokenExpiresAt,
+		user.PasswordResetToken, user.PasswordResetExpiresAt,
+		user.TOTPSecret, user.TOTPEnabled, user.TOTPLastStep,
+		user.MaxOwnedTenants, user.ID,
 	).Scan(&user.UpdatedAt)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
@@ -87,18 +90,36 @@ func (r *GlobalUserRepo) Update(ctx context.Context, user *entity.GlobalUser) er
 }
 
 func (r *GlobalUserRepo) FindByEmailToken(ctx context.Context, token string) (*entity.GlobalUser, error) {
-	var u entity.GlobalUser
+	return scanGlobalUser(r.pool.QueryRow(ctx, globalUserSelect+` WHERE email_token = $1`, token))
+}
+
+func (r *GlobalUserRepo) FindByPasswordResetToken(ctx context.Context, token string) (*entity.GlobalUser, error) {
+	return scanGlobalUser(r.pool.QueryRow(ctx, globalUserSelect+` WHERE password_reset_token = $1`, token))
+}
+
+func (r *GlobalUserRepo) ConsumePasswordResetToken(ctx context.Context, token string) (uuid.UUID, error) {
+	var id uuid.UUID
 	err := r.pool.QueryRow(ctx,
-		`SELECT id, name, email, password_hash, email_verified, email_token, email_token_expires_at, max_owned_tenants, created_at, updated_at
-		 FROM global_users WHERE email_token = $1`, token,
-	).Scan(&u.ID, &u.Name, &u.Email, &u.PasswordHash, &u.EmailVerified, &u.EmailToken, &u.EmailTokenExpiresAt, &u.MaxOwnedTenants, &u.CreatedAt, &u.UpdatedAt)
+		`UPDATE global_users SET password_reset_token = NULL, password_reset_expires_at = NULL, updated_at = NOW()
+		 WHERE password_reset_token = $1 AND password_reset_expires_at > NOW()
+		 RETURNING id`, token,
+	).Scan(&id)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
-			return nil, domain.ErrNotFound
+			return uuid.Nil, domain.ErrNotFound
 		}
-		return nil, err
+		return uuid.Nil, err
 	}
-	return &u, nil
+	return id, nil
+}
+
+func (r *GlobalUserRepo) RecordTOTPStep(ctx context.Context, id uuid.UUID, step int64) (bool, error) {
+	result, err := r.pool.Exec(ctx,
+		`UPDATE global_users SET totp_last_step = $2 WHERE id = $1 AND totp_last_step < $2`, id, step)
+	if err != nil {
+		return false, err
+	}
+	return result.RowsAffected() > 0, nil
 }
 
 func (r *GlobalUserRepo) CountOwnedTenants(ctx context.Context, globalUserID uuid.UUID) (int, error) {
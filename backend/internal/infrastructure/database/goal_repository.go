@@ -0,0 +1,244 @@
+package database
+
+import (
+	"context"
+	"errors"
+
+	"github.com/dcunha/finance/backend/internal/domain"
+	"github.com/dcunha/finance/backend/internal/domain/entity"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+type GoalRepo struct{}
+
+func NewGoalRepo() *GoalRepo {
+	return &GoalRepo{}
+}
+
+const goalSelect = `SELECT id, user_id, name, target_amount, deadline::text, created_at, updated_at
+		 FROM goals`
+
+func scanGoal(row pgx.Row, g *entity.Goal) error {
+	return row.Scan(&g.ID, &g.UserID, &g.Name, &g.TargetAmount, &g.Deadline, &g.CreatedAt, &g.UpdatedAt)
+}
+
+func (r *GoalRepo) Create(ctx context.Context, goal *entity.Goal) error {
+	conn, err := ConnFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	err = conn.QueryRow(ctx,
+		`INSERT INTO goals (user_id, name, target_amount, deadline)
+		 VALUES ($1, $2, $3, $4)
+		 RETURNING id, created_at, updated_at`,
+		goal.UserID, goal.Name, goal.TargetAmount, goal.Deadline,
+	).Scan(&goal.ID, &goal.CreatedAt, &goal.UpdatedAt)
+	if err != nil {
+		if isDuplicateKey(err) {
+			return domain.ErrDuplicateGoal
+		}
+		return err
+	}
+	return nil
+}
+
+func (r *GoalRepo) Update(ctx context.Context, goal *entity.Goal) error {
+	conn, err := ConnFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	err = conn.QueryRow(ctx,
+		`UPDATE goals SET name = $1, target_amount = $2, deadline = $3, updated_at = NOW()
+		 WHERE id = $4
+		 RETURNING updated_at`,
+		goal.Name, goal.TargetAmount, goal.Deadline, goal.ID,
+	).Scan(&goal.UpdatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return domain.ErrNotFound
+		}
+		if isDuplicateKey(err) {
+			return domain.ErrDuplicateGoal
+		}
+		return err
+	}
+	return nil
+}
+
+// Delete removes a goal with its contribution links; the transactions stay.
+func (r *GoalRepo) Delete(ctx context.Context, id uuid.UUID) error {
+	conn, err := ConnFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	result, err := conn.Exec(ctx, `DELETE FROM goals WHERE id = $1`, id)
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+func (r *GoalRepo) FindByID(ctx context.Context, id uuid.UUID) (*entity.Goal, error) {
+	conn, err := ConnFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var g entity.Goal
+	if err := scanGoal(conn.QueryRow(ctx, goalSelect+` WHERE id = $1`, id), &g); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, err
+	}
+	return &g, nil
+}
+
+func (r *GoalRepo) FindAll(ctx context.Context) ([]entity.Goal, error) {
+	conn, err := ConnFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := conn.Query(ctx, goalSelect+` ORDER BY deadline, name`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	goals := []entity.Goal{}
+	for rows.Next() {
+		var g entity.Goal
+		if err := scanGoal(rows, &g); err != nil {
+			return nil, err
+		}
+		goals = append(goals, g)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return goals, nil
+}
+
+func (r *GoalRepo) AddContribution(ctx context.Context, goalID, transactionID uuid.UUID) error {
+	conn, err := ConnFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	_, err = conn.Exec(ctx,
+		`INSERT INTO goal_contributions (goal_id, transaction_id) VALUES ($1, $2)`,
+		goalID, transactionID)
+	if err != nil {
+		if isDuplicateKey(err) {
+			return domain.ErrContributionExists
+		}
+		if isForeignKeyViolation(err) {
+			return domain.ErrNotFound
+		}
+		return err
+	}
+	return nil
+}
+
+func (r *GoalRepo) RemoveContribution(ctx context.Context, goalID, transactionID uuid.UUID) error {
+	conn, err := ConnFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	result, err := conn.Exec(ctx,
+		`DELETE FROM goal_contributions WHERE goal_id = $1 AND transaction_id = $2`,
+		goalID, transactionID)
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+// FindContributions returns the goal's contributions, newest first.
+func (r *GoalRepo) FindContributions(ctx context.Context, goalID uuid.UUID) ([]entity.GoalContribution, error) {
+	conn, err := ConnFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := conn.Query(ctx,
+		`SELECT gc.goal_id, t.id, t.transfer_id, a.name, t.type, t.amount, t.currency,
+		        t.description, t.date::text, gc.created_at
+		 FROM goal_contributions gc
+		 JOIN transactions t ON t.id = gc.transaction_id
+		 JOIN accounts a ON a.id = t.account_id
+		 WHERE gc.goal_id = $1
+		 ORDER BY t.date DESC, gc.created_at DESC`, goalID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	contributions := []entity.GoalContribution{}
+	for rows.Next() {
+		var gc entity.GoalContribution
+		if err := rows.Scan(
+			&gc.GoalID, &gc.TransactionID, &gc.TransferID, &gc.AccountName, &gc.Type, &gc.Amount, &gc.Currency,
+			&gc.Description, &gc.Date, &gc.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		contributions = append(contributions, gc)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return contributions, nil
+}
+
+// GetProgress sums each goal's contributions in the base currency, at the rate
+// of each contribution's date.
+func (r *GoalRepo) GetProgress(ctx context.Context) ([]entity.GoalProgress, error) {
+	conn, err := ConnFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := conn.Query(ctx,
+		`SELECT g.id, g.user_id, g.name, g.target_amount, g.deadline::text, g.created_at, g.updated_at,
+		        COALESCE(SUM(base_amount(t.amount, t.currency, t.date)), 0) AS contributed,
+		        COALESCE(MIN(t.date)::text, '') AS started_on
+		 FROM goals g
+		 LEFT JOIN goal_contributions gc ON gc.goal_id = g.id
+		 LEFT JOIN transactions t ON t.id = gc.transaction_id
+		 GROUP BY g.id
+		 ORDER BY g.deadline, g.name`)
+	if err != nil {
+		return nil, rateError(err)
+	}
+	defer rows.Close()
+
+	progress := []entity.GoalProgress{}
+	for rows.Next() {
+		var gp entity.GoalProgress
+		g := &gp.Goal
+		if err := rows.Scan(
+			&g.ID, &g.UserID, &g.Name, &g.TargetAmount, &g.Deadline, &g.CreatedAt, &g.UpdatedAt,
+			&gp.Contributed, &gp.StartedOn,
+		); err != nil {
+			return nil, err
+		}
+		progress = append(progress, gp)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, rateError(err)
+	}
+	return progress, nil
+}
@@ -1,7 +1,53 @@
 package database
 
-import "strings"
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/dcunha/finance/backend/internal/domain"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// missingRateCode is the SQLSTATE convert_amount raises for a currency pair
+// without any rate.
+const missingRateCode = "FX404"
 
 func isDuplicateKey(err error) bool {
 	return err != nil && strings.Contains(err.Error(), "duplicate key")
 }
+
+func isForeignKeyViolation(err error) bool {
+	return err != nil && strings.Contains(err.Error(), "violates foreign key constraint")
+}
+
+// rateError reports a convert_amount failure as domain.ErrMissingRate,
+// naming the currency pair that has no rate.
+func rateError(err error) error {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == missingRateCode {
+		return fmt.Errorf("%w: %s", domain.ErrMissingRate, pgErr.Detail)
+	}
+	return err
+}
+
+// uuidStrings converts ids to strings so they can be sent as a ::uuid[] parameter.
+func uuidStrings(ids []uuid.UUID) []string {
+	out := make([]string, len(ids))
+	for i, id := range ids {
+		out[i] = id.String()
+	}
+	return out
+}
+
+// parseUUIDs converts the text[] produced by an ARRAY(... ::text) column.
+func parseUUIDs(values []string) []uuid.UUID {
+	ids := make([]uuid.UUID, 0, len(values))
+	for _, v := range values {
+		if id, err := uuid.Parse(v); err == nil {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
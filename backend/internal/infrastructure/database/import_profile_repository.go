@@ -0,0 +1,148 @@
+package database
+
+import (
+	"context"
+	"errors"
+
+	"github.com/dcunha/finance/backend/internal/domain"
+	"github.com/dcunha/finance/backend/internal/domain/entity"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+type ImportProfileRepo struct{}
+
+func NewImportProfileRepo() *ImportProfileRepo {
+	return &ImportProfileRepo{}
+}
+
+const importProfileSelect = `SELECT id, user_id, name, delimiter, has_header, skip_rows,
+		        date_column, description_column, amount_column, external_id_column,
+		        date_format, decimal_separator, negate_amounts,
+		        default_income_category_id, default_expense_category_id, created_at, updated_at
+		 FROM import_profiles`
+
+func scanImportProfile(row pgx.Row, p *entity.ImportProfile) error {
+	return row.Scan(&p.ID, &p.UserID, &p.Name, &p.Delimiter, &p.HasHeader, &p.SkipRows,
+		&p.DateColumn, &p.DescriptionColumn, &p.AmountColumn, &p.ExternalIDColumn,
+		&p.DateFormat, &p.DecimalSeparator, &p.NegateAmounts,
+		&p.DefaultIncomeCategoryID, &p.DefaultExpenseCategoryID, &p.CreatedAt, &p.UpdatedAt)
+}
+
+func (r *ImportProfileRepo) Create(ctx context.Context, p *entity.ImportProfile) error {
+	conn, err := ConnFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	err = conn.QueryRow(ctx,
+		`INSERT INTO import_profiles (user_id, name, delimiter, has_header, skip_rows,
+		        date_column, description_column, amount_column, external_id_column,
+		        date_format, decimal_separator, negate_amounts,
+		        default_income_category_id, default_expense_category_id)
+		 VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)
+		 RETURNING id, created_at, updated_at`,
+		p.UserID, p.Name, p.Delimiter, p.HasHeader, p.SkipRows,
+		p.DateColumn, p.DescriptionColumn, p.AmountColumn, p.ExternalIDColumn,
+		p.DateFormat, p.DecimalSeparator, p.NegateAmounts,
+		p.DefaultIncomeCategoryID, p.DefaultExpenseCategoryID,
+	).Scan(&p.ID, &p.CreatedAt, &p.UpdatedAt)
+	if err != nil {
+		if isDuplicateKey(err) {
+			return domain.ErrDuplicateProfile
+		}
+		return err
+	}
+	return nil
+}
+
+func (r *ImportProfileRepo) Update(ctx context.Context, p *entity.ImportProfile) error {
+	conn, err := ConnFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	err = conn.QueryRow(ctx,
+		`UPDATE import_profiles SET name = $1, delimiter = $2, has_header = $3, skip_rows = $4,
+		        date_column = $5, description_column = $6, amount_column = $7, external_id_column = $8,
+		        date_format = $9, decimal_separator = $10, negate_amounts = $11,
+		        default_income_category_id = $12, default_expense_category_id = $13, updated_at = NOW()
+		 WHERE id = $14
+		 RETURNING updated_at`,
+		p.Name, p.Delimiter, p.HasHeader, p.SkipRows,
+		p.DateColumn, p.DescriptionColumn, p.AmountColumn, p.ExternalIDColumn,
+		p.DateFormat, p.DecimalSeparator, p.NegateAmounts,
+		p.DefaultIncomeCategoryID, p.DefaultExpenseCategoryID, p.ID,
+	).Scan(&p.UpdatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return domain.ErrNotFound
+		}
+		if isDuplicateKey(err) {
+			return domain.ErrDuplicateProfile
+		}
+		return err
+	}
+	return nil
+}
+
+func (r *ImportProfileRepo) Delete(ctx context.Context, id uuid.UUID) error {
+	conn, err := ConnFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	result, err := conn.Exec(ctx, `DELETE FROM import_profiles WHERE id = $1`, id)
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+func (r *ImportProfileRepo) FindByID(ctx context.Context, id uuid.UUID) (*entity.ImportProfile, error) {
+	conn, err := ConnFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var p entity.ImportProfile
+	if err := scanImportProfile(conn.QueryRow(ctx, importProfileSelect+` WHERE id = $1`, id), &p); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, err
+	}
+	return &p, nil
+}
+
+func (r *ImportProfileRepo) FindAll(ctx context.Context) ([]entity.ImportProfile, error) {
+	conn, err := ConnFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := conn.Query(ctx, importProfileSelect+` ORDER BY name ASC`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var profiles []entity.ImportProfile
+	for rows.Next() {
+		var p entity.ImportProfile
+		if err := scanImportProfile(rows, &p); err != nil {
+			return nil, err
+		}
+		profiles = append(profiles, p)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if profiles == nil {
+		profiles = []entity.ImportProfile{}
+	}
+	return profiles, nil
+}
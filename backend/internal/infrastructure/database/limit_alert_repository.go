@@ -0,0 +1,55 @@
+package database
+
+import (
+	"context"
+	"errors"
+
+	"github.com/dcunha/finance/backend/internal/domain/entity"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+type LimitAlertRepo struct{}
+
+func NewLimitAlertRepo() *LimitAlertRepo {
+	return &LimitAlertRepo{}
+}
+
+// Record relies on the unique indexes of limit_alerts, so two requests
+// crossing the same threshold at once still alert only once.
+func (r *LimitAlertRepo) Record(ctx context.Context, alert *entity.LimitAlert) (bool, error) {
+	conn, err := ConnFromContext(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	conflict := `ON CONFLICT (expense_limit_id, period_start, threshold) WHERE expense_limit_id IS NOT NULL`
+	if alert.BudgetID != nil {
+		conflict = `ON CONFLICT (budget_id, period_start, threshold) WHERE budget_id IS NOT NULL`
+	}
+
+	err = conn.QueryRow(ctx,
+		`INSERT INTO limit_alerts (expense_limit_id, budget_id, period_start, threshold)
+		 VALUES ($1, $2, $3, $4)
+		 `+conflict+` DO NOTHING
+		 RETURNING id, sent_at`,
+		alert.ExpenseLimitID, alert.BudgetID, alert.PeriodStart, alert.Threshold,
+	).Scan(&alert.ID, &alert.SentAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+func (r *LimitAlertRepo) Delete(ctx context.Context, id uuid.UUID) error {
+	conn, err := ConnFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	_, err = conn.Exec(ctx, `DELETE FROM limit_alerts WHERE id = $1`, id)
+	return err
+}
@@ -37,7 +37,7 @@ func (r *MembershipRepo) Create(ctx context.Context, m *entity.Membership) error
 
 func (r *MembershipRepo) FindByGlobalUser(ctx context.Context, globalUserID uuid.UUID) ([]entity.TenantMembership, error) {
 	rows, err := r.pool.Query(ctx,
-		`SELECT m.tenant_id, t.name, m.role
+		`SELECT m.tenant_id, t.name, m.role, t.require_two_factor
 		 FROM memberships m
 		 JOIN tenants t ON t.id = m.tenant_id
 		 WHERE m.global_user_id = $1 AND t.is_active = true
@@ -51,7 +51,7 @@ func (r *MembershipRepo) FindByGlobalUser(ctx context.Context, globalUserID uuid
 	var memberships []entity.TenantMembership
 	for rows.Next() {
 		var tm entity.TenantMembership
-		if err := rows.Scan(&tm.TenantID, &tm.TenantName, &tm.Role); err != nil {
+		if err := rows.Scan(&tm.TenantID, &tm.TenantName, &tm.Role, &tm.RequireTwoFactor); err != nil {
 			return nil, err
 		}
 		memberships = append(memberships, tm)
@@ -0,0 +1,58 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/dcunha/finance/backend/internal/domain"
+	"github.com/dcunha/finance/backend/internal/domain/entity"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type OIDCIdentityRepo struct {
+	pool *pgxpool.Pool
+}
+
+func NewOIDCIdentityRepo(pool *pgxpool.Pool) *OIDCIdentityRepo {
+	return &OIDCIdentityRepo{pool: pool}
+}
+
+func (r *OIDCIdentityRepo) Create(ctx context.Context, identity *entity.OIDCIdentity) error {
+	return r.pool.QueryRow(ctx,
+		`INSERT INTO oidc_identities (issuer, subject, global_user_id, email)
+		 VALUES ($1, $2, $3, $4)
+		 RETURNING created_at`,
+		identity.Issuer, identity.Subject, identity.GlobalUserID, identity.Email,
+	).Scan(&identity.CreatedAt)
+}
+
+func (r *OIDCIdentityRepo) Find(ctx context.Context, issuer, subject string) (*entity.OIDCIdentity, error) {
+	var i entity.OIDCIdentity
+	err := r.pool.QueryRow(ctx,
+		`SELECT issuer, subject, global_user_id, email, created_at
+		 FROM oidc_identities WHERE issuer = $1 AND subject = $2`, issuer, subject,
+	).Scan(&i.Issuer, &i.Subject, &i.GlobalUserID, &i.Email, &i.CreatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, err
+	}
+	return &i, nil
+}
+
+func (r *OIDCIdentityRepo) RecordTokenUse(ctx context.Context, jti uuid.UUID, expiresAt time.Time) (bool, error) {
+	if _, err := r.pool.Exec(ctx, `DELETE FROM oidc_used_tokens WHERE expires_at < NOW()`); err != nil {
+		return false, err
+	}
+	result, err := r.pool.Exec(ctx,
+		`INSERT INTO oidc_used_tokens (jti, expires_at) VALUES ($1, $2) ON CONFLICT (jti) DO NOTHING`,
+		jti, expiresAt)
+	if err != nil {
+		return false, err
+	}
+	return result.RowsAffected() > 0, nil
+}
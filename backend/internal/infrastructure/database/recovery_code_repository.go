@@ -0,0 +1,55 @@
+package database
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type RecoveryCodeRepo struct {
+	pool *pgxpool.Pool
+}
+
+func NewRecoveryCodeRepo(pool *pgxpool.Pool) *RecoveryCodeRepo {
+	return &RecoveryCodeRepo{pool: pool}
+}
+
+func (r *RecoveryCodeRepo) Replace(ctx context.Context, globalUserID uuid.UUID, codeHashes []string) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, `DELETE FROM recovery_codes WHERE global_user_id = $1`, globalUserID); err != nil {
+		return err
+	}
+	if len(codeHashes) > 0 {
+		if _, err := tx.Exec(ctx,
+			`INSERT INTO recovery_codes (global_user_id, code_hash)
+			 SELECT $1, unnest($2::text[])`, globalUserID, codeHashes); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit(ctx)
+}
+
+func (r *RecoveryCodeRepo) Use(ctx context.Context, globalUserID uuid.UUID, codeHash string) (bool, error) {
+	result, err := r.pool.Exec(ctx,
+		`UPDATE recovery_codes SET used_at = NOW()
+		 WHERE global_user_id = $1 AND code_hash = $2 AND used_at IS NULL`, globalUserID, codeHash)
+	if err != nil {
+		return false, err
+	}
+	return result.RowsAffected() > 0, nil
+}
+
+func (r *RecoveryCodeRepo) CountUnused(ctx context.Context, globalUserID uuid.UUID) (int, error) {
+	var count int
+	err := r.pool.QueryRow(ctx,
+		`SELECT COUNT(*) FROM recovery_codes WHERE global_user_id = $1 AND used_at IS NULL`, globalUserID,
+	).Scan(&count)
+	return count, err
+}
@@ -19,23 +19,73 @@ func NewRecurringTransactionRepo() *RecurringTransactionRepo {
 	return &RecurringTransactionRepo{}
 }
 
+// Create inserts the template together with its tags.
 func (r *RecurringTransactionRepo) Create(ctx context.Context, rt *entity.RecurringTransaction) error {
 	conn, err := ConnFromContext(ctx)
 	if err != nil {
 		return err
 	}
 
-	err = conn.QueryRow(ctx,
-		`INSERT INTO recurring_transactions (user_id, category_id, type, amount, description, frequency, start_date, end_date, max_occurrences, day_of_month, is_active)
-		 VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+	dbTx, err := conn.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer dbTx.Rollback(ctx)
+
+	err = dbTx.QueryRow(ctx,
+		`INSERT INTO recurring_transactions (user_id, category_id, account_id, type, amount, description, frequency, start_date, end_date, max_occurrences, day_of_month, is_active)
+		 VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
 		 RETURNING id, created_at, updated_at`,
-		rt.UserID, rt.CategoryID, rt.Type, rt.Amount, rt.Description, rt.Frequency,
+		rt.UserID, rt.CategoryID, rt.AccountID, rt.Type, rt.Amount, rt.Description, rt.Frequency,
 		rt.StartDate, rt.EndDate, rt.MaxOccurrences, rt.DayOfMonth, rt.IsActive,
 	).Scan(&rt.ID, &rt.CreatedAt, &rt.UpdatedAt)
 	if err != nil {
 		return err
 	}
-	return nil
+	if len(rt.TagIDs) > 0 {
+		if err := replaceTags(ctx, dbTx, "recurring_transaction_tags", "recurring_id", rt.ID, rt.TagIDs); err != nil {
+			return err
+		}
+	}
+
+	return dbTx.Commit(ctx)
+}
+
+// Update saves the template. Its tags are replaced only when TagIDs is not nil.
+func (r *RecurringTransactionRepo) Update(ctx context.Context, rt *entity.RecurringTransaction) error {
+	conn, err := ConnFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	dbTx, err := conn.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer dbTx.Rollback(ctx)
+
+	err = dbTx.QueryRow(ctx,
+		`UPDATE recurring_transactions
+		 SET category_id = $1, account_id = $2, amount = $3, description = $4, frequency = $5,
+		     end_date = $6, max_occurrences = $7, day_of_month = $8, updated_at = NOW()
+		 WHERE id = $9
+		 RETURNING updated_at`,
+		rt.CategoryID, rt.AccountID, rt.Amount, rt.Description, rt.Frequency,
+		rt.EndDate, rt.MaxOccurrences, rt.DayOfMonth, rt.ID,
+	).Scan(&rt.UpdatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return domain.ErrNotFound
+		}
+		return err
+	}
+	if rt.TagIDs != nil {
+		if err := replaceTags(ctx, dbTx, "recurring_transaction_tags", "recurring_id", rt.ID, rt.TagIDs); err != nil {
+			return err
+		}
+	}
+
+	return dbTx.Commit(ctx)
 }
 
 func (r *RecurringTransactionRepo) Delete(ctx context.Context, id uuid.UUID) error {
@@ -61,24 +111,27 @@ func (r *RecurringTransactionRepo) FindByID(ctx context.Context, id uuid.UUID) (
 	}
 
 	var rt entity.RecurringTransaction
+	var tagIDs []string
 	err = conn.QueryRow(ctx,
-		`SELECT rt.id, rt.user_id, rt.category_id, c.name AS category_name,
+		`SELECT rt.id, rt.user_id, rt.category_id, c.name AS category_name, rt.account_id, a.name AS account_name, a.currency,
 		        rt.type, rt.amount, rt.description, rt.frequency,
 		        rt.start_date::text, rt.end_date::text, rt.max_occurrences, rt.day_of_month,
-		        rt.is_active, rt.paused_at, rt.created_at, rt.updated_at
+		        rt.is_active, rt.paused_at, rt.materialized_until::text, `+recurringTagsColumn+`, rt.created_at, rt.updated_at
 		 FROM recurring_transactions rt
 		 JOIN categories c ON rt.category_id = c.id
+		 JOIN accounts a ON rt.account_id = a.id
 		 WHERE rt.id = $1`, id,
-	).Scan(&rt.ID, &rt.UserID, &rt.CategoryID, &rt.CategoryName,
+	).Scan(&rt.ID, &rt.UserID, &rt.CategoryID, &rt.CategoryName, &rt.AccountID, &rt.AccountName, &rt.Currency,
 		&rt.Type, &rt.Amount, &rt.Description, &rt.Frequency,
 		&rt.StartDate, &rt.EndDate, &rt.MaxOccurrences, &rt.DayOfMonth,
-		&rt.IsActive, &rt.PausedAt, &rt.CreatedAt, &rt.UpdatedAt)
+		&rt.IsActive, &rt.PausedAt, &rt.MaterializedUntil, &tagIDs, &rt.CreatedAt, &rt.UpdatedAt)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, domain.ErrNotFound
 		}
 		return nil, err
 	}
+	rt.TagIDs = parseUUIDs(tagIDs)
 	return &rt, nil
 }
 
@@ -121,12 +174,13 @@ func (r *RecurringTransactionRepo) FindAll(ctx context.Context, userID uuid.UUID
 
 	offset := (filter.Page - 1) * filter.PerPage
 	dataQuery := fmt.Sprintf(
-		`SELECT rt.id, rt.user_id, rt.category_id, c.name AS category_name,
+		`SELECT rt.id, rt.user_id, rt.category_id, c.name AS category_name, rt.account_id, a.name AS account_name, a.currency,
 		        rt.type, rt.amount, rt.description, rt.frequency,
 		        rt.start_date::text, rt.end_date::text, rt.max_occurrences, rt.day_of_month,
-		        rt.is_active, rt.paused_at, rt.created_at, rt.updated_at
+		        rt.is_active, rt.paused_at, rt.materialized_until::text, `+recurringTagsColumn+`, rt.created_at, rt.updated_at
 		 FROM recurring_transactions rt
 		 JOIN categories c ON rt.category_id = c.id
+		 JOIN accounts a ON rt.account_id = a.id
 		 %s
 		 ORDER BY rt.created_at DESC
 		 LIMIT $%d OFFSET $%d`,
@@ -143,12 +197,14 @@ func (r *RecurringTransactionRepo) FindAll(ctx context.Context, userID uuid.UUID
 	var items []entity.RecurringTransaction
 	for rows.Next() {
 		var rt entity.RecurringTransaction
-		if err := rows.Scan(&rt.ID, &rt.UserID, &rt.CategoryID, &rt.CategoryName,
+		var tagIDs []string
+		if err := rows.Scan(&rt.ID, &rt.UserID, &rt.CategoryID, &rt.CategoryName, &rt.AccountID, &rt.AccountName, &rt.Currency,
 			&rt.Type, &rt.Amount, &rt.Description, &rt.Frequency,
 			&rt.StartDate, &rt.EndDate, &rt.MaxOccurrences, &rt.DayOfMonth,
-			&rt.IsActive, &rt.PausedAt, &rt.CreatedAt, &rt.UpdatedAt); err != nil {
+			&rt.IsActive, &rt.PausedAt, &rt.MaterializedUntil, &tagIDs, &rt.CreatedAt, &rt.UpdatedAt); err != nil {
 			return nil, err
 		}
+		rt.TagIDs = parseUUIDs(tagIDs)
 		items = append(items, rt)
 	}
 
@@ -203,3 +259,60 @@ func (r *RecurringTransactionRepo) Resume(ctx context.Context, id uuid.UUID) err
 	}
 	return nil
 }
+
+func (r *RecurringTransactionRepo) FindActive(ctx context.Context) ([]entity.RecurringTransaction, error) {
+	conn, err := ConnFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := conn.Query(ctx,
+		`SELECT rt.id, rt.user_id, rt.category_id, c.name AS category_name, rt.account_id, a.name AS account_name, a.currency,
+		        rt.type, rt.amount, rt.description, rt.frequency,
+		        rt.start_date::text, rt.end_date::text, rt.max_occurrences, rt.day_of_month,
+		        rt.is_active, rt.paused_at, rt.materialized_until::text, `+recurringTagsColumn+`, rt.created_at, rt.updated_at
+		 FROM recurring_transactions rt
+		 JOIN categories c ON rt.category_id = c.id
+		 JOIN accounts a ON rt.account_id = a.id
+		 WHERE rt.is_active = true
+		 ORDER BY rt.created_at ASC`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var items []entity.RecurringTransaction
+	for rows.Next() {
+		var rt entity.RecurringTransaction
+		var tagIDs []string
+		if err := rows.Scan(&rt.ID, &rt.UserID, &rt.CategoryID, &rt.CategoryName, &rt.AccountID, &rt.AccountName, &rt.Currency,
+			&rt.Type, &rt.Amount, &rt.Description, &rt.Frequency,
+			&rt.StartDate, &rt.EndDate, &rt.MaxOccurrences, &rt.DayOfMonth,
+			&rt.IsActive, &rt.PausedAt, &rt.MaterializedUntil, &tagIDs, &rt.CreatedAt, &rt.UpdatedAt); err != nil {
+			return nil, err
+		}
+		rt.TagIDs = parseUUIDs(tagIDs)
+		items = append(items, rt)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+func (r *RecurringTransactionRepo) UpdateMaterializedUntil(ctx context.Context, id uuid.UUID, date string) error {
+	conn, err := ConnFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	result, err := conn.Exec(ctx,
+		`UPDATE recurring_transactions SET materialized_until = $1, updated_at = NOW() WHERE id = $2`, date, id)
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
@@ -0,0 +1,150 @@
+package database
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/dcunha/finance/backend/internal/domain/entity"
+	"github.com/google/uuid"
+)
+
+// ReportRepo filters on date ranges rather than on the month and year parts
+// of the date, so the queries can use the (user_id, date) index.
+type ReportRepo struct{}
+
+func NewReportRepo() *ReportRepo {
+	return &ReportRepo{}
+}
+
+func (r *ReportRepo) GetMonthlyTotals(ctx context.Context, startMonth, endMonth string, userID *uuid.UUID) ([]entity.MonthlyTotals, error) {
+	conn, err := ConnFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	userFilter := ""
+	args := []any{startMonth, endMonth}
+	if userID != nil {
+		userFilter = fmt.Sprintf(" AND t.user_id = $%d", len(args)+1)
+		args = append(args, *userID)
+	}
+
+	query := fmt.Sprintf(
+		`SELECT EXTRACT(MONTH FROM m.month)::int, EXTRACT(YEAR FROM m.month)::int,
+		        COALESCE(SUM(base_amount(t.amount, t.currency, t.date)) FILTER (WHERE t.type = 'income'), 0),
+		        COALESCE(SUM(base_amount(t.amount, t.currency, t.date)) FILTER (WHERE t.type = 'expense'), 0)
+		 FROM generate_series($1::date, $2::date, INTERVAL '1 month') AS m(month)
+		 LEFT JOIN transactions t
+		   ON t.date >= m.month::date
+		  AND t.date < (m.month + INTERVAL '1 month')::date
+		  AND t.type IN ('income', 'expense')%s
+		 GROUP BY m.month
+		 ORDER BY m.month`, userFilter)
+
+	rows, err := conn.Query(ctx, query, args...)
+	if err != nil {
+		return nil, rateError(err)
+	}
+	defer rows.Close()
+
+	var totals []entity.MonthlyTotals
+	for rows.Next() {
+		var mt entity.MonthlyTotals
+		if err := rows.Scan(&mt.Month, &mt.Year, &mt.Income, &mt.Expenses); err != nil {
+			return nil, err
+		}
+		totals = append(totals, mt)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, rateError(err)
+	}
+
+	if totals == nil {
+		totals = []entity.MonthlyTotals{}
+	}
+
+	return totals, nil
+}
+
+func (r *ReportRepo) GetBalanceBefore(ctx context.Context, date string, userID *uuid.UUID) (entity.Money, error) {
+	conn, err := ConnFromContext(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	userFilter := ""
+	// Opening balances belong to the tenant's accounts, not to a single member.
+	openingBalance := "(SELECT COALESCE(SUM(base_amount(initial_balance, currency, $1::date)), 0) FROM accounts)"
+	args := []any{date}
+	if userID != nil {
+		userFilter = " AND user_id = $2"
+		openingBalance = "0"
+		args = append(args, *userID)
+	}
+
+	query := fmt.Sprintf(
+		`SELECT %s + COALESCE(SUM(CASE WHEN type = 'income' OR transfer_direction = 'in' THEN 1 ELSE -1 END
+		                          * base_amount(amount, currency, date)), 0)
+		 FROM transactions
+		 WHERE date < $1::date%s`, openingBalance, userFilter)
+
+	var balance entity.Money
+	if err := conn.QueryRow(ctx, query, args...).Scan(&balance); err != nil {
+		return 0, rateError(err)
+	}
+	return balance, nil
+}
+
+func (r *ReportRepo) GetCategoryMonthlyTotals(ctx context.Context, startMonth, endMonth, txType string, userID *uuid.UUID) ([]entity.CategoryMonthTotal, error) {
+	conn, err := ConnFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	userFilter := ""
+	args := []any{startMonth, endMonth, txType}
+	if userID != nil {
+		userFilter = fmt.Sprintf(" AND t.user_id = $%d", len(args)+1)
+		args = append(args, *userID)
+	}
+
+	query := fmt.Sprintf(
+		`SELECT t.category_id, c.name,
+		        EXTRACT(MONTH FROM date_trunc('month', t.date))::int AS month,
+		        EXTRACT(YEAR FROM date_trunc('month', t.date))::int AS year,
+		        SUM(base_amount(t.amount, t.currency, t.date)) AS total
+		 FROM transaction_lines t
+		 JOIN categories c ON t.category_id = c.id
+		 WHERE t.date >= $1::date
+		   AND t.date < ($2::date + INTERVAL '1 month')::date
+		   AND t.type = $3
+		   %s
+		 GROUP BY t.category_id, c.name, date_trunc('month', t.date)
+		 ORDER BY date_trunc('month', t.date), c.name`, userFilter)
+
+	rows, err := conn.Query(ctx, query, args...)
+	if err != nil {
+		return nil, rateError(err)
+	}
+	defer rows.Close()
+
+	var totals []entity.CategoryMonthTotal
+	for rows.Next() {
+		var ct entity.CategoryMonthTotal
+		if err := rows.Scan(&ct.CategoryID, &ct.CategoryName, &ct.Month, &ct.Year, &ct.Total); err != nil {
+			return nil, err
+		}
+		totals = append(totals, ct)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, rateError(err)
+	}
+
+	if totals == nil {
+		totals = []entity.CategoryMonthTotal{}
+	}
+
+	return totals, nil
+}
@@ -0,0 +1,99 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/dcunha/finance/backend/internal/domain"
+	"github.com/dcunha/finance/backend/internal/domain/entity"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type SessionRepo struct {
+	pool *pgxpool.Pool
+}
+
+func NewSessionRepo(pool *pgxpool.Pool) *SessionRepo {
+	return &SessionRepo{pool: pool}
+}
+
+const sessionSelect = `SELECT id, global_user_id, tenant_id, schema_user_id, refresh_token_hash, previous_token_hash,
+		        expires_at, revoked_at, created_at, last_used_at
+		 FROM sessions`
+
+func scanSession(row pgx.Row) (*entity.Session, error) {
+	var s entity.Session
+	err := row.Scan(&s.ID, &s.GlobalUserID, &s.TenantID, &s.SchemaUserID, &s.RefreshTokenHash, &s.PreviousTokenHash,
+		&s.ExpiresAt, &s.RevokedAt, &s.CreatedAt, &s.LastUsedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, err
+	}
+	return &s, nil
+}
+
+func (r *SessionRepo) Create(ctx context.Context, s *entity.Session) error {
+	return r.pool.QueryRow(ctx,
+		`INSERT INTO sessions (global_user_id, tenant_id, schema_user_id, refresh_token_hash, expires_at)
+		 VALUES ($1, $2, $3, $4, $5)
+		 RETURNING id, created_at, last_used_at`,
+		s.GlobalUserID, s.TenantID, s.SchemaUserID, s.RefreshTokenHash, s.ExpiresAt,
+	).Scan(&s.ID, &s.CreatedAt, &s.LastUsedAt)
+}
+
+func (r *SessionRepo) FindByID(ctx context.Context, id uuid.UUID) (*entity.Session, error) {
+	return scanSession(r.pool.QueryRow(ctx, sessionSelect+` WHERE id = $1`, id))
+}
+
+func (r *SessionRepo) FindByTokenHash(ctx context.Context, hash string) (*entity.Session, error) {
+	return scanSession(r.pool.QueryRow(ctx,
+		sessionSelect+` WHERE refresh_token_hash = $1 OR previous_token_hash = $1 LIMIT 1`, hash))
+}
+
+func (r *SessionRepo) Rotate(ctx context.Context, id uuid.UUID, currentHash, newHash string, expiresAt time.Time) error {
+	result, err := r.pool.Exec(ctx,
+		`UPDATE sessions SET previous_token_hash = refresh_token_hash, refresh_token_hash = $3,
+		 expires_at = $4, last_used_at = NOW()
+		 WHERE id = $1 AND refresh_token_hash = $2 AND revoked_at IS NULL`,
+		id, currentHash, newHash, expiresAt)
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+func (r *SessionRepo) Revoke(ctx context.Context, id uuid.UUID) error {
+	_, err := r.pool.Exec(ctx,
+		`UPDATE sessions SET revoked_at = NOW() WHERE id = $1 AND revoked_at IS NULL`, id)
+	return err
+}
+
+func (r *SessionRepo) RevokeAllForUser(ctx context.Context, globalUserID, keepID uuid.UUID) error {
+	_, err := r.pool.Exec(ctx,
+		`UPDATE sessions SET revoked_at = NOW()
+		 WHERE global_user_id = $1 AND id <> $2 AND revoked_at IS NULL`, globalUserID, keepID)
+	return err
+}
+
+func (r *SessionRepo) RevokeAllForMember(ctx context.Context, tenantID, schemaUserID uuid.UUID) error {
+	_, err := r.pool.Exec(ctx,
+		`UPDATE sessions SET revoked_at = NOW()
+		 WHERE tenant_id = $1 AND schema_user_id = $2 AND revoked_at IS NULL`, tenantID, schemaUserID)
+	return err
+}
+
+func (r *SessionRepo) RevokeWithoutTwoFactor(ctx context.Context, tenantID uuid.UUID) error {
+	_, err := r.pool.Exec(ctx,
+		`UPDATE sessions s SET revoked_at = NOW()
+		 FROM global_users g
+		 WHERE g.id = s.global_user_id AND s.tenant_id = $1 AND NOT g.totp_enabled AND s.revoked_at IS NULL`, tenantID)
+	return err
+}
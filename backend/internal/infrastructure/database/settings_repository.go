@@ -0,0 +1,40 @@
+package database
+
+import (
+	"context"
+
+	"github.com/dcunha/finance/backend/internal/domain/entity"
+)
+
+// SettingsRepo reads and writes the single settings row of the tenant.
+type SettingsRepo struct{}
+
+func NewSettingsRepo() *SettingsRepo {
+	return &SettingsRepo{}
+}
+
+func (r *SettingsRepo) Get(ctx context.Context) (*entity.Settings, error) {
+	conn, err := ConnFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var s entity.Settings
+	err = conn.QueryRow(ctx, `SELECT base_currency, updated_at FROM settings`).Scan(&s.BaseCurrency, &s.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
+
+func (r *SettingsRepo) Update(ctx context.Context, s *entity.Settings) error {
+	conn, err := ConnFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	return conn.QueryRow(ctx,
+		`UPDATE settings SET base_currency = $1, updated_at = NOW() RETURNING updated_at`,
+		s.BaseCurrency,
+	).Scan(&s.UpdatedAt)
+}
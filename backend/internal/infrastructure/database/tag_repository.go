@@ -0,0 +1,161 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/dcunha/finance/backend/internal/domain"
+	"github.com/dcunha/finance/backend/internal/domain/entity"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+type TagRepo struct{}
+
+func NewTagRepo() *TagRepo {
+	return &TagRepo{}
+}
+
+func (r *TagRepo) Create(ctx context.Context, tag *entity.Tag) error {
+	conn, err := ConnFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	err = conn.QueryRow(ctx,
+		`INSERT INTO tags (user_id, name) VALUES ($1, $2)
+		 RETURNING id, created_at, updated_at`,
+		tag.UserID, tag.Name,
+	).Scan(&tag.ID, &tag.CreatedAt, &tag.UpdatedAt)
+	if err != nil {
+		if isDuplicateKey(err) {
+			return domain.ErrDuplicateTag
+		}
+		return err
+	}
+	return nil
+}
+
+func (r *TagRepo) Update(ctx context.Context, tag *entity.Tag) error {
+	conn, err := ConnFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	err = conn.QueryRow(ctx,
+		`UPDATE tags SET name = $1, updated_at = NOW()
+		 WHERE id = $2
+		 RETURNING updated_at`,
+		tag.Name, tag.ID,
+	).Scan(&tag.UpdatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return domain.ErrNotFound
+		}
+		if isDuplicateKey(err) {
+			return domain.ErrDuplicateTag
+		}
+		return err
+	}
+	return nil
+}
+
+// Delete removes a tag and detaches it from every transaction and template.
+func (r *TagRepo) Delete(ctx context.Context, id uuid.UUID) error {
+	conn, err := ConnFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	result, err := conn.Exec(ctx, `DELETE FROM tags WHERE id = $1`, id)
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+func (r *TagRepo) FindByID(ctx context.Context, id uuid.UUID) (*entity.Tag, error) {
+	conn, err := ConnFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var t entity.Tag
+	err = conn.QueryRow(ctx,
+		`SELECT id, user_id, name, created_at, updated_at FROM tags WHERE id = $1`, id,
+	).Scan(&t.ID, &t.UserID, &t.Name, &t.CreatedAt, &t.UpdatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, err
+	}
+	return &t, nil
+}
+
+func (r *TagRepo) FindAll(ctx context.Context) ([]entity.Tag, error) {
+	conn, err := ConnFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := conn.Query(ctx, `SELECT id, user_id, name, created_at, updated_at FROM tags ORDER BY name`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var tags []entity.Tag
+	for rows.Next() {
+		var t entity.Tag
+		if err := rows.Scan(&t.ID, &t.UserID, &t.Name, &t.CreatedAt, &t.UpdatedAt); err != nil {
+			return nil, err
+		}
+		tags = append(tags, t)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if tags == nil {
+		tags = []entity.Tag{}
+	}
+	return tags, nil
+}
+
+// transactionTagsColumn selects the tag ids of the transaction aliased "t":
+// its own tags plus those of the recurring template it came from.
+const transactionTagsColumn = `ARRAY(
+		            SELECT tag_id::text FROM transaction_tags WHERE transaction_id = t.id
+		            UNION
+		            SELECT tag_id::text FROM recurring_transaction_tags WHERE recurring_id = t.recurring_id
+		        )`
+
+// recurringTagsColumn selects the tag ids of the template aliased "rt".
+const recurringTagsColumn = `ARRAY(SELECT tag_id::text FROM recurring_transaction_tags WHERE recurring_id = rt.id)`
+
+// replaceTags sets the tags linked to ownerID in a join table, removing the
+// ones not listed. An unknown tag id fails with ErrUnknownTag.
+func replaceTags(ctx context.Context, dbTx pgx.Tx, table, ownerColumn string, ownerID uuid.UUID, tagIDs []uuid.UUID) error {
+	if _, err := dbTx.Exec(ctx, fmt.Sprintf(`DELETE FROM %s WHERE %s = $1`, table, ownerColumn), ownerID); err != nil {
+		return err
+	}
+	if len(tagIDs) == 0 {
+		return nil
+	}
+	_, err := dbTx.Exec(ctx, fmt.Sprintf(
+		`INSERT INTO %s (%s, tag_id)
+		 SELECT $1, unnest($2::uuid[])
+		 ON CONFLICT DO NOTHING`, table, ownerColumn),
+		ownerID, uuidStrings(tagIDs))
+	if err != nil {
+		if isForeignKeyViolation(err) {
+			return domain.ErrUnknownTag
+		}
+		return err
+	}
+	return nil
+}
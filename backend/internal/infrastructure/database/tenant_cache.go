@@ -22,7 +22,7 @@ func NewTenantCache() *TenantCache {
 
 func (tc *TenantCache) Load(ctx context.Context, pool *pgxpool.Pool) error {
 	rows, err := pool.Query(ctx,
-		`SELECT id, name, domain, schema_name, is_active, owner_id, created_at, updated_at FROM tenants WHERE is_active = true`,
+		`SELECT id, name, domain, schema_name, is_active, owner_id, require_two_factor, created_at, updated_at FROM tenants WHERE is_active = true`,
 	)
 	if err != nil {
 		return err
@@ -33,7 +33,7 @@ func (tc *TenantCache) Load(ctx context.Context, pool *pgxpool.Pool) error {
 
 	for rows.Next() {
 		var t entity.Tenant
-		if err := rows.Scan(&t.ID, &t.Name, &t.Domain, &t.SchemaName, &t.IsActive, &t.OwnerID, &t.CreatedAt, &t.UpdatedAt); err != nil {
+		if err := rows.Scan(&t.ID, &t.Name, &t.Domain, &t.SchemaName, &t.IsActive, &t.OwnerID, &t.RequireTwoFactor, &t.CreatedAt, &t.UpdatedAt); err != nil {
 			return err
 		}
 		byID[t.ID] = &t
@@ -59,3 +59,14 @@ func (tc *TenantCache) Add(t *entity.Tenant) {
 	defer tc.mu.Unlock()
 	tc.byID[t.ID] = t
 }
+
+// All returns a snapshot of the cached tenants.
+func (tc *TenantCache) All() []*entity.Tenant {
+	tc.mu.RLock()
+	defer tc.mu.RUnlock()
+	tenants := make([]*entity.Tenant, 0, len(tc.byID))
+	for _, t := range tc.byID {
+		tenants = append(tenants, t)
+	}
+	return tenants
+}
@@ -37,10 +37,11 @@ func (r *TenantRepo) Create(ctx context.Context, tenant *entity.Tenant) error {
 
 func (r *TenantRepo) Update(ctx context.Context, tenant *entity.Tenant) error {
 	err := r.pool.QueryRow(ctx,
-		`UPDATE tenants SET name = $1, domain = $2, schema_name = $3, is_active = $4, owner_id = $5, updated_at = NOW()
-		 WHERE id = $6
+		`UPDATE tenants SET name = $1, domain = $2, schema_name = $3, is_active = $4, owner_id = $5,
+		 require_two_factor = $6, updated_at = NOW()
+		 WHERE id = $7
 		 RETURNING updated_at`,
-		tenant.Name, tenant.Domain, tenant.SchemaName, tenant.IsActive, tenant.OwnerID, tenant.ID,
+		tenant.Name, tenant.Domain, tenant.SchemaName, tenant.IsActive, tenant.OwnerID, tenant.RequireTwoFactor, tenant.ID,
 	).Scan(&tenant.UpdatedAt)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
@@ -86,8 +87,8 @@ func (r *TenantRepo) Delete(ctx context.Context, id uuid.UUID) error {
 func (r *TenantRepo) FindByID(ctx context.Context, id uuid.UUID) (*entity.Tenant, error) {
 	var t entity.Tenant
 	err := r.pool.QueryRow(ctx,
-		`SELECT id, name, domain, schema_name, is_active, owner_id, created_at, updated_at FROM tenants WHERE id = $1`, id,
-	).Scan(&t.ID, &t.Name, &t.Domain, &t.SchemaName, &t.IsActive, &t.OwnerID, &t.CreatedAt, &t.UpdatedAt)
+		`SELECT id, name, domain, schema_name, is_active, owner_id, require_two_factor, created_at, updated_at FROM tenants WHERE id = $1`, id,
+	).Scan(&t.ID, &t.Name, &t.Domain, &t.SchemaName, &t.IsActive, &t.OwnerID, &t.RequireTwoFactor, &t.CreatedAt, &t.UpdatedAt)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, domain.ErrNotFound
@@ -100,8 +101,8 @@ func (r *TenantRepo) FindByID(ctx context.Context, id uuid.UUID) (*entity.Tenant
 func (r *TenantRepo) FindBySchemaName(ctx context.Context, schemaName string) (*entity.Tenant, error) {
 	var t entity.Tenant
 	err := r.pool.QueryRow(ctx,
-		`SELECT id, name, domain, schema_name, is_active, owner_id, created_at, updated_at FROM tenants WHERE schema_name = $1`, schemaName,
-	).Scan(&t.ID, &t.Name, &t.Domain, &t.SchemaName, &t.IsActive, &t.OwnerID, &t.CreatedAt, &t.UpdatedAt)
+		`SELECT id, name, domain, schema_name, is_active, owner_id, require_two_factor, created_at, updated_at FROM tenants WHERE schema_name = $1`, schemaName,
+	).Scan(&t.ID, &t.Name, &t.Domain, &t.SchemaName, &t.IsActive, &t.OwnerID, &t.RequireTwoFactor, &t.CreatedAt, &t.UpdatedAt)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, domain.ErrTenantNotFound
@@ -113,7 +114,7 @@ func (r *TenantRepo) FindBySchemaName(ctx context.Context, schemaName string) (*
 
 func (r *TenantRepo) FindAll(ctx context.Context) ([]entity.Tenant, error) {
 	rows, err := r.pool.Query(ctx,
-		`SELECT id, name, domain, schema_name, is_active, owner_id, created_at, updated_at FROM tenants ORDER BY created_at ASC`,
+		`SELECT id, name, domain, schema_name, is_active, owner_id, require_two_factor, created_at, updated_at FROM tenants ORDER BY created_at ASC`,
 	)
 	if err != nil {
 		return nil, err
@@ -123,7 +124,7 @@ func (r *TenantRepo) FindAll(ctx context.Context) ([]entity.Tenant, error) {
 	var tenants []entity.Tenant
 	for rows.Next() {
 		var t entity.Tenant
-		if err := rows.Scan(&t.ID, &t.Name, &t.Domain, &t.SchemaName, &t.IsActive, &t.OwnerID, &t.CreatedAt, &t.UpdatedAt); err != nil {
+		if err := rows.Scan(&t.ID, &t.Name, &t.Domain, &t.SchemaName, &t.IsActive, &t.OwnerID, &t.RequireTwoFactor, &t.CreatedAt, &t.UpdatedAt); err != nil {
 			return nil, err
 		}
 		tenants = append(tenants, t)
@@ -11,6 +11,7 @@ import (
 	"github.com/dcunha/finance/backend/internal/domain/entity"
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
 )
 
 type TransactionRepo struct{}
@@ -19,48 +20,80 @@ func NewTransactionRepo() *TransactionRepo {
 	return &TransactionRepo{}
 }
 
+// currencyOrAccount is the insert value of the currency column: the given
+// currency ($6), or the currency of the account ($3) when it is empty.
+const currencyOrAccount = `COALESCE(NULLIF($6, ''), (SELECT currency FROM accounts WHERE id = $3))`
+
+// Create inserts the transaction together with its tags and split lines.
 func (r *TransactionRepo) Create(ctx context.Context, tx *entity.Transaction) error {
 	conn, err := ConnFromContext(ctx)
 	if err != nil {
 		return err
 	}
 
-	err = conn.QueryRow(ctx,
-		`INSERT INTO transactions (user_id, category_id, type, amount, description, date, recurring_id)
-		 VALUES ($1, $2, $3, $4, $5, $6, $7)
-		 RETURNING id, created_at, updated_at`,
-		tx.UserID, tx.CategoryID, tx.Type, tx.Amount, tx.Description, tx.Date, tx.RecurringID,
-	).Scan(&tx.ID, &tx.CreatedAt, &tx.UpdatedAt)
+	dbTx, err := conn.Begin(ctx)
 	if err != nil {
 		return err
 	}
-	return nil
+	defer dbTx.Rollback(ctx)
+
+	err = dbTx.QueryRow(ctx,
+		`INSERT INTO transactions (user_id, category_id, account_id, type, amount, currency, description, date, recurring_id, statement_id, external_id)
+		 VALUES ($1, $2, $3, $4, $5, `+currencyOrAccount+`, $7, $8, $9, $10, $11)
+		 RETURNING id, currency, created_at, updated_at`,
+		tx.UserID, tx.CategoryID, tx.AccountID, tx.Type, tx.Amount, tx.Currency, tx.Description, tx.Date, tx.RecurringID, tx.StatementID, tx.ExternalID,
+	).Scan(&tx.ID, &tx.Currency, &tx.CreatedAt, &tx.UpdatedAt)
+	if err != nil {
+		return err
+	}
+	if len(tx.TagIDs) > 0 {
+		if err := replaceTags(ctx, dbTx, "transaction_tags", "transaction_id", tx.ID, tx.TagIDs); err != nil {
+			return err
+		}
+	}
+	if len(tx.Splits) > 0 {
+		if err := replaceSplits(ctx, dbTx, tx.ID, tx.Splits); err != nil {
+			return err
+		}
+	}
+
+	return dbTx.Commit(ctx)
 }
 
+// BulkCreate inserts all transactions in a single database transaction.
 func (r *TransactionRepo) BulkCreate(ctx context.Context, txs []entity.Transaction) error {
 	conn, err := ConnFromContext(ctx)
 	if err != nil {
 		return err
 	}
 
+	dbTx, err := conn.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer dbTx.Rollback(ctx)
+
 	batch := &pgx.Batch{}
 	for i := range txs {
 		batch.Queue(
-			`INSERT INTO transactions (user_id, category_id, type, amount, description, date, recurring_id)
-			 VALUES ($1, $2, $3, $4, $5, $6, $7)`,
-			txs[i].UserID, txs[i].CategoryID, txs[i].Type, txs[i].Amount, txs[i].Description, txs[i].Date, txs[i].RecurringID,
+			`INSERT INTO transactions (user_id, category_id, account_id, type, amount, currency, description, date, recurring_id, statement_id, external_id)
+			 VALUES ($1, $2, $3, $4, $5, `+currencyOrAccount+`, $7, $8, $9, $10, $11)`,
+			txs[i].UserID, txs[i].CategoryID, txs[i].AccountID, txs[i].Type, txs[i].Amount, txs[i].Currency, txs[i].Description, txs[i].Date, txs[i].RecurringID, txs[i].StatementID, txs[i].ExternalID,
 		)
 	}
 
-	br := conn.SendBatch(ctx, batch)
-	defer br.Close()
-
+	br := dbTx.SendBatch(ctx, batch)
 	for range txs {
 		if _, err := br.Exec(); err != nil {
+			br.Close()
 			return err
 		}
 	}
-	return nil
+	if err := br.Close(); err != nil {
+		return err
+	}
+
+	return dbTx.Commit(ctx)
 }
 
 func (r *TransactionRepo) DeleteByRecurringID(ctx context.Context, recurringID uuid.UUID, mode entity.DeleteMode) error {
@@ -132,26 +165,47 @@ func (r *TransactionRepo) CountByRecurringIDBeforeDate(ctx context.Context, recu
 	return count, nil
 }
 
+// Update saves the transaction. Its own tags and its split lines are replaced
+// only when TagIDs and Splits, respectively, are not nil; an empty Currency
+// keeps the stored one.
 func (r *TransactionRepo) Update(ctx context.Context, tx *entity.Transaction) error {
 	conn, err := ConnFromContext(ctx)
 	if err != nil {
 		return err
 	}
 
-	err = conn.QueryRow(ctx,
+	dbTx, err := conn.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer dbTx.Rollback(ctx)
+
+	err = dbTx.QueryRow(ctx,
 		`UPDATE transactions
-		 SET type = $1, amount = $2, description = $3, date = $4, category_id = $5, updated_at = NOW()
-		 WHERE id = $6
-		 RETURNING updated_at`,
-		tx.Type, tx.Amount, tx.Description, tx.Date, tx.CategoryID, tx.ID,
-	).Scan(&tx.UpdatedAt)
+		 SET type = $1, amount = $2, description = $3, date = $4, category_id = $5, account_id = $6,
+		     statement_id = $7, currency = COALESCE(NULLIF($8, ''), currency), updated_at = NOW()
+		 WHERE id = $9
+		 RETURNING currency, updated_at`,
+		tx.Type, tx.Amount, tx.Description, tx.Date, tx.CategoryID, tx.AccountID, tx.StatementID, tx.Currency, tx.ID,
+	).Scan(&tx.Currency, &tx.UpdatedAt)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return domain.ErrNotFound
 		}
 		return err
 	}
-	return nil
+	if tx.TagIDs != nil {
+		if err := replaceTags(ctx, dbTx, "transaction_tags", "transaction_id", tx.ID, tx.TagIDs); err != nil {
+			return err
+		}
+	}
+	if tx.Splits != nil {
+		if err := replaceSplits(ctx, dbTx, tx.ID, tx.Splits); err != nil {
+			return err
+		}
+	}
+
+	return dbTx.Commit(ctx)
 }
 
 func (r *TransactionRepo) Delete(ctx context.Context, id uuid.UUID) error {
@@ -177,24 +231,41 @@ func (r *TransactionRepo) FindByID(ctx context.Context, id uuid.UUID) (*entity.T
 	}
 
 	var tx entity.Transaction
+	var categoryID *uuid.UUID
+	var categoryName, transferDirection *string
+	var tagIDs []string
 	err = conn.QueryRow(ctx,
-		`SELECT t.id, t.user_id, t.category_id, c.name AS category_name,
-		        t.type, t.amount, t.description, t.date::text, t.recurring_id, t.created_at, t.updated_at
+		`SELECT t.id, t.user_id, t.category_id, c.name AS category_name, t.account_id, a.name AS account_name,
+		        t.type, t.amount, t.currency, t.description, t.date::text, t.recurring_id, t.transfer_id, t.transfer_direction,
+		        t.statement_id, t.external_id, `+transactionTagsColumn+`, t.created_at, t.updated_at
 		 FROM transactions t
-		 JOIN categories c ON t.category_id = c.id
+		 LEFT JOIN categories c ON t.category_id = c.id
+		 JOIN accounts a ON t.account_id = a.id
 		 WHERE t.id = $1`, id,
-	).Scan(&tx.ID, &tx.UserID, &tx.CategoryID, &tx.CategoryName,
-		&tx.Type, &tx.Amount, &tx.Description, &tx.Date, &tx.RecurringID, &tx.CreatedAt, &tx.UpdatedAt)
+	).Scan(&tx.ID, &tx.UserID, &categoryID, &categoryName, &tx.AccountID, &tx.AccountName,
+		&tx.Type, &tx.Amount, &tx.Currency, &tx.Description, &tx.Date, &tx.RecurringID, &tx.TransferID, &transferDirection,
+		&tx.StatementID, &tx.ExternalID, &tagIDs, &tx.CreatedAt, &tx.UpdatedAt)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, domain.ErrNotFound
 		}
 		return nil, err
 	}
+	setOptionalColumns(&tx, categoryID, categoryName, transferDirection)
+	tx.TagIDs = parseUUIDs(tagIDs)
+
+	splits, err := findSplits(ctx, conn, []uuid.UUID{tx.ID})
+	if err != nil {
+		return nil, err
+	}
+	tx.Splits = splits[tx.ID]
 	return &tx, nil
 }
 
-func (r *TransactionRepo) FindAll(ctx context.Context, filter entity.TransactionFilter) (*entity.PaginatedTransactions, error) {
+// FindAll returns a page of transactions matching the filter. Projected
+// occurrences, if any, are merged into the result set before filtering,
+// ordering and pagination, so they page exactly like stored rows.
+func (r *TransactionRepo) FindAll(ctx context.Context, filter entity.TransactionFilter, projected []entity.Transaction) (*entity.PaginatedTransactions, error) {
 	conn, err := ConnFromContext(ctx)
 	if err != nil {
 		return nil, err
@@ -207,35 +278,18 @@ func (r *TransactionRepo) FindAll(ctx context.Context, filter entity.Transaction
 		filter.Page = 1
 	}
 
-	baseWhere := ` WHERE 1=1`
+	source := `transactions`
+	projectedCol := `false`
 	args := []any{}
-	argIdx := 1
-
-	if filter.Type != "" {
-		baseWhere += fmt.Sprintf(` AND t.type = $%d`, argIdx)
-		args = append(args, filter.Type)
-		argIdx++
-	}
-
-	if filter.CategoryID != nil {
-		baseWhere += fmt.Sprintf(` AND t.category_id = $%d`, argIdx)
-		args = append(args, *filter.CategoryID)
-		argIdx++
+	if len(projected) > 0 {
+		source, args = projectedSource(projected)
+		projectedCol = `t.is_projected`
 	}
 
-	if filter.StartDate != "" {
-		baseWhere += fmt.Sprintf(` AND t.date >= $%d`, argIdx)
-		args = append(args, filter.StartDate)
-		argIdx++
-	}
+	baseWhere, args := filterWhere(filter, args)
+	argIdx := len(args) + 1
 
-	if filter.EndDate != "" {
-		baseWhere += fmt.Sprintf(` AND t.date <= $%d`, argIdx)
-		args = append(args, filter.EndDate)
-		argIdx++
-	}
-
-	countQuery := fmt.Sprintf(`SELECT COUNT(*) FROM transactions t%s`, baseWhere)
+	countQuery := fmt.Sprintf(`SELECT COUNT(*) FROM %s t%s`, source, baseWhere)
 	var total int
 	if err := conn.QueryRow(ctx, countQuery, args...).Scan(&total); err != nil {
 		return nil, err
@@ -243,16 +297,33 @@ func (r *TransactionRepo) FindAll(ctx context.Context, filter entity.Transaction
 
 	totalPages := int(math.Ceil(float64(total) / float64(filter.PerPage)))
 
+	// With a search, rank by relevance and highlight the matched words. The
+	// description is HTML-escaped first so only the <mark> tags are markup.
+	highlightCol := `''`
+	orderBy := `t.date DESC, t.created_at DESC`
+	if filter.Query != "" {
+		tsQuery := fmt.Sprintf(`websearch_to_tsquery('%s', $%d)`, searchConfig, argIdx)
+		highlightCol = fmt.Sprintf(
+			`ts_headline('%s', replace(replace(replace(t.description, '&', '&amp;'), '<', '&lt;'), '>', '&gt;'), %s,
+			             'StartSel=<mark>, StopSel=</mark>, HighlightAll=true')`,
+			searchConfig, tsQuery)
+		orderBy = fmt.Sprintf(`ts_rank(t.search_vector, %s) DESC, %s`, tsQuery, orderBy)
+		args = append(args, filter.Query)
+		argIdx++
+	}
+
 	offset := (filter.Page - 1) * filter.PerPage
 	dataQuery := fmt.Sprintf(
-		`SELECT t.id, t.user_id, t.category_id, c.name AS category_name,
-		        t.type, t.amount, t.description, t.date::text, t.recurring_id, t.created_at, t.updated_at
-		 FROM transactions t
-		 JOIN categories c ON t.category_id = c.id
+		`SELECT t.id, t.user_id, t.category_id, c.name AS category_name, t.account_id, a.name AS account_name,
+		        t.type, t.amount, t.currency, t.description, t.date::text, t.recurring_id, t.transfer_id, t.transfer_direction,
+		        t.statement_id, t.external_id, %s, %s, %s, t.created_at, t.updated_at
+		 FROM %s t
+		 LEFT JOIN categories c ON t.category_id = c.id
+		 JOIN accounts a ON t.account_id = a.id
 		 %s
-		 ORDER BY t.date DESC, t.created_at DESC
+		 ORDER BY %s
 		 LIMIT $%d OFFSET $%d`,
-		baseWhere, argIdx, argIdx+1,
+		transactionTagsColumn, projectedCol, highlightCol, source, baseWhere, orderBy, argIdx, argIdx+1,
 	)
 	args = append(args, filter.PerPage, offset)
 
@@ -265,10 +336,16 @@ func (r *TransactionRepo) FindAll(ctx context.Context, filter entity.Transaction
 	var transactions []entity.Transaction
 	for rows.Next() {
 		var tx entity.Transaction
-		if err := rows.Scan(&tx.ID, &tx.UserID, &tx.CategoryID, &tx.CategoryName,
-			&tx.Type, &tx.Amount, &tx.Description, &tx.Date, &tx.RecurringID, &tx.CreatedAt, &tx.UpdatedAt); err != nil {
+		var categoryID *uuid.UUID
+		var categoryName, transferDirection *string
+		var tagIDs []string
+		if err := rows.Scan(&tx.ID, &tx.UserID, &categoryID, &categoryName, &tx.AccountID, &tx.AccountName,
+			&tx.Type, &tx.Amount, &tx.Currency, &tx.Description, &tx.Date, &tx.RecurringID, &tx.TransferID, &transferDirection,
+			&tx.StatementID, &tx.ExternalID, &tagIDs, &tx.IsProjected, &tx.Highlight, &tx.CreatedAt, &tx.UpdatedAt); err != nil {
 			return nil, err
 		}
+		setOptionalColumns(&tx, categoryID, categoryName, transferDirection)
+		tx.TagIDs = parseUUIDs(tagIDs)
 		transactions = append(transactions, tx)
 	}
 
@@ -280,6 +357,24 @@ func (r *TransactionRepo) FindAll(ctx context.Context, filter entity.Transaction
 		transactions = []entity.Transaction{}
 	}
 
+	ids := make([]uuid.UUID, 0, len(transactions))
+	for _, tx := range transactions {
+		if !tx.IsProjected {
+			ids = append(ids, tx.ID)
+		}
+	}
+	if len(ids) > 0 {
+		splits, err := findSplits(ctx, conn, ids)
+		if err != nil {
+			return nil, err
+		}
+		for i := range transactions {
+			if !transactions[i].IsProjected {
+				transactions[i].Splits = splits[transactions[i].ID]
+			}
+		}
+	}
+
 	return &entity.PaginatedTransactions{
 		Data:       transactions,
 		Total:      total,
@@ -289,6 +384,269 @@ func (r *TransactionRepo) FindAll(ctx context.Context, filter entity.Transaction
 	}, nil
 }
 
+// searchConfig is the text search configuration created by public migration
+// 004: Portuguese stemming over accent-stripped words.
+const searchConfig = "public.portuguese_unaccent"
+
+// filterWhere builds the WHERE clause for a TransactionFilter over the alias
+// "t", numbering its placeholders after the existing args.
+func filterWhere(filter entity.TransactionFilter, args []any) (string, []any) {
+	where := ` WHERE 1=1`
+	argIdx := len(args) + 1
+
+	if filter.Type != "" {
+		where += fmt.Sprintf(` AND t.type = $%d`, argIdx)
+		args = append(args, filter.Type)
+		argIdx++
+	}
+
+	if filter.Query != "" {
+		where += fmt.Sprintf(` AND t.search_vector @@ websearch_to_tsquery('%s', $%d)`, searchConfig, argIdx)
+		args = append(args, filter.Query)
+		argIdx++
+	}
+
+	if len(filter.CategoryIDs) > 0 {
+		where += fmt.Sprintf(
+			` AND (t.category_id = ANY($%[1]d::uuid[])
+			    OR EXISTS (SELECT 1 FROM transaction_splits s WHERE s.transaction_id = t.id AND s.category_id = ANY($%[1]d::uuid[])))`,
+			argIdx)
+		args = append(args, uuidStrings(filter.CategoryIDs))
+		argIdx++
+	}
+
+	if len(filter.TagIDs) > 0 {
+		where += fmt.Sprintf(
+			` AND (EXISTS (SELECT 1 FROM transaction_tags tt WHERE tt.transaction_id = t.id AND tt.tag_id = ANY($%[1]d::uuid[]))
+			    OR EXISTS (SELECT 1 FROM recurring_transaction_tags rtt WHERE rtt.recurring_id = t.recurring_id AND rtt.tag_id = ANY($%[1]d::uuid[])))`,
+			argIdx)
+		args = append(args, uuidStrings(filter.TagIDs))
+		argIdx++
+	}
+
+	if filter.UserID != nil {
+		where += fmt.Sprintf(` AND t.user_id = $%d`, argIdx)
+		args = append(args, *filter.UserID)
+		argIdx++
+	}
+
+	if filter.MinAmount != nil {
+		where += fmt.Sprintf(` AND t.amount >= $%d`, argIdx)
+		args = append(args, *filter.MinAmount)
+		argIdx++
+	}
+
+	if filter.MaxAmount != nil {
+		where += fmt.Sprintf(` AND t.amount <= $%d`, argIdx)
+		args = append(args, *filter.MaxAmount)
+		argIdx++
+	}
+
+	if filter.AccountID != nil {
+		where += fmt.Sprintf(` AND t.account_id = $%d`, argIdx)
+		args = append(args, *filter.AccountID)
+		argIdx++
+	}
+
+	if filter.StatementID != nil {
+		where += fmt.Sprintf(` AND t.statement_id = $%d`, argIdx)
+		args = append(args, *filter.StatementID)
+		argIdx++
+	}
+
+	if filter.StartDate != "" {
+		where += fmt.Sprintf(` AND t.date >= $%d`, argIdx)
+		args = append(args, filter.StartDate)
+		argIdx++
+	}
+
+	if filter.EndDate != "" {
+		where += fmt.Sprintf(` AND t.date <= $%d`, argIdx)
+		args = append(args, filter.EndDate)
+	}
+
+	return where, args
+}
+
+// exportBatchSize is how many rows Stream fetches from the cursor at a time.
+const exportBatchSize = 500
+
+// Stream calls fn for every stored transaction matching the filter, ordered
+// by account and then by date. Rows are read through a server-side cursor in
+// batches, so memory use does not grow with the result. Page and PerPage are
+// ignored and projected occurrences are not included.
+func (r *TransactionRepo) Stream(ctx context.Context, filter entity.TransactionFilter, fn func(entity.Transaction) error) error {
+	conn, err := ConnFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	// Cursors only live inside a transaction.
+	dbTx, err := conn.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer dbTx.Rollback(ctx)
+
+	where, args := filterWhere(filter, nil)
+	_, err = dbTx.Exec(ctx, fmt.Sprintf(
+		`DECLARE transactions_export NO SCROLL CURSOR FOR
+		 SELECT t.id, t.user_id, t.category_id, c.name AS category_name, t.account_id, a.name AS account_name,
+		        t.type, t.amount, t.currency, t.description, t.date::text, t.recurring_id, t.transfer_id, t.transfer_direction,
+		        t.statement_id, t.external_id, t.created_at, t.updated_at
+		 FROM transactions t
+		 LEFT JOIN categories c ON t.category_id = c.id
+		 JOIN accounts a ON t.account_id = a.id
+		 %s
+		 ORDER BY a.name, t.account_id, t.date, t.created_at`, where), args...)
+	if err != nil {
+		return err
+	}
+
+	for {
+		rows, err := dbTx.Query(ctx, fmt.Sprintf(`FETCH FORWARD %d FROM transactions_export`, exportBatchSize))
+		if err != nil {
+			return err
+		}
+		fetched := 0
+		for rows.Next() {
+			var tx entity.Transaction
+			var categoryID *uuid.UUID
+			var categoryName, transferDirection *string
+			if err := rows.Scan(&tx.ID, &tx.UserID, &categoryID, &categoryName, &tx.AccountID, &tx.AccountName,
+				&tx.Type, &tx.Amount, &tx.Currency, &tx.Description, &tx.Date, &tx.RecurringID, &tx.TransferID, &transferDirection,
+				&tx.StatementID, &tx.ExternalID, &tx.CreatedAt, &tx.UpdatedAt); err != nil {
+				rows.Close()
+				return err
+			}
+			setOptionalColumns(&tx, categoryID, categoryName, transferDirection)
+			if err := fn(tx); err != nil {
+				rows.Close()
+				return err
+			}
+			fetched++
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return err
+		}
+		if fetched < exportBatchSize {
+			break
+		}
+	}
+
+	return dbTx.Commit(ctx)
+}
+
+// replaceSplits replaces the split lines of a transaction, keeping their order.
+func replaceSplits(ctx context.Context, dbTx pgx.Tx, transactionID uuid.UUID, splits []entity.TransactionSplit) error {
+	if _, err := dbTx.Exec(ctx, `DELETE FROM transaction_splits WHERE transaction_id = $1`, transactionID); err != nil {
+		return err
+	}
+	for i := range splits {
+		var description *string
+		if splits[i].Description != "" {
+			description = &splits[i].Description
+		}
+		err := dbTx.QueryRow(ctx,
+			`INSERT INTO transaction_splits (transaction_id, category_id, amount, description, position)
+			 VALUES ($1, $2, $3, $4, $5)
+			 RETURNING id`,
+			transactionID, splits[i].CategoryID, splits[i].Amount, description, i,
+		).Scan(&splits[i].ID)
+		if err != nil {
+			if isForeignKeyViolation(err) {
+				return domain.ErrInvalidSplit
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+// findSplits returns the split lines of the given transactions, keyed by
+// transaction. Transactions that are not split have no entry.
+func findSplits(ctx context.Context, conn *pgxpool.Conn, transactionIDs []uuid.UUID) (map[uuid.UUID][]entity.TransactionSplit, error) {
+	rows, err := conn.Query(ctx,
+		`SELECT s.transaction_id, s.id, s.category_id, c.name, s.amount, COALESCE(s.description, '')
+		 FROM transaction_splits s
+		 JOIN categories c ON s.category_id = c.id
+		 WHERE s.transaction_id = ANY($1::uuid[])
+		 ORDER BY s.transaction_id, s.position`, uuidStrings(transactionIDs))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	splits := make(map[uuid.UUID][]entity.TransactionSplit)
+	for rows.Next() {
+		var transactionID uuid.UUID
+		var sp entity.TransactionSplit
+		if err := rows.Scan(&transactionID, &sp.ID, &sp.CategoryID, &sp.CategoryName, &sp.Amount, &sp.Description); err != nil {
+			return nil, err
+		}
+		splits[transactionID] = append(splits[transactionID], sp)
+	}
+	return splits, rows.Err()
+}
+
+// setOptionalColumns copies the nullable columns of a transaction row. Transfer
+// legs have no category; other transactions have no transfer.
+func setOptionalColumns(tx *entity.Transaction, categoryID *uuid.UUID, categoryName, transferDirection *string) {
+	if categoryID != nil {
+		tx.CategoryID = *categoryID
+	}
+	if categoryName != nil {
+		tx.CategoryName = *categoryName
+	}
+	if transferDirection != nil {
+		tx.TransferDirection = *transferDirection
+	}
+}
+
+// projectedSource builds a derived table that unions stored transactions with
+// the given projected occurrences. It returns the SQL fragment and its
+// positional arguments, which always start at $1.
+func projectedSource(projected []entity.Transaction) (string, []any) {
+	userIDs := make([]string, len(projected))
+	categoryIDs := make([]string, len(projected))
+	accountIDs := make([]string, len(projected))
+	types := make([]string, len(projected))
+	amounts := make([]string, len(projected))
+	currencies := make([]string, len(projected))
+	descriptions := make([]string, len(projected))
+	dates := make([]string, len(projected))
+	recurringIDs := make([]*string, len(projected))
+	for i, p := range projected {
+		userIDs[i] = p.UserID.String()
+		categoryIDs[i] = p.CategoryID.String()
+		accountIDs[i] = p.AccountID.String()
+		types[i] = p.Type
+		amounts[i] = p.Amount.String()
+		currencies[i] = p.Currency
+		descriptions[i] = p.Description
+		dates[i] = p.Date
+		if p.RecurringID != nil {
+			id := p.RecurringID.String()
+			recurringIDs[i] = &id
+		}
+	}
+
+	source := `(
+		SELECT id, user_id, category_id, account_id, type, amount, currency, description, date, recurring_id,
+		       transfer_id, transfer_direction, statement_id, external_id, search_vector, false AS is_projected,
+		       created_at, updated_at
+		FROM transactions
+		UNION ALL
+		SELECT '00000000-0000-0000-0000-000000000000'::uuid, p.user_id::uuid, p.category_id::uuid, p.account_id::uuid,
+		       p.type, p.amount::numeric(12,2), p.currency::char(3), p.description, p.date::date, p.recurring_id::uuid,
+		       NULL, NULL, NULL, NULL, to_tsvector('` + searchConfig + `', p.description), true, NOW(), NOW()
+		FROM unnest($1::text[], $2::text[], $3::text[], $4::text[], $5::numeric[], $6::text[], $7::text[], $8::text[], $9::text[])
+		     AS p(user_id, category_id, account_id, type, amount, currency, description, date, recurring_id)
+	)`
+	return source, []any{userIDs, categoryIDs, accountIDs, types, amounts, currencies, descriptions, dates, recurringIDs}
+}
+
 func (r *TransactionRepo) FindByRecurringIDAndDateRange(ctx context.Context, recurringID uuid.UUID, fromDate, toDate string) ([]entity.Transaction, error) {
 	conn, err := ConnFromContext(ctx)
 	if err != nil {
@@ -296,10 +654,11 @@ func (r *TransactionRepo) FindByRecurringIDAndDateRange(ctx context.Context, rec
 	}
 
 	rows, err := conn.Query(ctx,
-		`SELECT t.id, t.user_id, t.category_id, c.name AS category_name,
-		        t.type, t.amount, t.description, t.date::text, t.recurring_id, t.created_at, t.updated_at
+		`SELECT t.id, t.user_id, t.category_id, c.name AS category_name, t.account_id, a.name AS account_name,
+		        t.type, t.amount, t.description, t.date::text, t.recurring_id, t.statement_id, t.created_at, t.updated_at
 		 FROM transactions t
 		 JOIN categories c ON t.category_id = c.id
+		 JOIN accounts a ON t.account_id = a.id
 		 WHERE t.recurring_id = $1 AND t.date >= $2 AND t.date <= $3
 		 ORDER BY t.date`, recurringID, fromDate, toDate)
 	if err != nil {
@@ -310,8 +669,44 @@ func (r *TransactionRepo) FindByRecurringIDAndDateRange(ctx context.Context, rec
 	var txs []entity.Transaction
 	for rows.Next() {
 		var tx entity.Transaction
-		if err := rows.Scan(&tx.ID, &tx.UserID, &tx.CategoryID, &tx.CategoryName,
-			&tx.Type, &tx.Amount, &tx.Description, &tx.Date, &tx.RecurringID, &tx.CreatedAt, &tx.UpdatedAt); err != nil {
+		if err := rows.Scan(&tx.ID, &tx.UserID, &tx.CategoryID, &tx.CategoryName, &tx.AccountID, &tx.AccountName,
+			&tx.Type, &tx.Amount, &tx.Description, &tx.Date, &tx.RecurringID, &tx.StatementID, &tx.CreatedAt, &tx.UpdatedAt); err != nil {
+			return nil, err
+		}
+		txs = append(txs, tx)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if txs == nil {
+		txs = []entity.Transaction{}
+	}
+	return txs, nil
+}
+
+// FindByAccountAndDateRange returns the income and expense transactions of an
+// account dated within [fromDate, toDate], used to detect duplicates on import.
+func (r *TransactionRepo) FindByAccountAndDateRange(ctx context.Context, accountID uuid.UUID, fromDate, toDate string) ([]entity.Transaction, error) {
+	conn, err := ConnFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := conn.Query(ctx,
+		`SELECT id, user_id, account_id, type, amount, description, date::text, external_id, created_at, updated_at
+		 FROM transactions
+		 WHERE account_id = $1 AND date >= $2 AND date <= $3 AND type <> 'transfer'
+		 ORDER BY date, created_at`, accountID, fromDate, toDate)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var txs []entity.Transaction
+	for rows.Next() {
+		var tx entity.Transaction
+		if err := rows.Scan(&tx.ID, &tx.UserID, &tx.AccountID, &tx.Type, &tx.Amount, &tx.Description,
+			&tx.Date, &tx.ExternalID, &tx.CreatedAt, &tx.UpdatedAt); err != nil {
 			return nil, err
 		}
 		txs = append(txs, tx)
@@ -325,6 +720,8 @@ func (r *TransactionRepo) FindByRecurringIDAndDateRange(ctx context.Context, rec
 	return txs, nil
 }
 
+// BulkUpdate saves the transactions in one batch. Split transactions are left
+// alone, since their category and amount are tied to their lines.
 func (r *TransactionRepo) BulkUpdate(ctx context.Context, txs []entity.Transaction) error {
 	conn, err := ConnFromContext(ctx)
 	if err != nil {
@@ -335,9 +732,11 @@ func (r *TransactionRepo) BulkUpdate(ctx context.Context, txs []entity.Transacti
 	for i := range txs {
 		batch.Queue(
 			`UPDATE transactions
-			 SET type = $1, amount = $2, description = $3, category_id = $4, updated_at = NOW()
-			 WHERE id = $5`,
-			txs[i].Type, txs[i].Amount, txs[i].Description, txs[i].CategoryID, txs[i].ID,
+			 SET type = $1, amount = $2, description = $3, date = $4, category_id = $5, account_id = $6,
+			     statement_id = $7, updated_at = NOW()
+			 WHERE id = $8
+			   AND NOT EXISTS (SELECT 1 FROM transaction_splits s WHERE s.transaction_id = transactions.id)`,
+			txs[i].Type, txs[i].Amount, txs[i].Description, txs[i].Date, txs[i].CategoryID, txs[i].AccountID, txs[i].StatementID, txs[i].ID,
 		)
 	}
 
@@ -352,6 +751,126 @@ func (r *TransactionRepo) BulkUpdate(ctx context.Context, txs []entity.Transacti
 	return nil
 }
 
+// CreateTransfer inserts the legs of a transfer in a single database
+// transaction, so either both legs exist or neither does.
+func (r *TransactionRepo) CreateTransfer(ctx context.Context, legs []entity.Transaction) error {
+	conn, err := ConnFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	dbTx, err := conn.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer dbTx.Rollback(ctx)
+
+	for i := range legs {
+		err = dbTx.QueryRow(ctx,
+			`INSERT INTO transactions (user_id, account_id, type, amount, currency, description, date, transfer_id, transfer_direction)
+			 VALUES ($1, $2, 'transfer', $3, (SELECT currency FROM accounts WHERE id = $2), $4, $5, $6, $7)
+			 RETURNING id, currency, created_at, updated_at`,
+			legs[i].UserID, legs[i].AccountID, legs[i].Amount, legs[i].Description, legs[i].Date,
+			legs[i].TransferID, legs[i].TransferDirection,
+		).Scan(&legs[i].ID, &legs[i].Currency, &legs[i].CreatedAt, &legs[i].UpdatedAt)
+		if err != nil {
+			return err
+		}
+		legs[i].Type = "transfer"
+	}
+
+	return dbTx.Commit(ctx)
+}
+
+// UpdateTransfer updates the legs of a transfer in a single database transaction.
+func (r *TransactionRepo) UpdateTransfer(ctx context.Context, legs []entity.Transaction) error {
+	conn, err := ConnFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	dbTx, err := conn.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer dbTx.Rollback(ctx)
+
+	for i := range legs {
+		err = dbTx.QueryRow(ctx,
+			`UPDATE transactions
+			 SET account_id = $1, amount = $2, currency = (SELECT currency FROM accounts WHERE id = $1),
+			     description = $3, date = $4, updated_at = NOW()
+			 WHERE id = $5 AND transfer_id IS NOT NULL
+			 RETURNING currency, updated_at`,
+			legs[i].AccountID, legs[i].Amount, legs[i].Description, legs[i].Date, legs[i].ID,
+		).Scan(&legs[i].Currency, &legs[i].UpdatedAt)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return domain.ErrNotFound
+			}
+			return err
+		}
+	}
+
+	return dbTx.Commit(ctx)
+}
+
+func (r *TransactionRepo) FindByTransferID(ctx context.Context, transferID uuid.UUID) ([]entity.Transaction, error) {
+	conn, err := ConnFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := conn.Query(ctx,
+		`SELECT t.id, t.user_id, t.account_id, a.name AS account_name,
+		        t.type, t.amount, t.currency, t.description, t.date::text, t.transfer_id, t.transfer_direction,
+		        t.created_at, t.updated_at
+		 FROM transactions t
+		 JOIN accounts a ON t.account_id = a.id
+		 WHERE t.transfer_id = $1
+		 ORDER BY t.transfer_direction DESC`, transferID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var txs []entity.Transaction
+	for rows.Next() {
+		var tx entity.Transaction
+		if err := rows.Scan(&tx.ID, &tx.UserID, &tx.AccountID, &tx.AccountName,
+			&tx.Type, &tx.Amount, &tx.Currency, &tx.Description, &tx.Date, &tx.TransferID, &tx.TransferDirection,
+			&tx.CreatedAt, &tx.UpdatedAt); err != nil {
+			return nil, err
+		}
+		txs = append(txs, tx)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if txs == nil {
+		txs = []entity.Transaction{}
+	}
+	return txs, nil
+}
+
+func (r *TransactionRepo) DeleteByTransferID(ctx context.Context, transferID uuid.UUID) error {
+	conn, err := ConnFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	result, err := conn.Exec(ctx, `DELETE FROM transactions WHERE transfer_id = $1`, transferID)
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+// GetSummary returns the month's totals in the base currency and each
+// account's balance at the end of the month in the account's currency.
 func (r *TransactionRepo) GetSummary(ctx context.Context, month, year int, userID *uuid.UUID) (*entity.DashboardSummary, error) {
 	conn, err := ConnFromContext(ctx)
 	if err != nil {
@@ -359,17 +878,22 @@ func (r *TransactionRepo) GetSummary(ctx context.Context, month, year int, userI
 	}
 
 	userFilter := ""
+	// Opening balances belong to the tenant's accounts, not to a single member.
+	openingBalance := "(SELECT COALESCE(SUM(base_amount(initial_balance, currency, make_date($2::int, $1::int, 1))), 0) FROM accounts)"
+	accountOpening := "a.initial_balance"
 	args := []any{month, year}
 	if userID != nil {
 		userFilter = fmt.Sprintf(" AND user_id = $%d", len(args)+1)
+		openingBalance = "0"
+		accountOpening = "0"
 		args = append(args, *userID)
 	}
 
 	query := fmt.Sprintf(`
 		WITH current_month AS (
 			SELECT
-				COALESCE(SUM(CASE WHEN type = 'income' THEN amount ELSE 0 END), 0) AS income,
-				COALESCE(SUM(CASE WHEN type = 'expense' THEN amount ELSE 0 END), 0) AS expenses,
+				COALESCE(SUM(CASE WHEN type = 'income' THEN base_amount(amount, currency, date) ELSE 0 END), 0) AS income,
+				COALESCE(SUM(CASE WHEN type = 'expense' THEN base_amount(amount, currency, date) ELSE 0 END), 0) AS expenses,
 				COUNT(*) FILTER (WHERE type = 'income') AS income_count,
 				COUNT(*) FILTER (WHERE type = 'expense') AS expense_count
 			FROM transactions
@@ -379,29 +903,66 @@ func (r *TransactionRepo) GetSummary(ctx context.Context, month, year int, userI
 		),
 		previous_months AS (
 			SELECT
-				COALESCE(SUM(CASE WHEN type = 'income' THEN amount ELSE -amount END), 0) AS balance
+				%s + COALESCE(SUM(CASE WHEN type = 'income' OR transfer_direction = 'in' THEN 1 ELSE -1 END
+				                  * base_amount(amount, currency, date)), 0) AS balance
 			FROM transactions
 			WHERE date < make_date($2::int, $1::int, 1)
 			  %s
 		)
-		SELECT cm.income, cm.expenses, cm.income_count, cm.expense_count, pm.balance
-		FROM current_month cm, previous_months pm`, userFilter, userFilter)
+		SELECT (SELECT base_currency FROM settings), cm.income, cm.expenses, cm.income_count, cm.expense_count, pm.balance
+		FROM current_month cm, previous_months pm`, userFilter, openingBalance, userFilter)
 
 	summary := &entity.DashboardSummary{}
 	err = conn.QueryRow(ctx, query, args...).Scan(
-		&summary.TotalIncome, &summary.TotalExpenses,
+		&summary.Currency, &summary.TotalIncome, &summary.TotalExpenses,
 		&summary.IncomeCount, &summary.ExpenseCount,
 		&summary.PreviousBalance,
 	)
 	if err != nil {
-		return nil, err
+		return nil, rateError(err)
 	}
 
 	summary.Balance = summary.PreviousBalance + summary.TotalIncome - summary.TotalExpenses
 
+	accountUserFilter := ""
+	if userID != nil {
+		accountUserFilter = " AND t.user_id = $3"
+	}
+	accountQuery := fmt.Sprintf(`
+		SELECT a.id, a.name, a.currency,
+		       %s + COALESCE(SUM(CASE WHEN t.type = 'income' OR t.transfer_direction = 'in' THEN 1 ELSE -1 END
+		                         * convert_amount(t.amount, t.currency, a.currency, t.date)), 0) AS balance
+		FROM accounts a
+		LEFT JOIN transactions t ON t.account_id = a.id
+		     AND t.date < make_date($2::int, $1::int, 1) + INTERVAL '1 month'
+		     %s
+		GROUP BY a.id, a.name, a.currency, a.initial_balance, a.is_default
+		ORDER BY a.is_default DESC, a.name ASC`, accountOpening, accountUserFilter)
+
+	rows, err := conn.Query(ctx, accountQuery, args...)
+	if err != nil {
+		return nil, rateError(err)
+	}
+	defer rows.Close()
+
+	summary.Accounts = []entity.AccountBalance{}
+	for rows.Next() {
+		var ab entity.AccountBalance
+		if err := rows.Scan(&ab.AccountID, &ab.AccountName, &ab.Currency, &ab.Balance); err != nil {
+			return nil, err
+		}
+		summary.Accounts = append(summary.Accounts, ab)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, rateError(err)
+	}
+
 	return summary, nil
 }
 
+// GetByCategory sums the month's transactions of the given type per category,
+// in the base currency. Each line of a split transaction counts towards its
+// own category.
 func (r *TransactionRepo) GetByCategory(ctx context.Context, month, year int, txType string, userID *uuid.UUID) ([]entity.CategoryTotal, error) {
 	conn, err := ConnFromContext(ctx)
 	if err != nil {
@@ -416,9 +977,10 @@ func (r *TransactionRepo) GetByCategory(ctx context.Context, month, year int, tx
 	}
 
 	query := fmt.Sprintf(
-		`SELECT t.category_id, c.name AS category_name, SUM(t.amount) AS total
-		 FROM transactions t
+		`SELECT t.category_id, c.name AS category_name, COALESCE(SUM(base_amount(t.amount, t.currency, t.date)), 0) AS total
+		 FROM transaction_lines t
 		 JOIN categories c ON t.category_id = c.id
+		 JOIN accounts a ON t.account_id = a.id
 		 WHERE EXTRACT(MONTH FROM t.date::date) = $1
 		   AND EXTRACT(YEAR FROM t.date::date) = $2
 		   AND t.type = $3
@@ -428,7 +990,7 @@ func (r *TransactionRepo) GetByCategory(ctx context.Context, month, year int, tx
 
 	rows, err := conn.Query(ctx, query, args...)
 	if err != nil {
-		return nil, err
+		return nil, rateError(err)
 	}
 	defer rows.Close()
 
@@ -442,12 +1004,118 @@ func (r *TransactionRepo) GetByCategory(ctx context.Context, month, year int, tx
 	}
 
 	if err := rows.Err(); err != nil {
+		return nil, rateError(err)
+	}
+
+	if totals == nil {
+		totals = []entity.CategoryTotal{}
+	}
+
+	return totals, nil
+}
+
+// GetNonRecurringExpenses sums the expenses between startDate and endDate,
+// inclusive, that have no recurring template, per category and in the base
+// currency. Split lines count towards their own categories.
+func (r *TransactionRepo) GetNonRecurringExpenses(ctx context.Context, startDate, endDate string, userID *uuid.UUID) ([]entity.CategoryTotal, error) {
+	conn, err := ConnFromContext(ctx)
+	if err != nil {
 		return nil, err
 	}
 
+	userFilter := ""
+	args := []any{startDate, endDate}
+	if userID != nil {
+		userFilter = fmt.Sprintf(" AND t.user_id = $%d", len(args)+1)
+		args = append(args, *userID)
+	}
+
+	query := fmt.Sprintf(
+		`SELECT t.category_id, c.name AS category_name, COALESCE(SUM(base_amount(t.amount, t.currency, t.date)), 0) AS total
+		 FROM transaction_lines t
+		 JOIN categories c ON t.category_id = c.id
+		 WHERE t.date BETWEEN $1::date AND $2::date
+		   AND t.type = 'expense'
+		   AND t.recurring_id IS NULL
+		   %s
+		 GROUP BY t.category_id, c.name
+		 ORDER BY total DESC`, userFilter)
+
+	rows, err := conn.Query(ctx, query, args...)
+	if err != nil {
+		return nil, rateError(err)
+	}
+	defer rows.Close()
+
+	var totals []entity.CategoryTotal
+	for rows.Next() {
+		var ct entity.CategoryTotal
+		if err := rows.Scan(&ct.CategoryID, &ct.CategoryName, &ct.Total); err != nil {
+			return nil, err
+		}
+		totals = append(totals, ct)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, rateError(err)
+	}
+
 	if totals == nil {
 		totals = []entity.CategoryTotal{}
 	}
 
 	return totals, nil
 }
+
+// GetByTag sums the month's transactions of the given type per tag, in the
+// base currency. A transaction with several tags counts towards each of them.
+func (r *TransactionRepo) GetByTag(ctx context.Context, month, year int, txType string, userID *uuid.UUID) ([]entity.TagTotal, error) {
+	conn, err := ConnFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	userFilter := ""
+	args := []any{month, year, txType}
+	if userID != nil {
+		userFilter = fmt.Sprintf(" AND t.user_id = $%d", len(args)+1)
+		args = append(args, *userID)
+	}
+
+	query := fmt.Sprintf(
+		`SELECT g.id, g.name, COALESCE(SUM(base_amount(t.amount, t.currency, t.date)), 0) AS total
+		 FROM transactions t
+		 JOIN tags g ON EXISTS (SELECT 1 FROM transaction_tags tt WHERE tt.transaction_id = t.id AND tt.tag_id = g.id)
+		             OR EXISTS (SELECT 1 FROM recurring_transaction_tags rtt WHERE rtt.recurring_id = t.recurring_id AND rtt.tag_id = g.id)
+		 WHERE EXTRACT(MONTH FROM t.date::date) = $1
+		   AND EXTRACT(YEAR FROM t.date::date) = $2
+		   AND t.type = $3
+		   %s
+		 GROUP BY g.id, g.name
+		 ORDER BY total DESC`, userFilter)
+
+	rows, err := conn.Query(ctx, query, args...)
+	if err != nil {
+		return nil, rateError(err)
+	}
+	defer rows.Close()
+
+	var totals []entity.TagTotal
+	for rows.Next() {
+		var tt entity.TagTotal
+		if err := rows.Scan(&tt.TagID, &tt.TagName, &tt.Total); err != nil {
+			return nil, err
+		}
+		totals = append(totals, tt)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, rateError(err)
+	}
+
+	if totals == nil {
+		totals = []entity.TagTotal{}
+	}
+
+	return totals, nil
+}
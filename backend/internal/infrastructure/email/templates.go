@@ -45,3 +45,65 @@ func InviteEmail(appURL, token, tenantName, inviterName string) (subject string,
 </html>`, inviterName, tenantName, link, link, link)
 	return
 }
+
+// LimitAlertEmail warns that spending reached threshold percent of an expense
+// limit or budget. Amounts and dates come already formatted.
+func LimitAlertEmail(appURL, limitName string, threshold int, spent, available, periodStart, periodEnd string) (subject string, body string) {
+	subject = fmt.Sprintf("DNA Fami — %s atingiu %d%% do limite", limitName, threshold)
+	body = fmt.Sprintf(`
+<!DOCTYPE html>
+<html>
+<body style="font-family: sans-serif; max-width: 600px; margin: 0 auto; padding: 20px;">
+  <h2 style="color: #2563EB;">DNA Fami</h2>
+  <p>Os gastos em <strong>%s</strong> atingiram <strong>%d%%</strong> do limite no período de %s a %s.</p>
+  <p>Gasto: <strong>%s</strong> de <strong>%s</strong> disponíveis.</p>
+  <a href="%s" style="display: inline-block; background: #2563EB; color: white; padding: 12px 24px; border-radius: 8px; text-decoration: none; font-weight: bold;">
+    Ver Dashboard
+  </a>
+  <p style="color: #9CA3AF; font-size: 12px;">Você recebe este aviso uma vez por limite a cada período.</p>
+</body>
+</html>`, limitName, threshold, periodStart, periodEnd, spent, available, appURL)
+	return
+}
+
+func PasswordResetEmail(appURL, token string) (subject string, body string) {
+	subject = "DNA Fami — Redefinir senha"
+	link := fmt.Sprintf("%s/reset-password?token=%s", appURL, token)
+	body = fmt.Sprintf(`
+<!DOCTYPE html>
+<html>
+<body style="font-family: sans-serif; max-width: 600px; margin: 0 auto; padding: 20px;">
+  <h2 style="color: #2563EB;">DNA Fami</h2>
+  <p>Recebemos um pedido para redefinir a sua senha. Clique no botão abaixo para escolher uma nova:</p>
+  <a href="%s" style="display: inline-block; background: #2563EB; color: white; padding: 12px 24px; border-radius: 8px; text-decoration: none; font-weight: bold;">
+    Redefinir Senha
+  </a>
+  <p style="color: #6B7280; font-size: 14px; margin-top: 20px;">
+    Ou copie e cole este link no navegador:<br>
+    <a href="%s">%s</a>
+  </p>
+  <p style="color: #9CA3AF; font-size: 12px;">Este link expira em 1 hora e só pode ser usado uma vez. Se você não pediu a redefinição, ignore este email.</p>
+</body>
+</html>`, link, link, link)
+	return
+}
+
+// AccountLockedEmail tells the owner of an account that logins were blocked
+// after repeated wrong passwords. until comes already formatted.
+func AccountLockedEmail(appURL, until string) (subject string, body string) {
+	subject = "DNA Fami — Acesso bloqueado temporariamente"
+	body = fmt.Sprintf(`
+<!DOCTYPE html>
+<html>
+<body style="font-family: sans-serif; max-width: 600px; margin: 0 auto; padding: 20px;">
+  <h2 style="color: #2563EB;">DNA Fami</h2>
+  <p>Houve várias tentativas de login com senha errada na sua conta, então o acesso foi bloqueado até <strong>%s</strong>.</p>
+  <p>Se foi você, aguarde e tente novamente. Se não foi, alguém pode estar tentando adivinhar a sua senha: recomendamos trocá-la assim que o acesso for liberado.</p>
+  <a href="%s" style="display: inline-block; background: #2563EB; color: white; padding: 12px 24px; border-radius: 8px; text-decoration: none; font-weight: bold;">
+    Acessar DNA Fami
+  </a>
+  <p style="color: #9CA3AF; font-size: 12px;">Novas sequências de tentativas erradas bloqueiam o acesso por mais tempo.</p>
+</body>
+</html>`, until, appURL)
+	return
+}
@@ -0,0 +1,36 @@
+// Package exchangerate fetches currency exchange rates from external sources.
+// Provider is the abstraction the rate sync depends on; PTAXProvider reads the
+// Banco Central do Brasil reference rates, and another source only needs to
+// satisfy the same interface.
+package exchangerate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrUnavailable is returned when the source has no rate for the pair around
+// the requested date.
+var ErrUnavailable = errors.New("exchangerate: rate unavailable")
+
+// Provider returns what one unit of from is worth in to on date. Sources
+// without a quote on that exact day (weekends, holidays) return the latest
+// one before it.
+type Provider interface {
+	Name() string
+	Rate(ctx context.Context, from, to string, date time.Time) (float64, error)
+}
+
+// NewProvider returns the provider with the given name, or nil when name is
+// empty, meaning rates are only entered by hand.
+func NewProvider(name string) (Provider, error) {
+	switch name {
+	case "":
+		return nil, nil
+	case "ptax":
+		return NewPTAXProvider(), nil
+	}
+	return nil, fmt.Errorf("exchangerate: unknown provider %q", name)
+}
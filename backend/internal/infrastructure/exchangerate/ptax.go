@@ -0,0 +1,86 @@
+package exchangerate
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+const ptaxURL = "https://olinda.bcb.gov.br/olinda/servico/PTAX/versao/v1/odata/" +
+	"CotacaoMoedaPeriodo(moeda=@moeda,dataInicial=@dataInicial,dataFinalCotacao=@dataFinalCotacao)"
+
+// ptaxLookback is how far back PTAXProvider looks for the last quote before
+// a day without one.
+const ptaxLookback = 7 * 24 * time.Hour
+
+// PTAXProvider reads the PTAX closing rates published by Banco Central do
+// Brasil. PTAX quotes foreign currencies in BRL; other pairs are crossed
+// through BRL.
+type PTAXProvider struct {
+	client  *http.Client
+	baseURL string
+}
+
+func NewPTAXProvider() *PTAXProvider {
+	return &PTAXProvider{client: &http.Client{Timeout: 15 * time.Second}, baseURL: ptaxURL}
+}
+
+func (p *PTAXProvider) Name() string {
+	return "ptax"
+}
+
+func (p *PTAXProvider) Rate(ctx context.Context, from, to string, date time.Time) (float64, error) {
+	fromBRL, err := p.brlRate(ctx, from, date)
+	if err != nil {
+		return 0, err
+	}
+	toBRL, err := p.brlRate(ctx, to, date)
+	if err != nil {
+		return 0, err
+	}
+	return fromBRL / toBRL, nil
+}
+
+type ptaxResponse struct {
+	Value []struct {
+		Rate     float64 `json:"cotacaoVenda"`
+		Bulletin string  `json:"tipoBoletim"`
+	} `json:"value"`
+}
+
+// brlRate returns the last PTAX closing rate of currency on or before date.
+func (p *PTAXProvider) brlRate(ctx context.Context, currency string, date time.Time) (float64, error) {
+	if currency == "BRL" {
+		return 1, nil
+	}
+
+	url := fmt.Sprintf("%s?@moeda='%s'&@dataInicial='%s'&@dataFinalCotacao='%s'&$format=json&$select=cotacaoVenda,tipoBoletim",
+		p.baseURL, currency, date.Add(-ptaxLookback).Format("01-02-2006"), date.Format("01-02-2006"))
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return 0, err
+	}
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("ptax: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return 0, fmt.Errorf("ptax: status %d for %s", resp.StatusCode, currency)
+	}
+
+	var body ptaxResponse
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return 0, fmt.Errorf("ptax: %w", err)
+	}
+	// Quotes come in chronological order; each day has intermediate
+	// bulletins and a closing one.
+	for i := len(body.Value) - 1; i >= 0; i-- {
+		if body.Value[i].Bulletin == "Fechamento" && body.Value[i].Rate > 0 {
+			return body.Value[i].Rate, nil
+		}
+	}
+	return 0, fmt.Errorf("%w: %s on %s", ErrUnavailable, currency, date.Format("2006-01-02"))
+}
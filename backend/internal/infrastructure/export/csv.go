@@ -0,0 +1,55 @@
+package export
+
+import (
+	"bufio"
+	"encoding/csv"
+	"io"
+	"strings"
+
+	"github.com/dcunha/finance/backend/internal/domain/entity"
+)
+
+// CSVWriter writes the layout Brazilian spreadsheets open directly: ";" as
+// delimiter, dd/mm/yyyy dates and a decimal comma, with a UTF-8 BOM. The
+// default import profile reads it back.
+type CSVWriter struct {
+	buf *bufio.Writer
+	csv *csv.Writer
+}
+
+func NewCSVWriter(w io.Writer) (*CSVWriter, error) {
+	buf := bufio.NewWriter(w)
+	if _, err := buf.WriteString("\xef\xbb\xbf"); err != nil {
+		return nil, err
+	}
+	cw := csv.NewWriter(buf)
+	cw.Comma = ';'
+	if err := cw.Write(header); err != nil {
+		return nil, err
+	}
+	return &CSVWriter{buf: buf, csv: cw}, nil
+}
+
+func (w *CSVWriter) Write(tx entity.Transaction) error {
+	date, err := parseDate(tx.Date)
+	if err != nil {
+		return err
+	}
+	amount := strings.Replace(signedAmount(tx).String(), ".", ",", 1)
+	return w.csv.Write([]string{
+		date.Format("02/01/2006"),
+		tx.Description,
+		tx.CategoryName,
+		tx.AccountName,
+		typeLabel(tx),
+		amount,
+	})
+}
+
+func (w *CSVWriter) Close() error {
+	w.csv.Flush()
+	if err := w.csv.Error(); err != nil {
+		return err
+	}
+	return w.buf.Flush()
+}
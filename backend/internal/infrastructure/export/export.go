@@ -0,0 +1,75 @@
+// Package export writes transactions to files for download. Writers receive
+// rows one at a time so exports can be streamed straight to the response.
+package export
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/dcunha/finance/backend/internal/domain/entity"
+)
+
+// Writer receives transactions in order and must be closed to finish the file.
+type Writer interface {
+	Write(tx entity.Transaction) error
+	Close() error
+}
+
+// Format describes a supported export format.
+type Format struct {
+	ContentType string
+	Extension   string
+}
+
+var Formats = map[string]Format{
+	"csv":  {ContentType: "text/csv; charset=utf-8", Extension: "csv"},
+	"xlsx": {ContentType: "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet", Extension: "xlsx"},
+	"ofx":  {ContentType: "application/x-ofx", Extension: "ofx"},
+}
+
+// Options carries what some formats need besides the rows: OFX statements
+// declare their date range and each account's balance.
+type Options struct {
+	StartDate string
+	EndDate   string
+	Accounts  []entity.Account
+}
+
+// NewWriter returns a writer for the given format name.
+func NewWriter(format string, w io.Writer, opts Options) (Writer, error) {
+	switch format {
+	case "csv":
+		return NewCSVWriter(w)
+	case "xlsx":
+		return NewXLSXWriter(w)
+	case "ofx":
+		return NewOFXWriter(w, opts)
+	}
+	return nil, fmt.Errorf("export: unsupported format %q", format)
+}
+
+// signedAmount returns the amount as it affects the account balance.
+func signedAmount(tx entity.Transaction) entity.Money {
+	if tx.Type == "income" || tx.TransferDirection == "in" {
+		return tx.Amount
+	}
+	return -tx.Amount
+}
+
+// typeLabel is the Portuguese label used in spreadsheet exports.
+func typeLabel(tx entity.Transaction) string {
+	switch tx.Type {
+	case "income":
+		return "Receita"
+	case "expense":
+		return "Despesa"
+	}
+	return "Transferência"
+}
+
+var header = []string{"Data", "Descrição", "Categoria", "Conta", "Tipo", "Valor"}
+
+func parseDate(date string) (time.Time, error) {
+	return time.Parse("2006-01-02", date)
+}
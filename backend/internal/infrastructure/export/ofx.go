@@ -0,0 +1,176 @@
+package export
+
+import (
+	"bufio"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/dcunha/finance/backend/internal/domain/entity"
+	"github.com/google/uuid"
+)
+
+// OFXWriter writes an OFX 2.1 (XML) file with one bank statement per
+// account, declared in the account's currency. It relies on rows arriving
+// grouped by account, as TransactionRepository.Stream returns them. Card
+// accounts are exported as credit lines so the whole file stays in a single
+// message set.
+type OFXWriter struct {
+	w        *bufio.Writer
+	opts     Options
+	accounts map[uuid.UUID]entity.Account
+	now      time.Time
+	current  uuid.UUID
+	open     bool
+	trnUID   int
+}
+
+func NewOFXWriter(w io.Writer, opts Options) (*OFXWriter, error) {
+	o := &OFXWriter{
+		w:        bufio.NewWriter(w),
+		opts:     opts,
+		accounts: make(map[uuid.UUID]entity.Account, len(opts.Accounts)),
+		now:      time.Now(),
+	}
+	for _, a := range opts.Accounts {
+		o.accounts[a.ID] = a
+	}
+
+	fmt.Fprintf(o.w, `<?xml version="1.0" encoding="UTF-8" standalone="no"?>
+<?OFX OFXHEADER="200" VERSION="211" SECURITY="NONE" OLDFILEUID="NONE" NEWFILEUID="NONE"?>
+<OFX>
+<SIGNONMSGSRSV1><SONRS><STATUS><CODE>0</CODE><SEVERITY>INFO</SEVERITY></STATUS><DTSERVER>%s</DTSERVER><LANGUAGE>POR</LANGUAGE></SONRS></SIGNONMSGSRSV1>
+<BANKMSGSRSV1>
+`, o.now.Format("20060102150405"))
+	return o, nil
+}
+
+func (o *OFXWriter) Write(tx entity.Transaction) error {
+	if !o.open || tx.AccountID != o.current {
+		o.endStatement()
+		if err := o.startStatement(tx); err != nil {
+			return err
+		}
+	}
+
+	date, err := parseDate(tx.Date)
+	if err != nil {
+		return err
+	}
+	fitID := tx.ID.String()
+	if tx.ExternalID != nil {
+		fitID = *tx.ExternalID
+	}
+	amount := signedAmount(tx)
+
+	o.w.WriteString("<STMTTRN>")
+	o.element("TRNTYPE", trnType(tx, amount))
+	o.element("DTPOSTED", date.Format("20060102"))
+	o.element("TRNAMT", amount.String())
+	o.element("FITID", fitID)
+	// NAME is limited to 32 characters; the full text goes in MEMO.
+	o.element("NAME", truncate(tx.Description, 32))
+	memo := tx.Description
+	if tx.CategoryName != "" {
+		memo += " (" + tx.CategoryName + ")"
+	}
+	o.element("MEMO", memo)
+	_, err = o.w.WriteString("</STMTTRN>\n")
+	return err
+}
+
+func (o *OFXWriter) Close() error {
+	o.endStatement()
+	o.w.WriteString("</BANKMSGSRSV1>\n</OFX>\n")
+	return o.w.Flush()
+}
+
+// startStatement opens the statement of tx's account. Without a start date
+// in the filter, the first (oldest) row of the account marks the start.
+func (o *OFXWriter) startStatement(tx entity.Transaction) error {
+	start := o.opts.StartDate
+	if start == "" {
+		start = tx.Date
+	}
+	startDate, err := parseDate(start)
+	if err != nil {
+		return err
+	}
+
+	o.current = tx.AccountID
+	o.open = true
+	o.trnUID++
+
+	o.w.WriteString("<STMTTRNRS>")
+	o.element("TRNUID", fmt.Sprint(o.trnUID))
+	o.w.WriteString("<STATUS><CODE>0</CODE><SEVERITY>INFO</SEVERITY></STATUS><STMTRS>")
+	currency := o.accounts[tx.AccountID].Currency
+	if currency == "" {
+		currency = "BRL"
+	}
+	o.element("CURDEF", currency)
+	o.w.WriteString("<BANKACCTFROM>")
+	o.element("BANKID", "0000")
+	o.element("ACCTID", tx.AccountID.String())
+	o.element("ACCTTYPE", acctType(o.accounts[tx.AccountID].Type))
+	o.w.WriteString("</BANKACCTFROM>\n<BANKTRANLIST>")
+	o.element("DTSTART", startDate.Format("20060102"))
+	o.element("DTEND", o.endDate())
+	o.w.WriteString("\n")
+	return nil
+}
+
+// endStatement closes the open statement with the account's current balance.
+func (o *OFXWriter) endStatement() {
+	if !o.open {
+		return
+	}
+	o.w.WriteString("</BANKTRANLIST>\n<LEDGERBAL>")
+	o.element("BALAMT", o.accounts[o.current].Balance.String())
+	o.element("DTASOF", o.now.Format("20060102"))
+	o.w.WriteString("</LEDGERBAL></STMTRS></STMTTRNRS>\n")
+	o.open = false
+}
+
+func (o *OFXWriter) endDate() string {
+	if end, err := parseDate(o.opts.EndDate); err == nil {
+		return end.Format("20060102")
+	}
+	return o.now.Format("20060102")
+}
+
+func (o *OFXWriter) element(tag, value string) {
+	o.w.WriteString("<" + tag + ">")
+	xml.EscapeText(o.w, []byte(value))
+	o.w.WriteString("</" + tag + ">")
+}
+
+func trnType(tx entity.Transaction, amount entity.Money) string {
+	if tx.Type == "transfer" {
+		return "XFER"
+	}
+	if amount < 0 {
+		return "DEBIT"
+	}
+	return "CREDIT"
+}
+
+func acctType(accountType string) string {
+	switch accountType {
+	case "savings":
+		return "SAVINGS"
+	case "credit_card":
+		return "CREDITLINE"
+	}
+	return "CHECKING"
+}
+
+func truncate(s string, n int) string {
+	s = strings.TrimSpace(s)
+	if r := []rune(s); len(r) > n {
+		return string(r[:n])
+	}
+	return s
+}
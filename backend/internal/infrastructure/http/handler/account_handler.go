@@ -0,0 +1,117 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/dcunha/finance/backend/internal/domain/entity"
+	"github.com/dcunha/finance/backend/internal/domain/usecase"
+	"github.com/dcunha/finance/backend/internal/infrastructure/http/middleware"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type AccountHandler struct {
+	uc *usecase.AccountUsecase
+}
+
+func NewAccountHandler(uc *usecase.AccountUsecase) *AccountHandler {
+	return &AccountHandler{uc: uc}
+}
+
+type accountRequest struct {
+	Name           string       `json:"name" binding:"required"`
+	Type           string       `json:"type" binding:"required,oneof=checking savings cash investment other"`
+	Currency       string       `json:"currency" binding:"omitempty,iso4217"`
+	InitialBalance entity.Money `json:"initial_balance"`
+}
+
+func (h *AccountHandler) List(c *gin.Context) {
+	accounts, err := h.uc.List(c.Request.Context())
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, accounts)
+}
+
+func (h *AccountHandler) GetByID(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		return
+	}
+
+	account, err := h.uc.GetByID(c.Request.Context(), id)
+	if err != nil {
+		status := mapDomainError(err)
+		c.JSON(status, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, account)
+}
+
+func (h *AccountHandler) Create(c *gin.Context) {
+	userID := middleware.GetUserID(c)
+	var req accountRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	account := &entity.Account{
+		UserID:         &userID,
+		Name:           req.Name,
+		Type:           req.Type,
+		Currency:       req.Currency,
+		InitialBalance: req.InitialBalance,
+	}
+
+	if err := h.uc.Create(c.Request.Context(), account); err != nil {
+		status := mapDomainError(err)
+		c.JSON(status, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, account)
+}
+
+func (h *AccountHandler) Update(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		return
+	}
+
+	var req accountRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	account, err := h.uc.Update(c.Request.Context(), id, req.Name, req.Type, req.Currency, req.InitialBalance)
+	if err != nil {
+		status := mapDomainError(err)
+		c.JSON(status, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, account)
+}
+
+func (h *AccountHandler) Delete(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		return
+	}
+
+	if err := h.uc.Delete(c.Request.Context(), id); err != nil {
+		status := mapDomainError(err)
+		c.JSON(status, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusNoContent, nil)
+}
@@ -4,6 +4,7 @@ import (
 	"net/http"
 
 	"github.com/dcunha/finance/backend/internal/domain/usecase"
+	"github.com/dcunha/finance/backend/internal/infrastructure/http/middleware"
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 )
@@ -83,7 +84,7 @@ func (h *AdminHandler) DeleteUser(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
 		return
 	}
-	if err := h.uc.DeleteUser(c.Request.Context(), id); err != nil {
+	if err := h.uc.DeleteUser(c.Request.Context(), middleware.GetTenantID(c), id); err != nil {
 		status := mapDomainError(err)
 		c.JSON(status, gin.H{"error": err.Error()})
 		return
@@ -102,7 +103,7 @@ func (h *AdminHandler) ResetPassword(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
-	if err := h.uc.ResetPassword(c.Request.Context(), id, req.NewPassword); err != nil {
+	if err := h.uc.ResetPassword(c.Request.Context(), middleware.GetTenantID(c), id, req.NewPassword); err != nil {
 		status := mapDomainError(err)
 		c.JSON(status, gin.H{"error": err.Error()})
 		return
@@ -0,0 +1,136 @@
+package handler
+
+import (
+	"errors"
+	"mime"
+	"net/http"
+
+	"github.com/dcunha/finance/backend/internal/domain/entity"
+	"github.com/dcunha/finance/backend/internal/domain/usecase"
+	"github.com/dcunha/finance/backend/internal/infrastructure/http/middleware"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// maxAttachmentRequestSize leaves room for the multipart envelope around a
+// file of the maximum attachment size.
+const maxAttachmentRequestSize = usecase.MaxAttachmentSize + 1<<20
+
+type AttachmentHandler struct {
+	uc *usecase.AttachmentUsecase
+}
+
+func NewAttachmentHandler(uc *usecase.AttachmentUsecase) *AttachmentHandler {
+	return &AttachmentHandler{uc: uc}
+}
+
+func (h *AttachmentHandler) List(c *gin.Context) {
+	transactionID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		return
+	}
+
+	attachments, err := h.uc.List(c.Request.Context(), transactionID)
+	if err != nil {
+		status := mapDomainError(err)
+		c.JSON(status, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, attachments)
+}
+
+func (h *AttachmentHandler) Upload(c *gin.Context) {
+	transactionID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		return
+	}
+
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxAttachmentRequestSize)
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		var maxErr *http.MaxBytesError
+		if errors.As(err, &maxErr) {
+			c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": "file too large"})
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": "file is required"})
+		return
+	}
+	file, err := fileHeader.Open()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "file is required"})
+		return
+	}
+	defer file.Close()
+
+	userID := middleware.GetUserID(c)
+	attachment := &entity.Attachment{
+		TransactionID: transactionID,
+		UserID:        &userID,
+		FileName:      fileHeader.Filename,
+	}
+	if err := h.uc.Upload(c.Request.Context(), attachment, file); err != nil {
+		status := mapDomainError(err)
+		c.JSON(status, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, attachment)
+}
+
+// Download streams the file. Images and PDFs are shown inline unless
+// ?download=true asks for a file download.
+func (h *AttachmentHandler) Download(c *gin.Context) {
+	transactionID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		return
+	}
+	id, err := uuid.Parse(c.Param("attachmentId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid attachment id"})
+		return
+	}
+
+	attachment, content, err := h.uc.Open(c.Request.Context(), transactionID, id)
+	if err != nil {
+		status := mapDomainError(err)
+		c.JSON(status, gin.H{"error": err.Error()})
+		return
+	}
+	defer content.Close()
+
+	disposition := "inline"
+	if c.Query("download") == "true" {
+		disposition = "attachment"
+	}
+	c.DataFromReader(http.StatusOK, attachment.Size, attachment.ContentType, content, map[string]string{
+		"Content-Disposition":    mime.FormatMediaType(disposition, map[string]string{"filename": attachment.FileName}),
+		"X-Content-Type-Options": "nosniff",
+		"Cache-Control":          "private, max-age=3600",
+	})
+}
+
+func (h *AttachmentHandler) Delete(c *gin.Context) {
+	transactionID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		return
+	}
+	id, err := uuid.Parse(c.Param("attachmentId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid attachment id"})
+		return
+	}
+
+	if err := h.uc.Delete(c.Request.Context(), transactionID, id); err != nil {
+		status := mapDomainError(err)
+		c.JSON(status, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusNoContent, nil)
+}
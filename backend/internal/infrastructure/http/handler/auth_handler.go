@@ -35,6 +35,23 @@ type selectTenantRequest struct {
 	TenantID      string `json:"tenant_id" binding:"required"`
 }
 
+type twoFactorTokenRequest struct {
+	TwoFactorToken string `json:"two_factor_token" binding:"required"`
+}
+
+type twoFactorCodeRequest struct {
+	TwoFactorToken string `json:"two_factor_token" binding:"required"`
+	Code           string `json:"code" binding:"required"`
+}
+
+type oidcCompleteRequest struct {
+	OIDCToken string `json:"oidc_token" binding:"required"`
+}
+
+type refreshRequest struct {
+	RefreshToken string `json:"refresh_token" binding:"required"`
+}
+
 type updateProfileRequest struct {
 	Name  string `json:"name" binding:"required"`
 	Email string `json:"email" binding:"required,email"`
@@ -70,7 +87,106 @@ func (h *AuthHandler) Login(c *gin.Context) {
 		return
 	}
 
-	// Single tenant: enrich user with full schema data
+	h.respondLogin(c, result)
+}
+
+// VerifyTwoFactor is the login step after the password for users with
+// two-factor authentication: a TOTP code or a recovery code.
+func (h *AuthHandler) VerifyTwoFactor(c *gin.Context) {
+	var req twoFactorCodeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	result, err := h.uc.VerifyTwoFactor(c.Request.Context(), req.TwoFactorToken, req.Code)
+	if err != nil {
+		h.loginStepError(c, err)
+		return
+	}
+
+	h.respondLogin(c, result)
+}
+
+// SetupTwoFactor starts the enrollment a tenant requires during login.
+func (h *AuthHandler) SetupTwoFactor(c *gin.Context) {
+	var req twoFactorTokenRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	setup, err := h.uc.SetupTwoFactor(c.Request.Context(), req.TwoFactorToken)
+	if err != nil {
+		h.loginStepError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, setup)
+}
+
+// EnableTwoFactor confirms the enrollment started by SetupTwoFactor and
+// completes the login, returning the recovery codes with it.
+func (h *AuthHandler) EnableTwoFactor(c *gin.Context) {
+	var req twoFactorCodeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	result, err := h.uc.EnableTwoFactor(c.Request.Context(), req.TwoFactorToken, req.Code)
+	if err != nil {
+		h.loginStepError(c, err)
+		return
+	}
+
+	h.respondLogin(c, result)
+}
+
+// CompleteOIDC finishes a login with a provider such as Google: the token
+// comes from the callback's redirect to the frontend, and the response is the
+// same as the password login's.
+func (h *AuthHandler) CompleteOIDC(c *gin.Context) {
+	var req oidcCompleteRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	result, err := h.uc.CompleteOIDC(c.Request.Context(), req.OIDCToken)
+	if err != nil {
+		h.loginStepError(c, err)
+		return
+	}
+
+	h.respondLogin(c, result)
+}
+
+// loginStepError answers the steps that continue a login with a token from
+// the previous one.
+func (h *AuthHandler) loginStepError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, domain.ErrInvalidCredentials):
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "sessão expirada, faça login novamente"})
+	case errors.Is(err, domain.ErrInvalidTOTPCode):
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "código de verificação inválido"})
+	case errors.Is(err, domain.ErrTooManyAttempts):
+		c.JSON(http.StatusTooManyRequests, gin.H{"error": "muitos códigos incorretos, faça login novamente mais tarde"})
+	case errors.Is(err, domain.ErrNoMemberships):
+		c.JSON(http.StatusForbidden, gin.H{"error": "conta sem acesso a nenhum dashboard"})
+	default:
+		status := mapDomainError(err)
+		if status == http.StatusInternalServerError {
+			c.JSON(status, gin.H{"error": "internal server error"})
+			return
+		}
+		c.JSON(status, gin.H{"error": err.Error()})
+	}
+}
+
+// respondLogin sends a login result, with the full schema user when a
+// single tenant was selected.
+func (h *AuthHandler) respondLogin(c *gin.Context, result *usecase.LoginResult) {
 	if result.Token != "" && result.User != nil && result.TenantID != nil {
 		enriched := h.enrichUser(c, result.User.ID, *result.TenantID)
 		if enriched != nil {
@@ -94,7 +210,7 @@ func (h *AuthHandler) SelectTenant(c *gin.Context) {
 		return
 	}
 
-	token, schemaUserID, role, err := h.uc.SelectTenant(c.Request.Context(), req.SelectorToken, tenantID)
+	tokens, membership, err := h.uc.SelectTenant(c.Request.Context(), req.SelectorToken, tenantID)
 	if err != nil {
 		if errors.Is(err, domain.ErrInvalidCredentials) || errors.Is(err, domain.ErrForbidden) {
 			c.JSON(http.StatusUnauthorized, gin.H{"error": "sessão expirada, faça login novamente"})
@@ -105,12 +221,50 @@ func (h *AuthHandler) SelectTenant(c *gin.Context) {
 	}
 
 	// Enrich user with schema data
-	user := h.enrichUser(c, schemaUserID, tenantID)
+	user := h.enrichUser(c, membership.SchemaUserID, tenantID)
 	if user == nil {
-		user = &entity.User{ID: schemaUserID, Role: role}
+		user = &entity.User{ID: membership.SchemaUserID, Role: membership.Role}
+	}
+
+	c.JSON(http.StatusOK, gin.H{"token": tokens.Token, "refresh_token": tokens.RefreshToken, "user": user})
+}
+
+// Refresh rotates the refresh token and returns a new access token with it.
+func (h *AuthHandler) Refresh(c *gin.Context) {
+	var req refreshRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	tokens, err := h.uc.Refresh(c.Request.Context(), req.RefreshToken)
+	if err != nil {
+		if errors.Is(err, domain.ErrSessionRevoked) {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "sessão expirada, faça login novamente"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+
+	c.JSON(http.StatusOK, tokens)
+}
+
+func (h *AuthHandler) Logout(c *gin.Context) {
+	if err := h.uc.Logout(c.Request.Context(), middleware.GetSessionID(c)); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
 	}
+	c.Status(http.StatusNoContent)
+}
 
-	c.JSON(http.StatusOK, gin.H{"token": token, "user": user})
+// LogoutAll signs the user out on every device and in every tenant.
+func (h *AuthHandler) LogoutAll(c *gin.Context) {
+	if err := h.uc.LogoutAll(c.Request.Context(), middleware.GetGlobalUserID(c)); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+	c.Status(http.StatusNoContent)
 }
 
 // enrichUser acquires a schema connection and fetches the full per-schema user.
@@ -169,7 +323,8 @@ func (h *AuthHandler) ChangePassword(c *gin.Context) {
 		return
 	}
 	userID := middleware.GetUserID(c)
-	if err := h.uc.ChangePassword(c.Request.Context(), userID, req.OldPassword, req.NewPassword); err != nil {
+	sessionID := middleware.GetSessionID(c)
+	if err := h.uc.ChangePassword(c.Request.Context(), userID, sessionID, req.OldPassword, req.NewPassword); err != nil {
 		status := mapDomainError(err)
 		c.JSON(status, gin.H{"error": err.Error()})
 		return
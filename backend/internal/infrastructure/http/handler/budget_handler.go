@@ -0,0 +1,142 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/dcunha/finance/backend/internal/domain/entity"
+	"github.com/dcunha/finance/backend/internal/domain/usecase"
+	"github.com/dcunha/finance/backend/internal/infrastructure/http/middleware"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type BudgetHandler struct {
+	uc *usecase.BudgetUsecase
+}
+
+func NewBudgetHandler(uc *usecase.BudgetUsecase) *BudgetHandler {
+	return &BudgetHandler{uc: uc}
+}
+
+// budgetRequest takes either a fixed amount or a percentage of income,
+// optionally restricted to one income category. Omitted alert thresholds are
+// kept on update.
+type budgetRequest struct {
+	CategoryID       *string       `json:"category_id" binding:"omitempty,uuid"`
+	Period           string        `json:"period" binding:"required,oneof=weekly monthly quarterly yearly"`
+	Amount           *entity.Money `json:"amount" binding:"omitempty,gt=0"`
+	IncomePercentage *float64      `json:"income_percentage" binding:"omitempty,gt=0,lte=100"`
+	IncomeCategoryID *string       `json:"income_category_id" binding:"omitempty,uuid"`
+	Rollover         bool          `json:"rollover"`
+	StartDate        string        `json:"start_date" binding:"required,datetime=2006-01-02"`
+	EndDate          *string       `json:"end_date" binding:"omitempty,datetime=2006-01-02"`
+	AlertThresholds  []int         `json:"alert_thresholds" binding:"omitempty,max=10,dive,min=1,max=1000"`
+}
+
+func (req budgetRequest) toEntity() *entity.Budget {
+	b := &entity.Budget{
+		Period:           req.Period,
+		Amount:           req.Amount,
+		IncomePercentage: req.IncomePercentage,
+		Rollover:         req.Rollover,
+		StartDate:        req.StartDate,
+		EndDate:          req.EndDate,
+		AlertThresholds:  req.AlertThresholds,
+	}
+	// Both ids were validated by the binding.
+	if req.CategoryID != nil {
+		id, _ := uuid.Parse(*req.CategoryID)
+		b.CategoryID = &id
+	}
+	if req.IncomeCategoryID != nil {
+		id, _ := uuid.Parse(*req.IncomeCategoryID)
+		b.IncomeCategoryID = &id
+	}
+	return b
+}
+
+func (h *BudgetHandler) List(c *gin.Context) {
+	budgets, err := h.uc.List(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+
+	c.JSON(http.StatusOK, budgets)
+}
+
+func (h *BudgetHandler) GetByID(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		return
+	}
+
+	budget, err := h.uc.GetByID(c.Request.Context(), id)
+	if err != nil {
+		status := mapDomainError(err)
+		c.JSON(status, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, budget)
+}
+
+func (h *BudgetHandler) Create(c *gin.Context) {
+	userID := middleware.GetUserID(c)
+	var req budgetRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	budget := req.toEntity()
+	budget.UserID = userID
+	if err := h.uc.Create(c.Request.Context(), budget); err != nil {
+		status := mapDomainError(err)
+		c.JSON(status, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, budget)
+}
+
+func (h *BudgetHandler) Update(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		return
+	}
+
+	var req budgetRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	budget := req.toEntity()
+	budget.ID = id
+	if err := h.uc.Update(c.Request.Context(), budget); err != nil {
+		status := mapDomainError(err)
+		c.JSON(status, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, budget)
+}
+
+func (h *BudgetHandler) Delete(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		return
+	}
+
+	if err := h.uc.Delete(c.Request.Context(), id); err != nil {
+		status := mapDomainError(err)
+		c.JSON(status, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusNoContent, nil)
+}
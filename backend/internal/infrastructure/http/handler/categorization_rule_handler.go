@@ -0,0 +1,174 @@
+package handler
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/dcunha/finance/backend/internal/domain/entity"
+	"github.com/dcunha/finance/backend/internal/domain/usecase"
+	"github.com/dcunha/finance/backend/internal/infrastructure/http/middleware"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type CategorizationRuleHandler struct {
+	uc *usecase.CategorizationRuleUsecase
+}
+
+func NewCategorizationRuleHandler(uc *usecase.CategorizationRuleUsecase) *CategorizationRuleHandler {
+	return &CategorizationRuleHandler{uc: uc}
+}
+
+type categorizationRuleRequest struct {
+	Name                string        `json:"name" binding:"required,max=100"`
+	Priority            int           `json:"priority"`
+	DescriptionContains string        `json:"description_contains" binding:"max=255"`
+	Type                string        `json:"type" binding:"omitempty,oneof=income expense"`
+	MinAmount           *entity.Money `json:"min_amount" binding:"omitempty,gte=0"`
+	MaxAmount           *entity.Money `json:"max_amount" binding:"omitempty,gte=0"`
+	AccountID           string        `json:"account_id" binding:"omitempty,uuid"`
+	CategoryID          string        `json:"category_id" binding:"required,uuid"`
+	IsActive            *bool         `json:"is_active"`
+}
+
+type applyRulesRequest struct {
+	StartDate string `json:"start_date"`
+	EndDate   string `json:"end_date"`
+}
+
+func (req categorizationRuleRequest) toEntity() *entity.CategorizationRule {
+	categoryID, _ := uuid.Parse(req.CategoryID)
+	rule := &entity.CategorizationRule{
+		Name:                req.Name,
+		Priority:            req.Priority,
+		DescriptionContains: strings.TrimSpace(req.DescriptionContains),
+		Type:                req.Type,
+		MinAmount:           req.MinAmount,
+		MaxAmount:           req.MaxAmount,
+		CategoryID:          categoryID,
+		IsActive:            req.IsActive == nil || *req.IsActive,
+	}
+	if req.AccountID != "" {
+		accountID, _ := uuid.Parse(req.AccountID)
+		rule.AccountID = &accountID
+	}
+	return rule
+}
+
+func (h *CategorizationRuleHandler) List(c *gin.Context) {
+	rules, err := h.uc.List(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+
+	c.JSON(http.StatusOK, rules)
+}
+
+func (h *CategorizationRuleHandler) GetByID(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		return
+	}
+
+	rule, err := h.uc.GetByID(c.Request.Context(), id)
+	if err != nil {
+		status := mapDomainError(err)
+		c.JSON(status, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, rule)
+}
+
+func (h *CategorizationRuleHandler) Create(c *gin.Context) {
+	userID := middleware.GetUserID(c)
+	var req categorizationRuleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	rule := req.toEntity()
+	rule.UserID = &userID
+
+	if err := h.uc.Create(c.Request.Context(), rule); err != nil {
+		status := mapDomainError(err)
+		c.JSON(status, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, rule)
+}
+
+func (h *CategorizationRuleHandler) Update(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		return
+	}
+
+	var req categorizationRuleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	rule, err := h.uc.Update(c.Request.Context(), id, req.toEntity())
+	if err != nil {
+		status := mapDomainError(err)
+		c.JSON(status, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, rule)
+}
+
+func (h *CategorizationRuleHandler) Delete(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		return
+	}
+
+	if err := h.uc.Delete(c.Request.Context(), id); err != nil {
+		status := mapDomainError(err)
+		c.JSON(status, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusNoContent, nil)
+}
+
+// Apply re-runs the rules over stored transactions, optionally limited to a
+// date range.
+func (h *CategorizationRuleHandler) Apply(c *gin.Context) {
+	var req applyRulesRequest
+	// The body is optional.
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	for _, date := range []string{req.StartDate, req.EndDate} {
+		if date == "" {
+			continue
+		}
+		if _, err := time.Parse("2006-01-02", date); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "dates must be in YYYY-MM-DD format"})
+			return
+		}
+	}
+
+	result, err := h.uc.Apply(c.Request.Context(), req.StartDate, req.EndDate)
+	if err != nil {
+		status := mapDomainError(err)
+		c.JSON(status, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
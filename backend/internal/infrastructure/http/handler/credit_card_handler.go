@@ -0,0 +1,238 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/dcunha/finance/backend/internal/domain/entity"
+	"github.com/dcunha/finance/backend/internal/domain/usecase"
+	"github.com/dcunha/finance/backend/internal/infrastructure/http/middleware"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type CreditCardHandler struct {
+	uc *usecase.CreditCardUsecase
+}
+
+func NewCreditCardHandler(uc *usecase.CreditCardUsecase) *CreditCardHandler {
+	return &CreditCardHandler{uc: uc}
+}
+
+type creditCardRequest struct {
+	Name        string        `json:"name" binding:"required"`
+	ClosingDay  int           `json:"closing_day" binding:"required,min=1,max=31"`
+	DueDay      int           `json:"due_day" binding:"required,min=1,max=31"`
+	CreditLimit *entity.Money `json:"credit_limit" binding:"omitempty,gt=0"`
+}
+
+type purchaseRequest struct {
+	Amount       entity.Money `json:"amount" binding:"required,gt=0"`
+	Description  string       `json:"description"`
+	Date         string       `json:"date" binding:"required"`
+	CategoryID   string       `json:"category_id" binding:"required,uuid"`
+	Installments int          `json:"installments" binding:"omitempty,min=1,max=72"`
+}
+
+type statementPaymentRequest struct {
+	FromAccountID string       `json:"from_account_id" binding:"required,uuid"`
+	Amount        entity.Money `json:"amount" binding:"required,gt=0"`
+	Date          string       `json:"date" binding:"required"`
+}
+
+func (h *CreditCardHandler) List(c *gin.Context) {
+	cards, err := h.uc.List(c.Request.Context())
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, cards)
+}
+
+func (h *CreditCardHandler) GetByID(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		return
+	}
+
+	card, err := h.uc.GetByID(c.Request.Context(), id)
+	if err != nil {
+		status := mapDomainError(err)
+		c.JSON(status, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, card)
+}
+
+func (h *CreditCardHandler) Create(c *gin.Context) {
+	userID := middleware.GetUserID(c)
+	var req creditCardRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	card := &entity.CreditCard{
+		Name:        req.Name,
+		ClosingDay:  req.ClosingDay,
+		DueDay:      req.DueDay,
+		CreditLimit: req.CreditLimit,
+	}
+
+	if err := h.uc.Create(c.Request.Context(), card, userID); err != nil {
+		status := mapDomainError(err)
+		c.JSON(status, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, card)
+}
+
+func (h *CreditCardHandler) Update(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		return
+	}
+
+	var req creditCardRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	card, err := h.uc.Update(c.Request.Context(), &entity.CreditCard{
+		ID:          id,
+		Name:        req.Name,
+		ClosingDay:  req.ClosingDay,
+		DueDay:      req.DueDay,
+		CreditLimit: req.CreditLimit,
+	})
+	if err != nil {
+		status := mapDomainError(err)
+		c.JSON(status, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, card)
+}
+
+func (h *CreditCardHandler) Delete(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		return
+	}
+
+	if err := h.uc.Delete(c.Request.Context(), id); err != nil {
+		status := mapDomainError(err)
+		c.JSON(status, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusNoContent, nil)
+}
+
+func (h *CreditCardHandler) Purchase(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		return
+	}
+
+	userID := middleware.GetUserID(c)
+	var req purchaseRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	catID, _ := uuid.Parse(req.CategoryID)
+	tx := &entity.Transaction{
+		UserID:      userID,
+		CategoryID:  catID,
+		Amount:      req.Amount,
+		Description: req.Description,
+		Date:        req.Date,
+	}
+
+	txs, err := h.uc.Purchase(c.Request.Context(), id, tx, req.Installments)
+	if err != nil {
+		status := mapDomainError(err)
+		c.JSON(status, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, txs)
+}
+
+func (h *CreditCardHandler) ListStatements(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		return
+	}
+
+	statements, err := h.uc.ListStatements(c.Request.Context(), id)
+	if err != nil {
+		status := mapDomainError(err)
+		c.JSON(status, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, statements)
+}
+
+func (h *CreditCardHandler) GetStatement(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		return
+	}
+	statementID, err := uuid.Parse(c.Param("statementId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid statement id"})
+		return
+	}
+
+	st, err := h.uc.GetStatement(c.Request.Context(), id, statementID)
+	if err != nil {
+		status := mapDomainError(err)
+		c.JSON(status, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, st)
+}
+
+func (h *CreditCardHandler) PayStatement(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		return
+	}
+	statementID, err := uuid.Parse(c.Param("statementId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid statement id"})
+		return
+	}
+
+	userID := middleware.GetUserID(c)
+	var req statementPaymentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	fromID, _ := uuid.Parse(req.FromAccountID)
+	st, err := h.uc.PayStatement(c.Request.Context(), id, statementID, userID, fromID, req.Amount, req.Date)
+	if err != nil {
+		status := mapDomainError(err)
+		c.JSON(status, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, st)
+}
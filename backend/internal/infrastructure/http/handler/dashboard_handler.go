@@ -25,7 +25,7 @@ func (h *DashboardHandler) Summary(c *gin.Context) {
 
 	summary, err := h.uc.GetSummary(c.Request.Context(), month, year, userID)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		respondError(c, err)
 		return
 	}
 
@@ -39,7 +39,21 @@ func (h *DashboardHandler) ByCategory(c *gin.Context) {
 
 	data, err := h.uc.GetByCategory(c.Request.Context(), month, year, txType, userID)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, data)
+}
+
+func (h *DashboardHandler) ByTag(c *gin.Context) {
+	month, year := getMonthYear(c)
+	txType := c.DefaultQuery("type", "expense")
+	userID := getUserIDFilter(c)
+
+	data, err := h.uc.GetByTag(c.Request.Context(), month, year, txType, userID)
+	if err != nil {
+		respondError(c, err)
 		return
 	}
 
@@ -52,13 +66,46 @@ func (h *DashboardHandler) LimitsProgress(c *gin.Context) {
 
 	progress, err := h.uc.GetLimitsProgress(c.Request.Context(), month, year, userID)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, progress)
+}
+
+// GoalsProgress returns the savings goals with their progress. Goals belong to
+// the tenant, so the month and scope filters do not apply.
+func (h *DashboardHandler) GoalsProgress(c *gin.Context) {
+	progress, err := h.uc.GetGoalsProgress(c.Request.Context())
+	if err != nil {
+		respondError(c, err)
 		return
 	}
 
 	c.JSON(http.StatusOK, progress)
 }
 
+// Forecast projects the balance month by month. Query: months (1 to 24,
+// default 6) and include_average=true to also subtract the average
+// non-recurring spending over the same number of past months.
+func (h *DashboardHandler) Forecast(c *gin.Context) {
+	months, err := strconv.Atoi(c.DefaultQuery("months", "6"))
+	if err != nil || months < 1 || months > 24 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "months must be between 1 and 24"})
+		return
+	}
+	withAverage := c.Query("include_average") == "true"
+	userID := getUserIDFilter(c)
+
+	forecast, err := h.uc.GetForecast(c.Request.Context(), months, withAverage, userID)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, forecast)
+}
+
 func getUserIDFilter(c *gin.Context) *uuid.UUID {
 	scope := c.DefaultQuery("scope", "tenant")
 	if scope == "user" {
@@ -0,0 +1,176 @@
+package handler
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/dcunha/finance/backend/internal/domain/entity"
+	"github.com/dcunha/finance/backend/internal/domain/usecase"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type ExchangeRateHandler struct {
+	uc *usecase.ExchangeRateUsecase
+}
+
+func NewExchangeRateHandler(uc *usecase.ExchangeRateUsecase) *ExchangeRateHandler {
+	return &ExchangeRateHandler{uc: uc}
+}
+
+type exchangeRateRequest struct {
+	FromCurrency string  `json:"from_currency" binding:"required,iso4217"`
+	ToCurrency   string  `json:"to_currency" binding:"required,iso4217"`
+	Date         string  `json:"date" binding:"required,datetime=2006-01-02"`
+	Rate         float64 `json:"rate" binding:"required,gt=0"`
+}
+
+func (req exchangeRateRequest) toEntity() *entity.ExchangeRate {
+	return &entity.ExchangeRate{
+		FromCurrency: req.FromCurrency,
+		ToCurrency:   req.ToCurrency,
+		Date:         req.Date,
+		Rate:         req.Rate,
+	}
+}
+
+type syncRatesRequest struct {
+	Date string `json:"date"`
+}
+
+// List returns the stored rates, newest first. Filters: currency (either side
+// of the pair), start_date and end_date.
+func (h *ExchangeRateHandler) List(c *gin.Context) {
+	filter := entity.ExchangeRateFilter{
+		Currency:  strings.ToUpper(c.Query("currency")),
+		StartDate: c.Query("start_date"),
+		EndDate:   c.Query("end_date"),
+	}
+	for _, date := range []string{filter.StartDate, filter.EndDate} {
+		if date == "" {
+			continue
+		}
+		if _, err := time.Parse("2006-01-02", date); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "dates must be in YYYY-MM-DD format"})
+			return
+		}
+	}
+
+	rates, err := h.uc.List(c.Request.Context(), filter)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+
+	c.JSON(http.StatusOK, rates)
+}
+
+func (h *ExchangeRateHandler) GetByID(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		return
+	}
+
+	rate, err := h.uc.GetByID(c.Request.Context(), id)
+	if err != nil {
+		status := mapDomainError(err)
+		c.JSON(status, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, rate)
+}
+
+func (h *ExchangeRateHandler) Create(c *gin.Context) {
+	var req exchangeRateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	rate := req.toEntity()
+	if err := h.uc.Create(c.Request.Context(), rate); err != nil {
+		status := mapDomainError(err)
+		c.JSON(status, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, rate)
+}
+
+func (h *ExchangeRateHandler) Update(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		return
+	}
+
+	var req exchangeRateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	rate := req.toEntity()
+	rate.ID = id
+	if err := h.uc.Update(c.Request.Context(), rate); err != nil {
+		status := mapDomainError(err)
+		c.JSON(status, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, rate)
+}
+
+func (h *ExchangeRateHandler) Delete(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		return
+	}
+
+	if err := h.uc.Delete(c.Request.Context(), id); err != nil {
+		status := mapDomainError(err)
+		c.JSON(status, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusNoContent, nil)
+}
+
+// Sync fetches rates from the configured provider for the given date, today
+// by default, and returns the rates stored.
+func (h *ExchangeRateHandler) Sync(c *gin.Context) {
+	var req syncRatesRequest
+	// The body is optional.
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	date := time.Now()
+	if req.Date != "" {
+		var err error
+		if date, err = time.Parse("2006-01-02", req.Date); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "dates must be in YYYY-MM-DD format"})
+			return
+		}
+	}
+
+	rates, err := h.uc.Sync(c.Request.Context(), date)
+	if err != nil {
+		status := mapDomainError(err)
+		if status == http.StatusInternalServerError {
+			c.JSON(http.StatusBadGateway, gin.H{"error": "could not fetch exchange rates"})
+			return
+		}
+		c.JSON(status, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, rates)
+}
@@ -20,15 +20,19 @@ func NewExpenseLimitHandler(uc *usecase.ExpenseLimitUsecase) *ExpenseLimitHandle
 	return &ExpenseLimitHandler{uc: uc}
 }
 
+// AlertThresholds are percentages of the amount, e.g. [80, 100].
 type expenseLimitRequest struct {
-	CategoryID *string `json:"category_id"`
-	Month      int     `json:"month" binding:"required,min=1,max=12"`
-	Year       int     `json:"year" binding:"required,min=2000"`
-	Amount     float64 `json:"amount" binding:"required,gt=0"`
+	CategoryID      *string      `json:"category_id"`
+	Month           int          `json:"month" binding:"required,min=1,max=12"`
+	Year            int          `json:"year" binding:"required,min=2000"`
+	Amount          entity.Money `json:"amount" binding:"required,gt=0"`
+	AlertThresholds []int        `json:"alert_thresholds" binding:"omitempty,max=10,dive,min=1,max=1000"`
 }
 
+// updateLimitRequest keeps the current alert thresholds when they are omitted.
 type updateLimitRequest struct {
-	Amount float64 `json:"amount" binding:"required,gt=0"`
+	Amount          entity.Money `json:"amount" binding:"required,gt=0"`
+	AlertThresholds []int        `json:"alert_thresholds" binding:"omitempty,max=10,dive,min=1,max=1000"`
 }
 
 type copyLimitsRequest struct {
@@ -61,10 +65,11 @@ func (h *ExpenseLimitHandler) Create(c *gin.Context) {
 	}
 
 	limit := &entity.ExpenseLimit{
-		UserID: userID,
-		Month:  req.Month,
-		Year:   req.Year,
-		Amount: req.Amount,
+		UserID:          userID,
+		Month:           req.Month,
+		Year:            req.Year,
+		Amount:          req.Amount,
+		AlertThresholds: req.AlertThresholds,
 	}
 
 	if req.CategoryID != nil && *req.CategoryID != "" {
@@ -98,7 +103,7 @@ func (h *ExpenseLimitHandler) Update(c *gin.Context) {
 		return
 	}
 
-	if err := h.uc.Update(c.Request.Context(), id, req.Amount); err != nil {
+	if err := h.uc.Update(c.Request.Context(), id, req.Amount, req.AlertThresholds); err != nil {
 		status := mapDomainError(err)
 		c.JSON(status, gin.H{"error": err.Error()})
 		return
@@ -0,0 +1,78 @@
+package handler
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/dcunha/finance/backend/internal/domain/usecase"
+	"github.com/dcunha/finance/backend/internal/infrastructure/export"
+	"github.com/gin-gonic/gin"
+)
+
+type ExportHandler struct {
+	transactionUC *usecase.TransactionUsecase
+	accountUC     *usecase.AccountUsecase
+}
+
+func NewExportHandler(transactionUC *usecase.TransactionUsecase, accountUC *usecase.AccountUsecase) *ExportHandler {
+	return &ExportHandler{transactionUC: transactionUC, accountUC: accountUC}
+}
+
+// Export streams every transaction matching the listing filters as a file
+// download (?format=csv|xlsx|ofx, default csv).
+func (h *ExportHandler) Export(c *gin.Context) {
+	formatName := c.DefaultQuery("format", "csv")
+	format, ok := export.Formats[formatName]
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "format must be one of csv, xlsx, ofx"})
+		return
+	}
+
+	filter := transactionFilterFromQuery(c)
+	for _, date := range []string{filter.StartDate, filter.EndDate} {
+		if date == "" {
+			continue
+		}
+		if _, err := time.Parse("2006-01-02", date); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "dates must be in YYYY-MM-DD format"})
+			return
+		}
+	}
+
+	opts := export.Options{StartDate: filter.StartDate, EndDate: filter.EndDate}
+	if formatName == "ofx" {
+		accounts, err := h.accountUC.List(c.Request.Context())
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+			return
+		}
+		opts.Accounts = accounts
+	}
+
+	filename := fmt.Sprintf("transacoes-%s.%s", time.Now().Format("20060102"), format.Extension)
+	c.Header("Content-Type", format.ContentType)
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, filename))
+	c.Status(http.StatusOK)
+
+	w, err := export.NewWriter(formatName, c.Writer, opts)
+	if err == nil {
+		err = h.transactionUC.Export(c.Request.Context(), filter, w.Write)
+	}
+	if err == nil {
+		err = w.Close()
+	}
+	if err != nil {
+		// Once the body has started the status can no longer change; the
+		// client gets a truncated file and the error is only logged.
+		if !c.Writer.Written() {
+			c.Writer.Header().Del("Content-Type")
+			c.Writer.Header().Del("Content-Disposition")
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+			return
+		}
+		log.Printf("Export: %v", err)
+		c.Abort()
+	}
+}
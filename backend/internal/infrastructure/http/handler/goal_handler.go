@@ -0,0 +1,190 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/dcunha/finance/backend/internal/domain/entity"
+	"github.com/dcunha/finance/backend/internal/domain/usecase"
+	"github.com/dcunha/finance/backend/internal/infrastructure/http/middleware"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type GoalHandler struct {
+	uc *usecase.GoalUsecase
+}
+
+func NewGoalHandler(uc *usecase.GoalUsecase) *GoalHandler {
+	return &GoalHandler{uc: uc}
+}
+
+type goalRequest struct {
+	Name         string       `json:"name" binding:"required,max=100"`
+	TargetAmount entity.Money `json:"target_amount" binding:"required,gt=0"`
+	Deadline     string       `json:"deadline" binding:"required,datetime=2006-01-02"`
+}
+
+// contributionRequest names either a transaction or a transfer.
+type contributionRequest struct {
+	TransactionID string `json:"transaction_id" binding:"required_without=TransferID,excluded_with=TransferID,omitempty,uuid"`
+	TransferID    string `json:"transfer_id" binding:"omitempty,uuid"`
+}
+
+func (h *GoalHandler) List(c *gin.Context) {
+	goals, err := h.uc.List(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+
+	c.JSON(http.StatusOK, goals)
+}
+
+func (h *GoalHandler) GetByID(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		return
+	}
+
+	goal, err := h.uc.GetByID(c.Request.Context(), id)
+	if err != nil {
+		status := mapDomainError(err)
+		c.JSON(status, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, goal)
+}
+
+func (h *GoalHandler) Create(c *gin.Context) {
+	userID := middleware.GetUserID(c)
+	var req goalRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	goal := &entity.Goal{
+		UserID:       &userID,
+		Name:         req.Name,
+		TargetAmount: req.TargetAmount,
+		Deadline:     req.Deadline,
+	}
+	if err := h.uc.Create(c.Request.Context(), goal); err != nil {
+		status := mapDomainError(err)
+		c.JSON(status, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, goal)
+}
+
+func (h *GoalHandler) Update(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		return
+	}
+
+	var req goalRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	goal, err := h.uc.Update(c.Request.Context(), id, req.Name, req.TargetAmount, req.Deadline)
+	if err != nil {
+		status := mapDomainError(err)
+		c.JSON(status, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, goal)
+}
+
+func (h *GoalHandler) Delete(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		return
+	}
+
+	if err := h.uc.Delete(c.Request.Context(), id); err != nil {
+		status := mapDomainError(err)
+		c.JSON(status, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusNoContent, nil)
+}
+
+func (h *GoalHandler) ListContributions(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		return
+	}
+
+	contributions, err := h.uc.ListContributions(c.Request.Context(), id)
+	if err != nil {
+		status := mapDomainError(err)
+		c.JSON(status, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, contributions)
+}
+
+func (h *GoalHandler) AddContribution(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		return
+	}
+
+	var req contributionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	var transactionID, transferID *uuid.UUID
+	if req.TransactionID != "" {
+		parsed, _ := uuid.Parse(req.TransactionID)
+		transactionID = &parsed
+	} else {
+		parsed, _ := uuid.Parse(req.TransferID)
+		transferID = &parsed
+	}
+
+	contribution, err := h.uc.AddContribution(c.Request.Context(), id, transactionID, transferID)
+	if err != nil {
+		status := mapDomainError(err)
+		c.JSON(status, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, contribution)
+}
+
+func (h *GoalHandler) RemoveContribution(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		return
+	}
+	transactionID, err := uuid.Parse(c.Param("transactionId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid transaction id"})
+		return
+	}
+
+	if err := h.uc.RemoveContribution(c.Request.Context(), id, transactionID); err != nil {
+		status := mapDomainError(err)
+		c.JSON(status, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusNoContent, nil)
+}
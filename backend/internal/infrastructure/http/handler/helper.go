@@ -5,6 +5,7 @@ import (
 	"net/http"
 
 	"github.com/dcunha/finance/backend/internal/domain"
+	"github.com/gin-gonic/gin"
 )
 
 func mapDomainError(err error) int {
@@ -17,6 +18,64 @@ func mapDomainError(err error) int {
 		return http.StatusConflict
 	case errors.Is(err, domain.ErrDuplicateCategory):
 		return http.StatusConflict
+	case errors.Is(err, domain.ErrDuplicateAccount):
+		return http.StatusConflict
+	case errors.Is(err, domain.ErrAccountInUse):
+		return http.StatusConflict
+	case errors.Is(err, domain.ErrSameAccount):
+		return http.StatusBadRequest
+	case errors.Is(err, domain.ErrTransferLeg):
+		return http.StatusConflict
+	case errors.Is(err, domain.ErrMissingCategory):
+		return http.StatusBadRequest
+	case errors.Is(err, domain.ErrInvalidImportRow):
+		return http.StatusBadRequest
+	case errors.Is(err, domain.ErrDuplicateProfile):
+		return http.StatusConflict
+	case errors.Is(err, domain.ErrInvalidRule):
+		return http.StatusBadRequest
+	case errors.Is(err, domain.ErrDuplicateTag):
+		return http.StatusConflict
+	case errors.Is(err, domain.ErrUnknownTag):
+		return http.StatusBadRequest
+	case errors.Is(err, domain.ErrAttachmentTooLarge):
+		return http.StatusRequestEntityTooLarge
+	case errors.Is(err, domain.ErrAttachmentType):
+		return http.StatusUnsupportedMediaType
+	case errors.Is(err, domain.ErrInvalidSplit):
+		return http.StatusBadRequest
+	case errors.Is(err, domain.ErrCurrencyMismatch):
+		return http.StatusBadRequest
+	case errors.Is(err, domain.ErrDuplicateRate):
+		return http.StatusConflict
+	case errors.Is(err, domain.ErrSameCurrency):
+		return http.StatusBadRequest
+	case errors.Is(err, domain.ErrNoRateProvider):
+		return http.StatusServiceUnavailable
+	case errors.Is(err, domain.ErrMissingRate):
+		return http.StatusUnprocessableEntity
+	case errors.Is(err, domain.ErrDuplicateGoal):
+		return http.StatusConflict
+	case errors.Is(err, domain.ErrContributionExists):
+		return http.StatusConflict
+	case errors.Is(err, domain.ErrDuplicateBudget):
+		return http.StatusConflict
+	case errors.Is(err, domain.ErrInvalidBudget):
+		return http.StatusBadRequest
+	case errors.Is(err, domain.ErrResetTokenExpired):
+		return http.StatusBadRequest
+	case errors.Is(err, domain.ErrSessionRevoked):
+		return http.StatusUnauthorized
+	case errors.Is(err, domain.ErrInvalidTOTPCode):
+		return http.StatusUnauthorized
+	case errors.Is(err, domain.ErrTwoFactorRequired):
+		return http.StatusForbidden
+	case errors.Is(err, domain.ErrTwoFactorEnabled):
+		return http.StatusConflict
+	case errors.Is(err, domain.ErrTwoFactorDisabled):
+		return http.StatusBadRequest
+	case errors.Is(err, domain.ErrTooManyAttempts):
+		return http.StatusTooManyRequests
 	case errors.Is(err, domain.ErrDuplicateEmail):
 		return http.StatusConflict
 	case errors.Is(err, domain.ErrDuplicateLimit):
@@ -39,7 +98,26 @@ func mapDomainError(err error) int {
 		return http.StatusConflict
 	case errors.Is(err, domain.ErrInvalidFrequency):
 		return http.StatusBadRequest
+	case errors.Is(err, domain.ErrInvalidScope):
+		return http.StatusBadRequest
+	case errors.Is(err, domain.ErrInvalidOccurrence):
+		return http.StatusBadRequest
+	case errors.Is(err, domain.ErrInstallmentTotal):
+		return http.StatusBadRequest
+	case errors.Is(err, domain.ErrRecurringPaused):
+		return http.StatusConflict
 	default:
 		return http.StatusInternalServerError
 	}
 }
+
+// respondError sends err with its domain status. Unexpected errors are
+// reported as a generic 500 without their details.
+func respondError(c *gin.Context, err error) {
+	status := mapDomainError(err)
+	if status == http.StatusInternalServerError {
+		c.JSON(status, gin.H{"error": "internal server error"})
+		return
+	}
+	c.JSON(status, gin.H{"error": err.Error()})
+}
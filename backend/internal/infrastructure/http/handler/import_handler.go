@@ -0,0 +1,246 @@
+package handler
+
+import (
+	"errors"
+	"mime/multipart"
+	"net/http"
+	"time"
+
+	"github.com/dcunha/finance/backend/internal/domain/entity"
+	"github.com/dcunha/finance/backend/internal/domain/usecase"
+	"github.com/dcunha/finance/backend/internal/infrastructure/csvimport"
+	"github.com/dcunha/finance/backend/internal/infrastructure/http/middleware"
+	"github.com/dcunha/finance/backend/internal/infrastructure/ofx"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// maxImportFileSize caps uploaded statement files; a year of bank history is
+// well under this.
+const maxImportFileSize = 5 << 20
+
+type ImportHandler struct {
+	uc        *usecase.ImportUsecase
+	profileUC *usecase.ImportProfileUsecase
+}
+
+func NewImportHandler(uc *usecase.ImportUsecase, profileUC *usecase.ImportProfileUsecase) *ImportHandler {
+	return &ImportHandler{uc: uc, profileUC: profileUC}
+}
+
+type importCommitRequest struct {
+	AccountID    string                   `json:"account_id" binding:"omitempty,uuid"`
+	Transactions []importTransactionInput `json:"transactions" binding:"required,min=1,dive"`
+}
+
+type importTransactionInput struct {
+	ExternalID  string       `json:"external_id"`
+	Type        string       `json:"type" binding:"required,oneof=income expense"`
+	Amount      entity.Money `json:"amount" binding:"required,gt=0"`
+	Description string       `json:"description"`
+	Date        string       `json:"date" binding:"required"`
+	CategoryID  string       `json:"category_id" binding:"omitempty,uuid"`
+}
+
+// importForm holds the multipart fields shared by the preview endpoints.
+type importForm struct {
+	accountID         *uuid.UUID
+	incomeCategoryID  *uuid.UUID
+	expenseCategoryID *uuid.UUID
+	file              multipart.File
+}
+
+// readImportForm limits the request size and reads the uploaded file and
+// optional ids, writing an error response and returning false on failure. The
+// caller must close the file.
+func readImportForm(c *gin.Context) (*importForm, bool) {
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxImportFileSize)
+
+	form := &importForm{}
+	var ok bool
+	if form.accountID, ok = parseOptionalUUID(c, c.PostForm("account_id"), "invalid account_id"); !ok {
+		return nil, false
+	}
+	if form.incomeCategoryID, ok = parseOptionalUUID(c, c.PostForm("income_category_id"), "invalid income_category_id"); !ok {
+		return nil, false
+	}
+	if form.expenseCategoryID, ok = parseOptionalUUID(c, c.PostForm("expense_category_id"), "invalid expense_category_id"); !ok {
+		return nil, false
+	}
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		var maxErr *http.MaxBytesError
+		if errors.As(err, &maxErr) {
+			c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": "file too large"})
+			return nil, false
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": "file is required"})
+		return nil, false
+	}
+	form.file, err = fileHeader.Open()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "could not read file"})
+		return nil, false
+	}
+	return form, true
+}
+
+// candidate builds an import row from a signed amount: negative amounts are
+// expenses. It returns false for zero amounts, which are skipped.
+func (f *importForm) candidate(externalID string, date time.Time, amount entity.Money, description string) (entity.ImportCandidate, bool) {
+	if amount == 0 {
+		return entity.ImportCandidate{}, false
+	}
+	candidate := entity.ImportCandidate{
+		ExternalID:  externalID,
+		Type:        "income",
+		Amount:      amount.Abs(),
+		Description: description,
+		Date:        date.Format("2006-01-02"),
+		CategoryID:  f.incomeCategoryID,
+	}
+	if amount < 0 {
+		candidate.Type = "expense"
+		candidate.CategoryID = f.expenseCategoryID
+	}
+	return candidate, true
+}
+
+// PreviewOFX parses an uploaded OFX file and returns its transactions flagged
+// against what is already stored. Optional default categories per type are
+// pre-filled so the client can commit without editing every row.
+func (h *ImportHandler) PreviewOFX(c *gin.Context) {
+	form, ok := readImportForm(c)
+	if !ok {
+		return
+	}
+	defer form.file.Close()
+
+	stmt, err := ofx.Parse(form.file)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	candidates := make([]entity.ImportCandidate, 0, len(stmt.Transactions))
+	for _, t := range stmt.Transactions {
+		if candidate, ok := form.candidate(t.FITID, t.Date, t.Amount, t.Description()); ok {
+			candidates = append(candidates, candidate)
+		}
+	}
+
+	h.preview(c, derefUUID(form.accountID), candidates)
+}
+
+// PreviewCSV parses an uploaded CSV file with a saved import profile. This is
+// a dry run: nothing is stored until the rows are sent to Commit. Default
+// categories come from the form, falling back to the profile's.
+func (h *ImportHandler) PreviewCSV(c *gin.Context) {
+	form, ok := readImportForm(c)
+	if !ok {
+		return
+	}
+	defer form.file.Close()
+
+	profileID, err := uuid.Parse(c.PostForm("profile_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid profile_id"})
+		return
+	}
+	profile, err := h.profileUC.GetByID(c.Request.Context(), profileID)
+	if err != nil {
+		status := mapDomainError(err)
+		c.JSON(status, gin.H{"error": err.Error()})
+		return
+	}
+	if form.incomeCategoryID == nil {
+		form.incomeCategoryID = profile.DefaultIncomeCategoryID
+	}
+	if form.expenseCategoryID == nil {
+		form.expenseCategoryID = profile.DefaultExpenseCategoryID
+	}
+
+	rows, err := csvimport.Parse(form.file, profile)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	candidates := make([]entity.ImportCandidate, 0, len(rows))
+	for _, r := range rows {
+		if candidate, ok := form.candidate(r.ExternalID, r.Date, r.Amount, r.Description); ok {
+			candidates = append(candidates, candidate)
+		}
+	}
+
+	h.preview(c, derefUUID(form.accountID), candidates)
+}
+
+func (h *ImportHandler) preview(c *gin.Context, accountID uuid.UUID, candidates []entity.ImportCandidate) {
+	preview, err := h.uc.Preview(c.Request.Context(), accountID, candidates)
+	if err != nil {
+		status := mapDomainError(err)
+		c.JSON(status, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, preview)
+}
+
+// Commit stores the transactions confirmed by the client after a preview.
+func (h *ImportHandler) Commit(c *gin.Context) {
+	userID := middleware.GetUserID(c)
+	var req importCommitRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	accountID, _ := uuid.Parse(req.AccountID)
+
+	candidates := make([]entity.ImportCandidate, len(req.Transactions))
+	for i, t := range req.Transactions {
+		candidates[i] = entity.ImportCandidate{
+			ExternalID:  t.ExternalID,
+			Type:        t.Type,
+			Amount:      t.Amount,
+			Description: t.Description,
+			Date:        t.Date,
+		}
+		if t.CategoryID != "" {
+			categoryID, _ := uuid.Parse(t.CategoryID)
+			candidates[i].CategoryID = &categoryID
+		}
+	}
+
+	result, err := h.uc.Commit(c.Request.Context(), userID, accountID, candidates)
+	if err != nil {
+		status := mapDomainError(err)
+		c.JSON(status, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, result)
+}
+
+// parseOptionalUUID parses an optional form value, writing a 400 response and
+// returning false when it is present but malformed.
+func parseOptionalUUID(c *gin.Context, value, message string) (*uuid.UUID, bool) {
+	if value == "" {
+		return nil, true
+	}
+	id, err := uuid.Parse(value)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": message})
+		return nil, false
+	}
+	return &id, true
+}
+
+func derefUUID(id *uuid.UUID) uuid.UUID {
+	if id == nil {
+		return uuid.Nil
+	}
+	return *id
+}
@@ -0,0 +1,160 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/dcunha/finance/backend/internal/domain/entity"
+	"github.com/dcunha/finance/backend/internal/domain/usecase"
+	"github.com/dcunha/finance/backend/internal/infrastructure/http/middleware"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type ImportProfileHandler struct {
+	uc *usecase.ImportProfileUsecase
+}
+
+func NewImportProfileHandler(uc *usecase.ImportProfileUsecase) *ImportProfileHandler {
+	return &ImportProfileHandler{uc: uc}
+}
+
+type importProfileRequest struct {
+	Name                     string `json:"name" binding:"required,max=100"`
+	Delimiter                string `json:"delimiter" binding:"omitempty,len=1"`
+	HasHeader                *bool  `json:"has_header"`
+	SkipRows                 int    `json:"skip_rows" binding:"min=0"`
+	DateColumn               string `json:"date_column" binding:"required,max=100"`
+	DescriptionColumn        string `json:"description_column" binding:"required,max=100"`
+	AmountColumn             string `json:"amount_column" binding:"required,max=100"`
+	ExternalIDColumn         string `json:"external_id_column" binding:"max=100"`
+	DateFormat               string `json:"date_format" binding:"omitempty,oneof=dd/mm/yyyy mm/dd/yyyy yyyy-mm-dd dd-mm-yyyy dd.mm.yyyy"`
+	DecimalSeparator         string `json:"decimal_separator" binding:"omitempty,oneof=0x2C ."`
+	NegateAmounts            bool   `json:"negate_amounts"`
+	DefaultIncomeCategoryID  string `json:"default_income_category_id" binding:"omitempty,uuid"`
+	DefaultExpenseCategoryID string `json:"default_expense_category_id" binding:"omitempty,uuid"`
+}
+
+// toEntity fills in the defaults for a Brazilian bank export: ";" delimiter,
+// a header row, dd/mm/yyyy dates and a decimal comma.
+func (req importProfileRequest) toEntity() *entity.ImportProfile {
+	profile := &entity.ImportProfile{
+		Name:              req.Name,
+		Delimiter:         req.Delimiter,
+		HasHeader:         req.HasHeader == nil || *req.HasHeader,
+		SkipRows:          req.SkipRows,
+		DateColumn:        req.DateColumn,
+		DescriptionColumn: req.DescriptionColumn,
+		AmountColumn:      req.AmountColumn,
+		DateFormat:        req.DateFormat,
+		DecimalSeparator:  req.DecimalSeparator,
+		NegateAmounts:     req.NegateAmounts,
+	}
+	if profile.Delimiter == "" {
+		profile.Delimiter = ";"
+	}
+	if profile.DateFormat == "" {
+		profile.DateFormat = "dd/mm/yyyy"
+	}
+	if profile.DecimalSeparator == "" {
+		profile.DecimalSeparator = ","
+	}
+	if req.ExternalIDColumn != "" {
+		column := req.ExternalIDColumn
+		profile.ExternalIDColumn = &column
+	}
+	if req.DefaultIncomeCategoryID != "" {
+		id, _ := uuid.Parse(req.DefaultIncomeCategoryID)
+		profile.DefaultIncomeCategoryID = &id
+	}
+	if req.DefaultExpenseCategoryID != "" {
+		id, _ := uuid.Parse(req.DefaultExpenseCategoryID)
+		profile.DefaultExpenseCategoryID = &id
+	}
+	return profile
+}
+
+func (h *ImportProfileHandler) List(c *gin.Context) {
+	profiles, err := h.uc.List(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+
+	c.JSON(http.StatusOK, profiles)
+}
+
+func (h *ImportProfileHandler) GetByID(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		return
+	}
+
+	profile, err := h.uc.GetByID(c.Request.Context(), id)
+	if err != nil {
+		status := mapDomainError(err)
+		c.JSON(status, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, profile)
+}
+
+func (h *ImportProfileHandler) Create(c *gin.Context) {
+	userID := middleware.GetUserID(c)
+	var req importProfileRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	profile := req.toEntity()
+	profile.UserID = &userID
+
+	if err := h.uc.Create(c.Request.Context(), profile); err != nil {
+		status := mapDomainError(err)
+		c.JSON(status, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, profile)
+}
+
+func (h *ImportProfileHandler) Update(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		return
+	}
+
+	var req importProfileRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	profile, err := h.uc.Update(c.Request.Context(), id, req.toEntity())
+	if err != nil {
+		status := mapDomainError(err)
+		c.JSON(status, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, profile)
+}
+
+func (h *ImportProfileHandler) Delete(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		return
+	}
+
+	if err := h.uc.Delete(c.Request.Context(), id); err != nil {
+		status := mapDomainError(err)
+		c.JSON(status, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusNoContent, nil)
+}
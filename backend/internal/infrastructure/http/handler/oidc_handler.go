@@ -0,0 +1,127 @@
+package handler
+
+import (
+	"crypto/subtle"
+	"errors"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/dcunha/finance/backend/internal/domain"
+	"github.com/dcunha/finance/backend/internal/domain/usecase"
+	"github.com/dcunha/finance/backend/internal/infrastructure/oidc"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// oidcFlowCookie carries the flow's state, nonce and PKCE verifier from
+	// the redirect to the callback. It is only sent to the OIDC routes.
+	oidcFlowCookie     = "oidc_flow"
+	oidcFlowCookiePath = "/api/v1/auth/oidc"
+	oidcFlowMaxAge     = 10 * 60
+)
+
+// OIDCHandler runs the browser side of signing in with an OpenID Connect
+// provider (Google). The callback ends by redirecting to the frontend's login
+// page with either #oidc_token=..., which the frontend sends to
+// POST /auth/oidc/complete, or #oidc_error=<reason>. The fragment keeps the
+// token out of server logs.
+type OIDCHandler struct {
+	uc       *usecase.AuthUsecase
+	provider *oidc.Provider // nil when no provider is configured
+	appURL   string
+}
+
+func NewOIDCHandler(uc *usecase.AuthUsecase, provider *oidc.Provider, appURL string) *OIDCHandler {
+	return &OIDCHandler{uc: uc, provider: provider, appURL: strings.TrimSuffix(appURL, "/")}
+}
+
+// Config tells the frontend whether to offer the provider's button.
+func (h *OIDCHandler) Config(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{"enabled": h.provider != nil})
+}
+
+// Login starts the flow: it stores a new flow in a cookie and redirects to
+// the provider.
+func (h *OIDCHandler) Login(c *gin.Context) {
+	if h.provider == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "login com Google não está configurado"})
+		return
+	}
+
+	flow, err := oidc.NewFlow()
+	if err != nil {
+		h.redirectError(c, "failed")
+		return
+	}
+	authURL, err := h.provider.AuthCodeURL(c.Request.Context(), flow)
+	if err != nil {
+		log.Printf("OIDC: %v", err)
+		h.redirectError(c, "unavailable")
+		return
+	}
+
+	h.setFlowCookie(c, flow.Encode(), oidcFlowMaxAge)
+	c.Redirect(http.StatusFound, authURL)
+}
+
+// Callback is where the provider sends the browser back with a code.
+func (h *OIDCHandler) Callback(c *gin.Context) {
+	if h.provider == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "login com Google não está configurado"})
+		return
+	}
+
+	cookie, _ := c.Cookie(oidcFlowCookie)
+	h.setFlowCookie(c, "", -1)
+
+	if c.Query("error") != "" {
+		// The user cancelled at the provider, or the provider refused.
+		h.redirectError(c, "denied")
+		return
+	}
+	flow, err := oidc.ParseFlow(cookie)
+	state := c.Query("state")
+	if err != nil || state == "" || subtle.ConstantTimeCompare([]byte(state), []byte(flow.State)) != 1 {
+		h.redirectError(c, "expired")
+		return
+	}
+
+	claims, err := h.provider.Exchange(c.Request.Context(), c.Query("code"), flow)
+	if err != nil {
+		log.Printf("OIDC: %v", err)
+		h.redirectError(c, "failed")
+		return
+	}
+
+	token, err := h.uc.AuthenticateOIDC(c.Request.Context(), usecase.OIDCProfile{
+		Issuer:        claims.Issuer,
+		Subject:       claims.Subject,
+		Email:         claims.Email,
+		EmailVerified: claims.EmailVerified,
+		Name:          claims.Name,
+	})
+	if err != nil {
+		if errors.Is(err, domain.ErrEmailNotVerified) {
+			h.redirectError(c, "email_not_verified")
+			return
+		}
+		log.Printf("OIDC: %v", err)
+		h.redirectError(c, "failed")
+		return
+	}
+
+	c.Redirect(http.StatusFound, h.appURL+"/login#oidc_token="+url.QueryEscape(token))
+}
+
+func (h *OIDCHandler) redirectError(c *gin.Context, reason string) {
+	c.Redirect(http.StatusFound, h.appURL+"/login#oidc_error="+reason)
+}
+
+func (h *OIDCHandler) setFlowCookie(c *gin.Context, value string, maxAge int) {
+	// Lax, not Strict: the callback is a top-level navigation coming from
+	// the provider's site.
+	c.SetSameSite(http.SameSiteLaxMode)
+	c.SetCookie(oidcFlowCookie, value, maxAge, oidcFlowCookiePath, "", strings.HasPrefix(h.appURL, "https://"), true)
+}
@@ -21,15 +21,29 @@ func NewRecurringTransactionHandler(uc *usecase.RecurringTransactionUsecase) *Re
 }
 
 type recurringTransactionRequest struct {
-	Type           string  `json:"type" binding:"required,oneof=income expense"`
-	Amount         float64 `json:"amount" binding:"required,gt=0"`
-	Description    string  `json:"description"`
-	CategoryID     string  `json:"category_id" binding:"required,uuid"`
-	Frequency      string  `json:"frequency" binding:"required,oneof=weekly biweekly monthly yearly"`
-	StartDate      string  `json:"start_date" binding:"required"`
-	EndDate        *string `json:"end_date"`
-	MaxOccurrences *int    `json:"max_occurrences"`
-	DayOfMonth     *int    `json:"day_of_month"`
+	Type           string       `json:"type" binding:"required,oneof=income expense"`
+	Amount         entity.Money `json:"amount" binding:"required,gt=0"`
+	Description    string       `json:"description"`
+	CategoryID     string       `json:"category_id" binding:"required,uuid"`
+	AccountID      string       `json:"account_id" binding:"omitempty,uuid"`
+	Frequency      string       `json:"frequency" binding:"required,oneof=weekly biweekly monthly yearly"`
+	StartDate      string       `json:"start_date" binding:"required"`
+	EndDate        *string      `json:"end_date"`
+	MaxOccurrences *int         `json:"max_occurrences"`
+	DayOfMonth     *int         `json:"day_of_month"`
+	TagIDs         []string     `json:"tag_ids" binding:"omitempty,dive,uuid"`
+}
+
+type updateRecurringRequest struct {
+	Scope          string        `json:"scope" binding:"required,oneof=this this_and_future all"`
+	OccurrenceDate string        `json:"occurrence_date" binding:"required_unless=Scope all"`
+	Amount         *entity.Money `json:"amount" binding:"omitempty,gt=0"`
+	Description    *string       `json:"description"`
+	CategoryID     *string       `json:"category_id" binding:"omitempty,uuid"`
+	AccountID      *string       `json:"account_id" binding:"omitempty,uuid"`
+	Frequency      *string       `json:"frequency" binding:"omitempty,oneof=weekly biweekly monthly yearly"`
+	DayOfMonth     *int          `json:"day_of_month" binding:"omitempty,min=1,max=31"`
+	TagIDs         []string      `json:"tag_ids" binding:"omitempty,dive,uuid"`
 }
 
 type resumeRequest struct {
@@ -72,9 +86,11 @@ func (h *RecurringTransactionHandler) Create(c *gin.Context) {
 	}
 
 	catID, _ := uuid.Parse(req.CategoryID)
+	accountID, _ := uuid.Parse(req.AccountID)
 	rt := &entity.RecurringTransaction{
 		UserID:         userID,
 		CategoryID:     catID,
+		AccountID:      accountID,
 		Type:           req.Type,
 		Amount:         req.Amount,
 		Description:    req.Description,
@@ -83,6 +99,7 @@ func (h *RecurringTransactionHandler) Create(c *gin.Context) {
 		EndDate:        req.EndDate,
 		MaxOccurrences: req.MaxOccurrences,
 		DayOfMonth:     req.DayOfMonth,
+		TagIDs:         parseTagIDs(req.TagIDs),
 	}
 
 	if err := h.uc.Create(c.Request.Context(), rt); err != nil {
@@ -94,6 +111,47 @@ func (h *RecurringTransactionHandler) Create(c *gin.Context) {
 	c.JSON(http.StatusCreated, rt)
 }
 
+func (h *RecurringTransactionHandler) Update(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		return
+	}
+
+	var req updateRecurringRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	upd := entity.RecurringTransactionUpdate{
+		Scope:          entity.UpdateScope(req.Scope),
+		OccurrenceDate: req.OccurrenceDate,
+		Amount:         req.Amount,
+		Description:    req.Description,
+		Frequency:      req.Frequency,
+		DayOfMonth:     req.DayOfMonth,
+		TagIDs:         parseTagIDs(req.TagIDs),
+	}
+	if req.CategoryID != nil {
+		catID, _ := uuid.Parse(*req.CategoryID)
+		upd.CategoryID = &catID
+	}
+	if req.AccountID != nil {
+		accountID, _ := uuid.Parse(*req.AccountID)
+		upd.AccountID = &accountID
+	}
+
+	rt, err := h.uc.Update(c.Request.Context(), id, upd)
+	if err != nil {
+		status := mapDomainError(err)
+		c.JSON(status, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, rt)
+}
+
 func (h *RecurringTransactionHandler) Delete(c *gin.Context) {
 	id, err := uuid.Parse(c.Param("id"))
 	if err != nil {
@@ -28,6 +28,15 @@ type verifyEmailRequest struct {
 	Token string `json:"token" binding:"required"`
 }
 
+type forgotPasswordRequest struct {
+	Email string `json:"email" binding:"required,email"`
+}
+
+type resetForgottenPasswordRequest struct {
+	Token    string `json:"token" binding:"required"`
+	Password string `json:"password" binding:"required,min=6"`
+}
+
 func (h *RegistrationHandler) Register(c *gin.Context) {
 	var req registerRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
@@ -80,3 +89,44 @@ func (h *RegistrationHandler) VerifyEmail(c *gin.Context) {
 
 	c.JSON(http.StatusOK, gin.H{"message": "Email verificado com sucesso!"})
 }
+
+// ForgotPassword always answers the same way, whether or not the email is
+// registered.
+func (h *RegistrationHandler) ForgotPassword(c *gin.Context) {
+	var req forgotPasswordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.uc.ForgotPassword(c.Request.Context(), req.Email); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "erro ao solicitar redefinição de senha"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Se o email estiver cadastrado, você receberá um link para redefinir a senha."})
+}
+
+func (h *RegistrationHandler) ResetPassword(c *gin.Context) {
+	var req resetForgottenPasswordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	err := h.uc.ResetPassword(c.Request.Context(), req.Token, req.Password)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "token inválido"})
+			return
+		}
+		if errors.Is(err, domain.ErrResetTokenExpired) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "link de redefinição expirado, peça um novo"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "erro ao redefinir senha"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Senha redefinida com sucesso!"})
+}
@@ -0,0 +1,118 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/dcunha/finance/backend/internal/domain/usecase"
+	"github.com/gin-gonic/gin"
+)
+
+// maxReportMonths bounds the range of a report.
+const maxReportMonths = 120
+
+type ReportHandler struct {
+	uc *usecase.ReportUsecase
+}
+
+func NewReportHandler(uc *usecase.ReportUsecase) *ReportHandler {
+	return &ReportHandler{uc: uc}
+}
+
+// Monthly returns income, expenses, net and balance per month. Query: start
+// and end as YYYY-MM (default: the last 12 months up to the current one) and
+// scope, as in the dashboard.
+func (h *ReportHandler) Monthly(c *gin.Context) {
+	start, end, ok := getMonthRange(c)
+	if !ok {
+		return
+	}
+	userID := getUserIDFilter(c)
+
+	totals, err := h.uc.Monthly(c.Request.Context(), start, end, userID)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, totals)
+}
+
+// ByCategory returns monthly totals per category for the range. Query as in
+// Monthly, plus type (default expense).
+func (h *ReportHandler) ByCategory(c *gin.Context) {
+	start, end, ok := getMonthRange(c)
+	if !ok {
+		return
+	}
+	txType := c.DefaultQuery("type", "expense")
+	if txType != "income" && txType != "expense" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "type must be income or expense"})
+		return
+	}
+	userID := getUserIDFilter(c)
+
+	series, err := h.uc.ByCategory(c.Request.Context(), start, end, txType, userID)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, series)
+}
+
+// YearOverYear compares a year with another, month by month. Query: year
+// (default current) and compare_year (default the year before).
+func (h *ReportHandler) YearOverYear(c *gin.Context) {
+	year, err := strconv.Atoi(c.DefaultQuery("year", strconv.Itoa(time.Now().Year())))
+	if err != nil || year < 2000 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid year"})
+		return
+	}
+	compareYear, err := strconv.Atoi(c.DefaultQuery("compare_year", strconv.Itoa(year-1)))
+	if err != nil || compareYear < 2000 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid compare_year"})
+		return
+	}
+	userID := getUserIDFilter(c)
+
+	yoy, err := h.uc.YearOverYear(c.Request.Context(), year, compareYear, userID)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, yoy)
+}
+
+// getMonthRange reads start and end (YYYY-MM) and writes a 400 response when
+// they are invalid.
+func getMonthRange(c *gin.Context) (time.Time, time.Time, bool) {
+	now := time.Now()
+	end := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	if v := c.Query("end"); v != "" {
+		parsed, err := time.Parse("2006-01", v)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "start and end must be in YYYY-MM format"})
+			return time.Time{}, time.Time{}, false
+		}
+		end = parsed
+	}
+	start := end.AddDate(0, -11, 0)
+	if v := c.Query("start"); v != "" {
+		parsed, err := time.Parse("2006-01", v)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "start and end must be in YYYY-MM format"})
+			return time.Time{}, time.Time{}, false
+		}
+		start = parsed
+	}
+
+	months := (end.Year()-start.Year())*12 + int(end.Month()-start.Month()) + 1
+	if months < 1 || months > maxReportMonths {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "start must not be after end and the range is limited to 120 months"})
+		return time.Time{}, time.Time{}, false
+	}
+	return start, end, true
+}
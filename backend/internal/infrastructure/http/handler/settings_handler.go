@@ -0,0 +1,47 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/dcunha/finance/backend/internal/domain/usecase"
+	"github.com/gin-gonic/gin"
+)
+
+type SettingsHandler struct {
+	uc *usecase.SettingsUsecase
+}
+
+func NewSettingsHandler(uc *usecase.SettingsUsecase) *SettingsHandler {
+	return &SettingsHandler{uc: uc}
+}
+
+type settingsRequest struct {
+	BaseCurrency string `json:"base_currency" binding:"required,iso4217"`
+}
+
+func (h *SettingsHandler) Get(c *gin.Context) {
+	settings, err := h.uc.Get(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+
+	c.JSON(http.StatusOK, settings)
+}
+
+func (h *SettingsHandler) Update(c *gin.Context) {
+	var req settingsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	settings, err := h.uc.Update(c.Request.Context(), req.BaseCurrency)
+	if err != nil {
+		status := mapDomainError(err)
+		c.JSON(status, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, settings)
+}
@@ -3,6 +3,7 @@ package handler
 import (
 	"net/http"
 	"strconv"
+	"strings"
 
 	"github.com/dcunha/finance/backend/internal/domain/entity"
 	"github.com/dcunha/finance/backend/internal/domain/usecase"
@@ -20,37 +21,118 @@ func NewTransactionHandler(uc *usecase.TransactionUsecase) *TransactionHandler {
 }
 
 type transactionRequest struct {
-	Type        string  `json:"type" binding:"required,oneof=income expense"`
-	Amount      float64 `json:"amount" binding:"required,gt=0"`
-	Description string  `json:"description"`
-	Date        string  `json:"date" binding:"required"`
-	CategoryID  string  `json:"category_id" binding:"required,uuid"`
+	Type        string             `json:"type" binding:"required,oneof=income expense"`
+	Amount      entity.Money       `json:"amount" binding:"required,gt=0"`
+	Currency    string             `json:"currency" binding:"omitempty,iso4217"`
+	Description string             `json:"description"`
+	Date        string             `json:"date" binding:"required"`
+	CategoryID  string             `json:"category_id" binding:"omitempty,uuid"`
+	AccountID   string             `json:"account_id" binding:"omitempty,uuid"`
+	TagIDs      []string           `json:"tag_ids" binding:"omitempty,dive,uuid"`
+	Splits      []transactionSplit `json:"splits" binding:"omitempty,dive"`
+}
+
+type transactionSplit struct {
+	CategoryID  string       `json:"category_id" binding:"required,uuid"`
+	Amount      entity.Money `json:"amount" binding:"required,gt=0"`
+	Description string       `json:"description" binding:"max=255"`
+}
+
+// splits converts the request lines, keeping nil apart from an empty list.
+func (req transactionRequest) splits() []entity.TransactionSplit {
+	if req.Splits == nil {
+		return nil
+	}
+	splits := make([]entity.TransactionSplit, len(req.Splits))
+	for i, sp := range req.Splits {
+		catID, _ := uuid.Parse(sp.CategoryID)
+		splits[i] = entity.TransactionSplit{CategoryID: catID, Amount: sp.Amount, Description: sp.Description}
+	}
+	return splits
 }
 
 func (h *TransactionHandler) List(c *gin.Context) {
+	filter := transactionFilterFromQuery(c)
+	filter.Page, _ = strconv.Atoi(c.DefaultQuery("page", "1"))
+	filter.PerPage, _ = strconv.Atoi(c.DefaultQuery("per_page", "20"))
+
+	result, err := h.uc.List(c.Request.Context(), filter)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// transactionFilterFromQuery reads the filter query parameters shared by the
+// listing and the export. Malformed ids and amounts are ignored. category_id
+// and tag_id may be repeated or comma-separated; user_id accepts "me".
+func transactionFilterFromQuery(c *gin.Context) entity.TransactionFilter {
 	filter := entity.TransactionFilter{
 		Type:      c.Query("type"),
+		Query:     strings.TrimSpace(c.Query("q")),
 		StartDate: c.Query("start_date"),
 		EndDate:   c.Query("end_date"),
 	}
 
-	if catID := c.Query("category_id"); catID != "" {
-		id, err := uuid.Parse(catID)
+	filter.CategoryIDs = queryUUIDs(c, "category_id")
+	filter.TagIDs = queryUUIDs(c, "tag_id")
+
+	if userID := c.Query("user_id"); userID == "me" {
+		id := middleware.GetUserID(c)
+		filter.UserID = &id
+	} else if userID != "" {
+		id, err := uuid.Parse(userID)
 		if err == nil {
-			filter.CategoryID = &id
+			filter.UserID = &id
 		}
 	}
 
-	filter.Page, _ = strconv.Atoi(c.DefaultQuery("page", "1"))
-	filter.PerPage, _ = strconv.Atoi(c.DefaultQuery("per_page", "20"))
+	if minAmount := c.Query("min_amount"); minAmount != "" {
+		amount, err := entity.ParseMoney(minAmount)
+		if err == nil {
+			filter.MinAmount = &amount
+		}
+	}
 
-	result, err := h.uc.List(c.Request.Context(), filter)
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
-		return
+	if maxAmount := c.Query("max_amount"); maxAmount != "" {
+		amount, err := entity.ParseMoney(maxAmount)
+		if err == nil {
+			filter.MaxAmount = &amount
+		}
 	}
 
-	c.JSON(http.StatusOK, result)
+	if accountID := c.Query("account_id"); accountID != "" {
+		id, err := uuid.Parse(accountID)
+		if err == nil {
+			filter.AccountID = &id
+		}
+	}
+
+	if statementID := c.Query("statement_id"); statementID != "" {
+		id, err := uuid.Parse(statementID)
+		if err == nil {
+			filter.StatementID = &id
+		}
+	}
+
+	return filter
+}
+
+// queryUUIDs collects the ids of a query parameter that may be repeated or
+// hold a comma-separated list.
+func queryUUIDs(c *gin.Context, key string) []uuid.UUID {
+	var ids []uuid.UUID
+	for _, value := range c.QueryArray(key) {
+		for _, part := range strings.Split(value, ",") {
+			id, err := uuid.Parse(strings.TrimSpace(part))
+			if err == nil {
+				ids = append(ids, id)
+			}
+		}
+	}
+	return ids
 }
 
 func (h *TransactionHandler) GetByID(c *gin.Context) {
@@ -79,17 +161,23 @@ func (h *TransactionHandler) Create(c *gin.Context) {
 	}
 
 	catID, _ := uuid.Parse(req.CategoryID)
+	accountID, _ := uuid.Parse(req.AccountID)
 	tx := &entity.Transaction{
 		UserID:      userID,
 		CategoryID:  catID,
+		AccountID:   accountID,
 		Type:        req.Type,
 		Amount:      req.Amount,
+		Currency:    req.Currency,
 		Description: req.Description,
 		Date:        req.Date,
+		TagIDs:      parseTagIDs(req.TagIDs),
+		Splits:      req.splits(),
 	}
 
 	if err := h.uc.Create(c.Request.Context(), tx); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		status := mapDomainError(err)
+		c.JSON(status, gin.H{"error": err.Error()})
 		return
 	}
 
@@ -110,13 +198,18 @@ func (h *TransactionHandler) Update(c *gin.Context) {
 	}
 
 	catID, _ := uuid.Parse(req.CategoryID)
+	accountID, _ := uuid.Parse(req.AccountID)
 	tx := &entity.Transaction{
 		ID:          id,
 		CategoryID:  catID,
+		AccountID:   accountID,
 		Type:        req.Type,
 		Amount:      req.Amount,
+		Currency:    req.Currency,
 		Description: req.Description,
 		Date:        req.Date,
+		TagIDs:      parseTagIDs(req.TagIDs),
+		Splits:      req.splits(),
 	}
 
 	if err := h.uc.Update(c.Request.Context(), tx); err != nil {
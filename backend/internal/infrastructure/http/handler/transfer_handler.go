@@ -0,0 +1,117 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/dcunha/finance/backend/internal/domain/entity"
+	"github.com/dcunha/finance/backend/internal/domain/usecase"
+	"github.com/dcunha/finance/backend/internal/infrastructure/http/middleware"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type TransferHandler struct {
+	uc *usecase.TransferUsecase
+}
+
+func NewTransferHandler(uc *usecase.TransferUsecase) *TransferHandler {
+	return &TransferHandler{uc: uc}
+}
+
+type transferRequest struct {
+	FromAccountID string       `json:"from_account_id" binding:"required,uuid"`
+	ToAccountID   string       `json:"to_account_id" binding:"required,uuid"`
+	Amount        entity.Money `json:"amount" binding:"required,gt=0"`
+	Description   string       `json:"description"`
+	Date          string       `json:"date" binding:"required"`
+}
+
+func (req transferRequest) toEntity() *entity.Transfer {
+	fromID, _ := uuid.Parse(req.FromAccountID)
+	toID, _ := uuid.Parse(req.ToAccountID)
+	return &entity.Transfer{
+		FromAccountID: fromID,
+		ToAccountID:   toID,
+		Amount:        req.Amount,
+		Description:   req.Description,
+		Date:          req.Date,
+	}
+}
+
+func (h *TransferHandler) GetByID(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		return
+	}
+
+	t, err := h.uc.GetByID(c.Request.Context(), id)
+	if err != nil {
+		status := mapDomainError(err)
+		c.JSON(status, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, t)
+}
+
+func (h *TransferHandler) Create(c *gin.Context) {
+	userID := middleware.GetUserID(c)
+	var req transferRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	t := req.toEntity()
+	t.UserID = userID
+
+	if err := h.uc.Create(c.Request.Context(), t); err != nil {
+		status := mapDomainError(err)
+		c.JSON(status, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, t)
+}
+
+func (h *TransferHandler) Update(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		return
+	}
+
+	var req transferRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	t := req.toEntity()
+	t.ID = id
+
+	if err := h.uc.Update(c.Request.Context(), t); err != nil {
+		status := mapDomainError(err)
+		c.JSON(status, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, t)
+}
+
+func (h *TransferHandler) Delete(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		return
+	}
+
+	if err := h.uc.Delete(c.Request.Context(), id); err != nil {
+		status := mapDomainError(err)
+		c.JSON(status, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusNoContent, nil)
+}
@@ -0,0 +1,115 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/dcunha/finance/backend/internal/domain/usecase"
+	"github.com/dcunha/finance/backend/internal/infrastructure/http/middleware"
+	"github.com/gin-gonic/gin"
+)
+
+type TwoFactorHandler struct {
+	uc *usecase.TwoFactorUsecase
+}
+
+func NewTwoFactorHandler(uc *usecase.TwoFactorUsecase) *TwoFactorHandler {
+	return &TwoFactorHandler{uc: uc}
+}
+
+type twoFactorCodeOnlyRequest struct {
+	Code string `json:"code" binding:"required"`
+}
+
+type disableTwoFactorRequest struct {
+	Password string `json:"password"`
+	Code     string `json:"code" binding:"required"`
+}
+
+type twoFactorPolicyRequest struct {
+	Required *bool `json:"required" binding:"required"`
+}
+
+func (h *TwoFactorHandler) Status(c *gin.Context) {
+	status, err := h.uc.Status(c.Request.Context(), middleware.GetGlobalUserID(c))
+	if err != nil {
+		status := mapDomainError(err)
+		c.JSON(status, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, status)
+}
+
+func (h *TwoFactorHandler) Setup(c *gin.Context) {
+	setup, err := h.uc.Setup(c.Request.Context(), middleware.GetGlobalUserID(c))
+	if err != nil {
+		status := mapDomainError(err)
+		c.JSON(status, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, setup)
+}
+
+func (h *TwoFactorHandler) Enable(c *gin.Context) {
+	var req twoFactorCodeOnlyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	codes, err := h.uc.Enable(c.Request.Context(), middleware.GetGlobalUserID(c), req.Code)
+	if err != nil {
+		status := mapDomainError(err)
+		c.JSON(status, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"recovery_codes": codes})
+}
+
+func (h *TwoFactorHandler) Disable(c *gin.Context) {
+	var req disableTwoFactorRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.uc.Disable(c.Request.Context(), middleware.GetGlobalUserID(c), req.Password, req.Code); err != nil {
+		status := mapDomainError(err)
+		c.JSON(status, gin.H{"error": err.Error()})
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+func (h *TwoFactorHandler) RegenerateRecoveryCodes(c *gin.Context) {
+	var req twoFactorCodeOnlyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	codes, err := h.uc.RegenerateRecoveryCodes(c.Request.Context(), middleware.GetGlobalUserID(c), req.Code)
+	if err != nil {
+		status := mapDomainError(err)
+		c.JSON(status, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"recovery_codes": codes})
+}
+
+// SetTenantPolicy lets the owner require two-factor authentication from
+// every member of the tenant.
+func (h *TwoFactorHandler) SetTenantPolicy(c *gin.Context) {
+	var req twoFactorPolicyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	t, err := h.uc.SetTenantRequirement(c.Request.Context(), middleware.GetTenantID(c), *req.Required)
+	if err != nil {
+		status := mapDomainError(err)
+		c.JSON(status, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"require_two_factor": t.RequireTwoFactor})
+}
@@ -3,7 +3,9 @@ package middleware
 import (
 	"net/http"
 	"strings"
+	"time"
 
+	"github.com/dcunha/finance/backend/internal/domain/repository"
 	"github.com/dcunha/finance/backend/internal/infrastructure/database"
 	"github.com/dcunha/finance/backend/internal/tenant"
 	"github.com/gin-gonic/gin"
@@ -11,7 +13,7 @@ import (
 	"github.com/google/uuid"
 )
 
-func Auth(jwtSecret string, tenantCache *database.TenantCache) gin.HandlerFunc {
+func Auth(jwtSecret string, tenantCache *database.TenantCache, sessionRepo repository.SessionRepository) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		header := c.GetHeader("Authorization")
 		if header == "" {
@@ -62,6 +64,20 @@ func Auth(jwtSecret string, tenantCache *database.TenantCache) gin.HandlerFunc {
 		globalUserIDStr, _ := claims["global_user_id"].(string)
 		globalUserID, _ := uuid.Parse(globalUserIDStr)
 
+		// Reject tokens whose session was revoked (logout, password change,
+		// member removed) without waiting for them to expire
+		sidStr, _ := claims["sid"].(string)
+		sessionID, err := uuid.Parse(sidStr)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "invalid session in token"})
+			return
+		}
+		session, err := sessionRepo.FindByID(c.Request.Context(), sessionID)
+		if err != nil || !session.Active(time.Now()) {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "session has expired or was revoked"})
+			return
+		}
+
 		// Look up tenant in cache and inject schema into request context
 		t, ok := tenantCache.GetByID(tenantID)
 		if !ok {
@@ -76,6 +92,7 @@ func Auth(jwtSecret string, tenantCache *database.TenantCache) gin.HandlerFunc {
 		c.Set("role", role)
 		c.Set("tenantID", tenantID)
 		c.Set("globalUserID", globalUserID)
+		c.Set("sessionID", sessionID)
 		c.Next()
 	}
 }
@@ -95,3 +112,7 @@ func GetTenantID(c *gin.Context) uuid.UUID {
 func GetGlobalUserID(c *gin.Context) uuid.UUID {
 	return c.MustGet("globalUserID").(uuid.UUID)
 }
+
+func GetSessionID(c *gin.Context) uuid.UUID {
+	return c.MustGet("sessionID").(uuid.UUID)
+}
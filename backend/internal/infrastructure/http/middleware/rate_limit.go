@@ -0,0 +1,81 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/dcunha/finance/backend/internal/infrastructure/ratelimit"
+	"github.com/gin-gonic/gin"
+)
+
+// RateLimit limits requests per client IP to the given scope. c.ClientIP
+// honours the trusted platform set on the engine, so behind Cloudflare it is
+// the visitor's address rather than the proxy's. Store errors let the request
+// through: an outage of the counters should not lock everyone out.
+func RateLimit(limiter *ratelimit.Limiter, scope string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		wait, err := limiter.AllowIP(c.Request.Context(), scope, c.ClientIP())
+		if err != nil {
+			log.Printf("Rate limit: %s: %v", scope, err)
+		}
+		if wait > 0 {
+			abortTooManyRequests(c, wait, "muitas tentativas, tente novamente mais tarde")
+			return
+		}
+		c.Next()
+	}
+}
+
+// LoginLockout refuses logins for an email while it is locked, and after the
+// handler runs counts a 401 as a failed attempt and a 200 as a success. The
+// email is peeked from the JSON body, which is put back for the handler.
+func LoginLockout(limiter *ratelimit.Limiter) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		body, err := io.ReadAll(c.Request.Body)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
+			return
+		}
+		c.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+		var req struct {
+			Email string `json:"email"`
+		}
+		if json.Unmarshal(body, &req) != nil || req.Email == "" {
+			c.Next()
+			return
+		}
+
+		ctx := c.Request.Context()
+		wait, err := limiter.LockedFor(ctx, req.Email)
+		if err != nil {
+			log.Printf("Rate limit: lockout: %v", err)
+		}
+		if wait > 0 {
+			abortTooManyRequests(c, wait, "conta bloqueada temporariamente por excesso de tentativas, tente novamente mais tarde")
+			return
+		}
+
+		c.Next()
+
+		switch c.Writer.Status() {
+		case http.StatusUnauthorized:
+			err = limiter.RecordFailure(ctx, req.Email)
+		case http.StatusOK:
+			err = limiter.RecordSuccess(ctx, req.Email)
+		}
+		if err != nil {
+			log.Printf("Rate limit: lockout: %v", err)
+		}
+	}
+}
+
+func abortTooManyRequests(c *gin.Context, wait time.Duration, message string) {
+	c.Header("Retry-After", strconv.Itoa(int(wait.Round(time.Second)/time.Second)))
+	c.AbortWithStatusJSON(http.StatusTooManyRequests, gin.H{"error": message})
+}
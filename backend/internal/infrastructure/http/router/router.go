@@ -4,51 +4,93 @@ import (
 	"net/http"
 	"strings"
 
+	"github.com/dcunha/finance/backend/internal/domain/repository"
 	"github.com/dcunha/finance/backend/internal/infrastructure/database"
 	"github.com/dcunha/finance/backend/internal/infrastructure/http/handler"
 	"github.com/dcunha/finance/backend/internal/infrastructure/http/middleware"
+	"github.com/dcunha/finance/backend/internal/infrastructure/ratelimit"
 	"github.com/gin-gonic/gin"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
 type Handlers struct {
-	Health       *handler.HealthHandler
-	Auth         *handler.AuthHandler
-	Registration *handler.RegistrationHandler
-	Invite       *handler.InviteHandler
-	Category     *handler.CategoryHandler
-	Transaction  *handler.TransactionHandler
-	ExpenseLimit *handler.ExpenseLimitHandler
-	Dashboard    *handler.DashboardHandler
-	Admin        *handler.AdminHandler
-	Recurring    *handler.RecurringTransactionHandler
+	Health        *handler.HealthHandler
+	Auth          *handler.AuthHandler
+	TwoFactor     *handler.TwoFactorHandler
+	OIDC          *handler.OIDCHandler
+	Registration  *handler.RegistrationHandler
+	Invite        *handler.InviteHandler
+	Category      *handler.CategoryHandler
+	Account       *handler.AccountHandler
+	CreditCard    *handler.CreditCardHandler
+	Transaction   *handler.TransactionHandler
+	Transfer      *handler.TransferHandler
+	Attachment    *handler.AttachmentHandler
+	Export        *handler.ExportHandler
+	Import        *handler.ImportHandler
+	ImportProfile *handler.ImportProfileHandler
+	Rule          *handler.CategorizationRuleHandler
+	Tag           *handler.TagHandler
+	ExchangeRate  *handler.ExchangeRateHandler
+	Settings      *handler.SettingsHandler
+	ExpenseLimit  *handler.ExpenseLimitHandler
+	Budget        *handler.BudgetHandler
+	Goal          *handler.GoalHandler
+	Dashboard     *handler.DashboardHandler
+	Report        *handler.ReportHandler
+	Admin         *handler.AdminHandler
+	Recurring     *handler.RecurringTransactionHandler
 }
 
-func Setup(r *gin.Engine, jwtSecret string, staticDir string, allowedOrigin string, pool *pgxpool.Pool, tenantCache *database.TenantCache, h Handlers) {
+func Setup(r *gin.Engine, jwtSecret string, staticDir string, allowedOrigin string, pool *pgxpool.Pool, tenantCache *database.TenantCache, sessionRepo repository.SessionRepository, limiter *ratelimit.Limiter, h Handlers) {
 	r.Use(middleware.CORS(allowedOrigin))
 
 	r.GET("/health", h.Health.Health)
 
 	api := r.Group("/api/v1")
 
-	// Auth (public)
+	// Auth (public). Endpoints that check a password, a code or a token, or
+	// send email, are throttled per IP; login also locks out an email after
+	// repeated wrong passwords.
 	auth := api.Group("/auth")
-	auth.POST("/login", h.Auth.Login)
+	auth.POST("/login", middleware.RateLimit(limiter, "login"), middleware.LoginLockout(limiter), h.Auth.Login)
 	auth.POST("/select-tenant", h.Auth.SelectTenant)
-	auth.POST("/register", h.Registration.Register)
+	auth.POST("/refresh", middleware.RateLimit(limiter, "refresh"), h.Auth.Refresh)
+	auth.POST("/2fa/verify", middleware.RateLimit(limiter, "2fa"), h.Auth.VerifyTwoFactor)
+	auth.POST("/2fa/setup", middleware.RateLimit(limiter, "2fa"), h.Auth.SetupTwoFactor)
+	auth.POST("/2fa/enable", middleware.RateLimit(limiter, "2fa"), h.Auth.EnableTwoFactor)
+	auth.POST("/register", middleware.RateLimit(limiter, "register"), h.Registration.Register)
 	auth.POST("/verify-email", h.Registration.VerifyEmail)
+	auth.POST("/forgot-password", middleware.RateLimit(limiter, "forgot-password"), h.Registration.ForgotPassword)
+	auth.POST("/reset-password", middleware.RateLimit(limiter, "reset-password"), h.Registration.ResetPassword)
 	auth.GET("/invite-info", h.Invite.GetInviteInfo)
-	auth.POST("/accept-invite", h.Invite.AcceptInvite)
+	auth.POST("/accept-invite", middleware.RateLimit(limiter, "accept-invite"), h.Invite.AcceptInvite)
+	auth.GET("/oidc/config", h.OIDC.Config)
+	auth.GET("/oidc/login", middleware.RateLimit(limiter, "oidc"), h.OIDC.Login)
+	auth.GET("/oidc/callback", middleware.RateLimit(limiter, "oidc"), h.OIDC.Callback)
+	auth.POST("/oidc/complete", middleware.RateLimit(limiter, "oidc"), h.Auth.CompleteOIDC)
 
 	// Protected routes
 	protected := api.Group("")
-	protected.Use(middleware.Auth(jwtSecret, tenantCache))
+	protected.Use(middleware.Auth(jwtSecret, tenantCache, sessionRepo))
 	protected.Use(middleware.SchemaConn(pool))
 
+	// Sessions
+	protected.POST("/auth/logout", h.Auth.Logout)
+	protected.POST("/auth/logout-all", h.Auth.LogoutAll)
+
 	// Profile
 	protected.GET("/profile", h.Auth.GetProfile)
 	protected.PUT("/profile", h.Auth.UpdateProfile)
 	protected.POST("/profile/change-password", h.Auth.ChangePassword)
+	protected.GET("/profile/2fa", h.TwoFactor.Status)
+	protected.POST("/profile/2fa/setup", h.TwoFactor.Setup)
+	protected.POST("/profile/2fa/enable", h.TwoFactor.Enable)
+	protected.POST("/profile/2fa/disable", h.TwoFactor.Disable)
+	protected.POST("/profile/2fa/recovery-codes", h.TwoFactor.RegenerateRecoveryCodes)
+
+	// Settings
+	protected.GET("/settings", h.Settings.Get)
 
 	// Categories
 	cats := protected.Group("/categories")
@@ -57,13 +99,85 @@ func Setup(r *gin.Engine, jwtSecret string, staticDir string, allowedOrigin stri
 	cats.PUT("/:id", h.Category.Update)
 	cats.DELETE("/:id", h.Category.Delete)
 
+	// Categorization Rules
+	rules := protected.Group("/categorization-rules")
+	rules.GET("", h.Rule.List)
+	rules.GET("/:id", h.Rule.GetByID)
+	rules.POST("", h.Rule.Create)
+	rules.POST("/apply", h.Rule.Apply)
+	rules.PUT("/:id", h.Rule.Update)
+	rules.DELETE("/:id", h.Rule.Delete)
+
+	// Tags
+	tags := protected.Group("/tags")
+	tags.GET("", h.Tag.List)
+	tags.GET("/:id", h.Tag.GetByID)
+	tags.POST("", h.Tag.Create)
+	tags.PUT("/:id", h.Tag.Update)
+	tags.DELETE("/:id", h.Tag.Delete)
+
+	// Exchange Rates
+	rates := protected.Group("/exchange-rates")
+	rates.GET("", h.ExchangeRate.List)
+	rates.GET("/:id", h.ExchangeRate.GetByID)
+	rates.POST("", h.ExchangeRate.Create)
+	rates.POST("/sync", h.ExchangeRate.Sync)
+	rates.PUT("/:id", h.ExchangeRate.Update)
+	rates.DELETE("/:id", h.ExchangeRate.Delete)
+
+	// Accounts
+	accounts := protected.Group("/accounts")
+	accounts.GET("", h.Account.List)
+	accounts.GET("/:id", h.Account.GetByID)
+	accounts.POST("", h.Account.Create)
+	accounts.PUT("/:id", h.Account.Update)
+	accounts.DELETE("/:id", h.Account.Delete)
+
+	// Credit Cards
+	cards := protected.Group("/credit-cards")
+	cards.GET("", h.CreditCard.List)
+	cards.GET("/:id", h.CreditCard.GetByID)
+	cards.POST("", h.CreditCard.Create)
+	cards.PUT("/:id", h.CreditCard.Update)
+	cards.DELETE("/:id", h.CreditCard.Delete)
+	cards.POST("/:id/purchases", h.CreditCard.Purchase)
+	cards.GET("/:id/statements", h.CreditCard.ListStatements)
+	cards.GET("/:id/statements/:statementId", h.CreditCard.GetStatement)
+	cards.POST("/:id/statements/:statementId/payments", h.CreditCard.PayStatement)
+
 	// Transactions
 	txs := protected.Group("/transactions")
 	txs.GET("", h.Transaction.List)
+	txs.GET("/export", h.Export.Export)
 	txs.GET("/:id", h.Transaction.GetByID)
 	txs.POST("", h.Transaction.Create)
 	txs.PUT("/:id", h.Transaction.Update)
 	txs.DELETE("/:id", h.Transaction.Delete)
+	txs.GET("/:id/attachments", h.Attachment.List)
+	txs.POST("/:id/attachments", h.Attachment.Upload)
+	txs.GET("/:id/attachments/:attachmentId", h.Attachment.Download)
+	txs.DELETE("/:id/attachments/:attachmentId", h.Attachment.Delete)
+
+	// Transfers
+	transfers := protected.Group("/transfers")
+	transfers.GET("/:id", h.Transfer.GetByID)
+	transfers.POST("", h.Transfer.Create)
+	transfers.PUT("/:id", h.Transfer.Update)
+	transfers.DELETE("/:id", h.Transfer.Delete)
+
+	// Imports
+	imports := protected.Group("/imports")
+	imports.POST("/ofx/preview", h.Import.PreviewOFX)
+	imports.POST("/csv/preview", h.Import.PreviewCSV)
+	imports.POST("/commit", h.Import.Commit)
+
+	// Import Profiles
+	profiles := protected.Group("/import-profiles")
+	profiles.GET("", h.ImportProfile.List)
+	profiles.GET("/:id", h.ImportProfile.GetByID)
+	profiles.POST("", h.ImportProfile.Create)
+	profiles.PUT("/:id", h.ImportProfile.Update)
+	profiles.DELETE("/:id", h.ImportProfile.Delete)
 
 	// Expense Limits
 	limits := protected.Group("/expense-limits")
@@ -73,10 +187,30 @@ func Setup(r *gin.Engine, jwtSecret string, staticDir string, allowedOrigin stri
 	limits.PUT("/:id", h.ExpenseLimit.Update)
 	limits.DELETE("/:id", h.ExpenseLimit.Delete)
 
+	// Budgets
+	budgets := protected.Group("/budgets")
+	budgets.GET("", h.Budget.List)
+	budgets.GET("/:id", h.Budget.GetByID)
+	budgets.POST("", h.Budget.Create)
+	budgets.PUT("/:id", h.Budget.Update)
+	budgets.DELETE("/:id", h.Budget.Delete)
+
+	// Goals
+	goals := protected.Group("/goals")
+	goals.GET("", h.Goal.List)
+	goals.GET("/:id", h.Goal.GetByID)
+	goals.POST("", h.Goal.Create)
+	goals.PUT("/:id", h.Goal.Update)
+	goals.DELETE("/:id", h.Goal.Delete)
+	goals.GET("/:id/contributions", h.Goal.ListContributions)
+	goals.POST("/:id/contributions", h.Goal.AddContribution)
+	goals.DELETE("/:id/contributions/:transactionId", h.Goal.RemoveContribution)
+
 	// Recurring Transactions
 	recurring := protected.Group("/recurring-transactions")
 	recurring.GET("", h.Recurring.List)
 	recurring.POST("", h.Recurring.Create)
+	recurring.PUT("/:id", h.Recurring.Update)
 	recurring.DELETE("/:id", h.Recurring.Delete)
 	recurring.POST("/:id/pause", h.Recurring.Pause)
 	recurring.POST("/:id/resume", h.Recurring.Resume)
@@ -85,7 +219,16 @@ func Setup(r *gin.Engine, jwtSecret string, staticDir string, allowedOrigin stri
 	dash := protected.Group("/dashboard")
 	dash.GET("/summary", h.Dashboard.Summary)
 	dash.GET("/by-category", h.Dashboard.ByCategory)
+	dash.GET("/by-tag", h.Dashboard.ByTag)
 	dash.GET("/limits-progress", h.Dashboard.LimitsProgress)
+	dash.GET("/goals-progress", h.Dashboard.GoalsProgress)
+	dash.GET("/forecast", h.Dashboard.Forecast)
+
+	// Reports
+	reports := protected.Group("/reports")
+	reports.GET("/monthly", h.Report.Monthly)
+	reports.GET("/by-category", h.Report.ByCategory)
+	reports.GET("/year-over-year", h.Report.YearOverYear)
 
 	// Admin routes (admin or owner)
 	admin := protected.Group("/admin")
@@ -96,6 +239,8 @@ func Setup(r *gin.Engine, jwtSecret string, staticDir string, allowedOrigin stri
 	admin.DELETE("/users/:id", h.Admin.DeleteUser)
 	admin.POST("/users/:id/reset-password", h.Admin.ResetPassword)
 	admin.POST("/invite", h.Invite.CreateInvite)
+	admin.PUT("/settings", h.Settings.Update)
+	admin.PUT("/two-factor", middleware.RequireOwner(), h.TwoFactor.SetTenantPolicy)
 
 	// Serve frontend static files (production)
 	if staticDir != "" {
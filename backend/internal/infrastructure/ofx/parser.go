@@ -0,0 +1,173 @@
+// Package ofx reads bank statements in the OFX format, both the SGML flavour
+// (OFX 1.x, still exported by most Brazilian banks) and XML (OFX 2.x).
+package ofx
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"html"
+	"io"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/dcunha/finance/backend/internal/domain/entity"
+)
+
+// Transaction is a statement entry (STMTTRN).
+type Transaction struct {
+	FITID  string
+	Type   string
+	Date   time.Time
+	Amount entity.Money
+	Name   string
+	Memo   string
+}
+
+// Description returns the memo, falling back to the payee name.
+func (t Transaction) Description() string {
+	if t.Memo != "" {
+		return t.Memo
+	}
+	return t.Name
+}
+
+// Statement is the content of a bank or credit card statement response.
+type Statement struct {
+	BankID       string
+	AccountID    string
+	Currency     string
+	Transactions []Transaction
+}
+
+var ErrNoTransactions = errors.New("ofx: no transactions found")
+
+// Parse reads an OFX document. Leaf elements may be left unclosed, as SGML
+// allows. Files that are not valid UTF-8 are decoded as Latin-1 (CHARSET 1252).
+func Parse(r io.Reader) (*Statement, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	if !utf8.Valid(data) {
+		data = latin1ToUTF8(data)
+	}
+
+	start := bytes.Index(bytes.ToUpper(data), []byte("<OFX>"))
+	if start < 0 {
+		return nil, fmt.Errorf("ofx: missing <OFX> element")
+	}
+	body := string(data[start:])
+
+	st := &Statement{}
+	var current *Transaction
+	for len(body) > 0 {
+		open := strings.IndexByte(body, '<')
+		if open < 0 {
+			break
+		}
+		end := strings.IndexByte(body[open:], '>')
+		if end < 0 {
+			return nil, fmt.Errorf("ofx: unterminated tag")
+		}
+		tag := strings.ToUpper(strings.TrimSpace(body[open+1 : open+end]))
+		body = body[open+end+1:]
+
+		next := strings.IndexByte(body, '<')
+		if next < 0 {
+			next = len(body)
+		}
+		value := strings.TrimSpace(html.UnescapeString(body[:next]))
+
+		switch {
+		case tag == "STMTTRN":
+			current = &Transaction{}
+		case tag == "/STMTTRN":
+			if current != nil {
+				st.Transactions = append(st.Transactions, *current)
+				current = nil
+			}
+		case strings.HasPrefix(tag, "/") || value == "":
+			continue
+		case current != nil:
+			if err := setTransactionField(current, tag, value); err != nil {
+				return nil, err
+			}
+		case tag == "BANKID":
+			st.BankID = value
+		case tag == "ACCTID":
+			st.AccountID = value
+		case tag == "CURDEF":
+			st.Currency = value
+		}
+	}
+
+	if len(st.Transactions) == 0 {
+		return nil, ErrNoTransactions
+	}
+	return st, nil
+}
+
+func setTransactionField(t *Transaction, tag, value string) error {
+	switch tag {
+	case "FITID":
+		t.FITID = value
+	case "TRNTYPE":
+		t.Type = strings.ToUpper(value)
+	case "NAME":
+		t.Name = value
+	case "MEMO":
+		t.Memo = value
+	case "DTPOSTED":
+		date, err := parseDate(value)
+		if err != nil {
+			return err
+		}
+		t.Date = date
+	case "TRNAMT":
+		amount, err := parseAmount(value)
+		if err != nil {
+			return err
+		}
+		t.Amount = amount
+	}
+	return nil
+}
+
+// parseDate reads the date part of an OFX datetime such as
+// "20240115120000.000[-3:BRT]".
+func parseDate(value string) (time.Time, error) {
+	if len(value) < 8 {
+		return time.Time{}, fmt.Errorf("ofx: invalid date %q", value)
+	}
+	date, err := time.Parse("20060102", value[:8])
+	if err != nil {
+		return time.Time{}, fmt.Errorf("ofx: invalid date %q", value)
+	}
+	return date, nil
+}
+
+// parseAmount accepts both "." and "," as the decimal separator; some banks
+// write amounts with a decimal comma.
+func parseAmount(value string) (entity.Money, error) {
+	normalized := strings.ReplaceAll(value, " ", "")
+	if strings.Contains(normalized, ",") {
+		normalized = strings.ReplaceAll(normalized, ".", "")
+		normalized = strings.ReplaceAll(normalized, ",", ".")
+	}
+	amount, err := entity.ParseMoney(normalized)
+	if err != nil {
+		return 0, fmt.Errorf("ofx: invalid amount %q", value)
+	}
+	return amount, nil
+}
+
+func latin1ToUTF8(data []byte) []byte {
+	var buf bytes.Buffer
+	buf.Grow(len(data))
+	for _, b := range data {
+		buf.WriteRune(rune(b))
+	}
+	return buf.Bytes()
+}
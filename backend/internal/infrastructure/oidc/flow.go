@@ -0,0 +1,51 @@
+package oidc
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"strings"
+)
+
+// Flow holds the per-login secrets: the state that ties the callback to the
+// browser that started the login, the nonce bound into the ID token, and the
+// PKCE code verifier. It travels in a cookie between the redirect and the
+// callback.
+type Flow struct {
+	State    string
+	Nonce    string
+	Verifier string
+}
+
+func NewFlow() (*Flow, error) {
+	var values [3]string
+	for i := range values {
+		b := make([]byte, 32)
+		if _, err := rand.Read(b); err != nil {
+			return nil, err
+		}
+		values[i] = base64.RawURLEncoding.EncodeToString(b)
+	}
+	return &Flow{State: values[0], Nonce: values[1], Verifier: values[2]}, nil
+}
+
+// Encode returns the flow as a cookie value.
+func (f *Flow) Encode() string {
+	return f.State + "." + f.Nonce + "." + f.Verifier
+}
+
+// ParseFlow reads a cookie value written by Encode.
+func ParseFlow(s string) (*Flow, error) {
+	parts := strings.Split(s, ".")
+	if len(parts) != 3 || parts[0] == "" || parts[1] == "" || parts[2] == "" {
+		return nil, errors.New("oidc: invalid flow")
+	}
+	return &Flow{State: parts[0], Nonce: parts[1], Verifier: parts[2]}, nil
+}
+
+// challenge is the S256 PKCE challenge of the verifier.
+func (f *Flow) challenge() string {
+	sum := sha256.Sum256([]byte(f.Verifier))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
@@ -0,0 +1,170 @@
+package ratelimit
+
+import (
+	"context"
+	"strings"
+	"time"
+)
+
+// Policy allows Limit events per Window.
+type Policy struct {
+	Limit  int
+	Window time.Duration
+}
+
+// Limiter applies the authentication limits on top of a Store:
+//
+//   - per IP and scope (one scope per endpoint), a fixed window of requests;
+//   - per email, progressive lockout: Failures.Limit failed logins within
+//     Failures.Window lock the email for LockoutBase, and every further
+//     lockout within LockoutMemory doubles it, up to LockoutMax;
+//   - per user, Failures.Limit wrong second-factor codes within
+//     Failures.Window lock the user out of codes for a duration set by the
+//     caller.
+type Limiter struct {
+	store Store
+
+	IP            Policy
+	Failures      Policy
+	LockoutBase   time.Duration
+	LockoutMax    time.Duration
+	LockoutMemory time.Duration
+
+	// OnLockout, when set, is called with the email as typed the first time
+	// it gets locked within LockoutMemory, for example to notify its owner.
+	// Counters ignore case, so "Ana@x.com" and "ana@x.com" share them.
+	OnLockout func(email string, until time.Time)
+
+	// AccountExists, when set, limits failure counting to emails with an
+	// account, so unknown addresses cannot be locked and their owners cannot
+	// be flooded with notices.
+	AccountExists func(ctx context.Context, email string) (bool, error)
+}
+
+// NewLimiter returns a limiter with the default policy: 20 requests per IP
+// and endpoint every 5 minutes, and a lockout after 5 failed logins within
+// 15 minutes that starts at 5 minutes and doubles up to 24 hours.
+func NewLimiter(store Store) *Limiter {
+	return &Limiter{
+		store:         store,
+		IP:            Policy{Limit: 20, Window: 5 * time.Minute},
+		Failures:      Policy{Limit: 5, Window: 15 * time.Minute},
+		LockoutBase:   5 * time.Minute,
+		LockoutMax:    24 * time.Hour,
+		LockoutMemory: 24 * time.Hour,
+	}
+}
+
+// AllowIP counts a request from ip to scope. It returns zero while the IP is
+// within the limit, and otherwise how long until it may try again.
+func (l *Limiter) AllowIP(ctx context.Context, scope, ip string) (time.Duration, error) {
+	count, expiresAt, err := l.store.Incr(ctx, "ip:"+scope+":"+ip, l.IP.Window)
+	if err != nil {
+		return 0, err
+	}
+	if count <= l.IP.Limit {
+		return 0, nil
+	}
+	return retryAfter(expiresAt), nil
+}
+
+// LockedFor returns how long the email stays locked, or zero.
+func (l *Limiter) LockedFor(ctx context.Context, email string) (time.Duration, error) {
+	count, expiresAt, err := l.store.Get(ctx, "lock:"+normalizeEmail(email))
+	if err != nil || count == 0 {
+		return 0, err
+	}
+	return retryAfter(expiresAt), nil
+}
+
+// RecordFailure counts a failed login for the email and locks it once the
+// failures reach the limit.
+func (l *Limiter) RecordFailure(ctx context.Context, email string) error {
+	if l.AccountExists != nil {
+		exists, err := l.AccountExists(ctx, strings.TrimSpace(email))
+		if err != nil || !exists {
+			return err
+		}
+	}
+
+	key := normalizeEmail(email)
+	failures, _, err := l.store.Incr(ctx, "fail:"+key, l.Failures.Window)
+	if err != nil {
+		return err
+	}
+	if failures < l.Failures.Limit {
+		return nil
+	}
+
+	level, _, err := l.store.Incr(ctx, "level:"+key, l.LockoutMemory)
+	if err != nil {
+		return err
+	}
+	_, until, err := l.store.Incr(ctx, "lock:"+key, l.lockoutDuration(level))
+	if err != nil {
+		return err
+	}
+	if err := l.store.Delete(ctx, "fail:"+key); err != nil {
+		return err
+	}
+	if l.OnLockout != nil && level == 1 {
+		l.OnLockout(strings.TrimSpace(email), until)
+	}
+	return nil
+}
+
+// RecordSuccess clears the failures and lockout history of the email.
+func (l *Limiter) RecordSuccess(ctx context.Context, email string) error {
+	email = normalizeEmail(email)
+	return l.store.Delete(ctx, "fail:"+email, "level:"+email)
+}
+
+// CodeLockedFor returns how long the user stays locked out of second-factor
+// codes, or zero.
+func (l *Limiter) CodeLockedFor(ctx context.Context, userID string) (time.Duration, error) {
+	count, expiresAt, err := l.store.Get(ctx, "code-lock:"+userID)
+	if err != nil || count == 0 {
+		return 0, err
+	}
+	return retryAfter(expiresAt), nil
+}
+
+// RecordCodeFailure counts a wrong second-factor code for the user and locks
+// the user for lockout once the failures reach the limit. It reports whether
+// the user got locked.
+func (l *Limiter) RecordCodeFailure(ctx context.Context, userID string, lockout time.Duration) (bool, error) {
+	failures, _, err := l.store.Incr(ctx, "code-fail:"+userID, l.Failures.Window)
+	if err != nil {
+		return false, err
+	}
+	if failures < l.Failures.Limit {
+		return false, nil
+	}
+	if _, _, err := l.store.Incr(ctx, "code-lock:"+userID, lockout); err != nil {
+		return false, err
+	}
+	return true, l.store.Delete(ctx, "code-fail:"+userID)
+}
+
+// RecordCodeSuccess clears the user's wrong codes.
+func (l *Limiter) RecordCodeSuccess(ctx context.Context, userID string) error {
+	return l.store.Delete(ctx, "code-fail:"+userID)
+}
+
+// lockoutDuration returns LockoutBase doubled for every lockout before the
+// level-th, capped at LockoutMax.
+func (l *Limiter) lockoutDuration(level int) time.Duration {
+	d := l.LockoutBase
+	for i := 1; i < level && d < l.LockoutMax; i++ {
+		d *= 2
+	}
+	return min(d, l.LockoutMax)
+}
+
+func retryAfter(expiresAt time.Time) time.Duration {
+	return max(time.Until(expiresAt), time.Second)
+}
+
+func normalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
@@ -0,0 +1,71 @@
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// MemoryStore keeps counters in the process. Each instance counts on its own,
+// so it only fits a single instance or local development.
+type MemoryStore struct {
+	mu       sync.Mutex
+	counters map[string]memoryCounter
+}
+
+type memoryCounter struct {
+	count     int
+	expiresAt time.Time
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{counters: make(map[string]memoryCounter)}
+}
+
+func (s *MemoryStore) Incr(ctx context.Context, key string, ttl time.Duration) (int, time.Time, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	c, ok := s.counters[key]
+	if !ok || !c.expiresAt.After(now) {
+		c = memoryCounter{expiresAt: now.Add(ttl)}
+	}
+	c.count++
+	s.counters[key] = c
+	return c.count, c.expiresAt, nil
+}
+
+func (s *MemoryStore) Get(ctx context.Context, key string) (int, time.Time, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c, ok := s.counters[key]
+	if !ok || !c.expiresAt.After(time.Now()) {
+		return 0, time.Time{}, nil
+	}
+	return c.count, c.expiresAt, nil
+}
+
+func (s *MemoryStore) Delete(ctx context.Context, keys ...string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, key := range keys {
+		delete(s.counters, key)
+	}
+	return nil
+}
+
+func (s *MemoryStore) DeleteExpired(ctx context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	for key, c := range s.counters {
+		if !c.expiresAt.After(now) {
+			delete(s.counters, key)
+		}
+	}
+	return nil
+}
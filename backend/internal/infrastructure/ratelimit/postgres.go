@@ -0,0 +1,58 @@
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// PostgresStore keeps counters in public.rate_limits, shared by all
+// instances. Expiry is computed with the database clock so instances with
+// drifting clocks agree.
+type PostgresStore struct {
+	pool *pgxpool.Pool
+}
+
+func NewPostgresStore(pool *pgxpool.Pool) *PostgresStore {
+	return &PostgresStore{pool: pool}
+}
+
+func (s *PostgresStore) Incr(ctx context.Context, key string, ttl time.Duration) (int, time.Time, error) {
+	var count int
+	var expiresAt time.Time
+	err := s.pool.QueryRow(ctx,
+		`INSERT INTO rate_limits (key, count, expires_at)
+		 VALUES ($1, 1, NOW() + make_interval(secs => $2))
+		 ON CONFLICT (key) DO UPDATE SET
+		     count = CASE WHEN rate_limits.expires_at <= NOW() THEN 1 ELSE rate_limits.count + 1 END,
+		     expires_at = CASE WHEN rate_limits.expires_at <= NOW() THEN EXCLUDED.expires_at ELSE rate_limits.expires_at END
+		 RETURNING count, expires_at`,
+		key, ttl.Seconds(),
+	).Scan(&count, &expiresAt)
+	return count, expiresAt, err
+}
+
+func (s *PostgresStore) Get(ctx context.Context, key string) (int, time.Time, error) {
+	var count int
+	var expiresAt time.Time
+	err := s.pool.QueryRow(ctx,
+		`SELECT count, expires_at FROM rate_limits WHERE key = $1 AND expires_at > NOW()`, key,
+	).Scan(&count, &expiresAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return 0, time.Time{}, nil
+	}
+	return count, expiresAt, err
+}
+
+func (s *PostgresStore) Delete(ctx context.Context, keys ...string) error {
+	_, err := s.pool.Exec(ctx, `DELETE FROM rate_limits WHERE key = ANY($1)`, keys)
+	return err
+}
+
+func (s *PostgresStore) DeleteExpired(ctx context.Context) error {
+	_, err := s.pool.Exec(ctx, `DELETE FROM rate_limits WHERE expires_at <= NOW()`)
+	return err
+}
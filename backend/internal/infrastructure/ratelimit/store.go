@@ -0,0 +1,58 @@
+// Package ratelimit throttles authentication endpoints. Store is the counter
+// abstraction; MemoryStore keeps counters in the process and PostgresStore in
+// a table, so that every instance behind the load balancer sees the same
+// counts.
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// Store keeps counters that expire. Implementations are safe for concurrent use.
+type Store interface {
+	// Incr adds one to the counter of key and returns the new count and when
+	// the counter expires. A missing or expired counter starts again at 1 and
+	// lives for ttl.
+	Incr(ctx context.Context, key string, ttl time.Duration) (int, time.Time, error)
+	// Get returns the count and expiry of key, or a zero count when the
+	// counter is missing or expired.
+	Get(ctx context.Context, key string) (int, time.Time, error)
+	Delete(ctx context.Context, keys ...string) error
+	// DeleteExpired drops expired counters.
+	DeleteExpired(ctx context.Context) error
+}
+
+// NewStore returns the store with the given name: "memory" (the default
+// when name is empty) or "postgres".
+func NewStore(name string, pool *pgxpool.Pool) (Store, error) {
+	switch name {
+	case "", "memory":
+		return NewMemoryStore(), nil
+	case "postgres":
+		return NewPostgresStore(pool), nil
+	}
+	return nil, fmt.Errorf("ratelimit: unknown store %q", name)
+}
+
+// StartCleanup drops expired counters on every interval until ctx is done.
+func StartCleanup(ctx context.Context, store Store, interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if err := store.DeleteExpired(ctx); err != nil {
+					log.Printf("Rate limit: cleanup: %v", err)
+				}
+			}
+		}
+	}()
+}
@@ -0,0 +1,73 @@
+package scheduler
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/dcunha/finance/backend/internal/domain/usecase"
+	"github.com/dcunha/finance/backend/internal/infrastructure/database"
+	"github.com/dcunha/finance/backend/internal/tenant"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// ExchangeRateJob periodically fetches the day's exchange rates into every
+// tenant schema. It only runs when a rate provider is configured.
+type ExchangeRateJob struct {
+	pool        *pgxpool.Pool
+	tenantCache *database.TenantCache
+	uc          *usecase.ExchangeRateUsecase
+	interval    time.Duration
+}
+
+func NewExchangeRateJob(pool *pgxpool.Pool, tenantCache *database.TenantCache, uc *usecase.ExchangeRateUsecase, interval time.Duration) *ExchangeRateJob {
+	return &ExchangeRateJob{pool: pool, tenantCache: tenantCache, uc: uc, interval: interval}
+}
+
+// Start runs the job once immediately and then on every interval until ctx is done.
+func (j *ExchangeRateJob) Start(ctx context.Context) {
+	go func() {
+		j.RunOnce(ctx)
+
+		ticker := time.NewTicker(j.interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				j.RunOnce(ctx)
+			}
+		}
+	}()
+}
+
+// RunOnce syncs today's rates for all cached tenants. Failures are logged per
+// tenant so one broken schema does not block the others.
+func (j *ExchangeRateJob) RunOnce(ctx context.Context) {
+	today := time.Now()
+	for _, t := range j.tenantCache.All() {
+		if err := j.runTenant(ctx, t.SchemaName, today); err != nil {
+			log.Printf("Exchange rates: tenant %s: %v", t.SchemaName, err)
+		}
+	}
+}
+
+func (j *ExchangeRateJob) runTenant(ctx context.Context, schemaName string, date time.Time) error {
+	schemaCtx := tenant.ContextWithSchema(ctx, schemaName)
+	conn, release, err := database.AcquireWithSchema(schemaCtx, j.pool)
+	if err != nil {
+		return err
+	}
+	defer release()
+
+	schemaCtx = database.ContextWithConn(schemaCtx, conn)
+	rates, err := j.uc.Sync(schemaCtx, date)
+	if err != nil {
+		return err
+	}
+	if len(rates) > 0 {
+		log.Printf("Exchange rates: tenant %s: stored %d rates", schemaName, len(rates))
+	}
+	return nil
+}
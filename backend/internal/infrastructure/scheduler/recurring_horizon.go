@@ -0,0 +1,83 @@
+package scheduler
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/dcunha/finance/backend/internal/domain/usecase"
+	"github.com/dcunha/finance/backend/internal/infrastructure/database"
+	"github.com/dcunha/finance/backend/internal/tenant"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// RecurringHorizonJob periodically extends the materialized horizon of
+// recurring transactions in every tenant schema.
+type RecurringHorizonJob struct {
+	pool        *pgxpool.Pool
+	tenantCache *database.TenantCache
+	uc          *usecase.RecurringTransactionUsecase
+	interval    time.Duration
+}
+
+func NewRecurringHorizonJob(pool *pgxpool.Pool, tenantCache *database.TenantCache, uc *usecase.RecurringTransactionUsecase, interval time.Duration) *RecurringHorizonJob {
+	return &RecurringHorizonJob{pool: pool, tenantCache: tenantCache, uc: uc, interval: interval}
+}
+
+// Start runs the job once immediately and then on every interval until ctx is done.
+func (j *RecurringHorizonJob) Start(ctx context.Context) {
+	go func() {
+		j.RunOnce(ctx)
+
+		ticker := time.NewTicker(j.interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				j.RunOnce(ctx)
+			}
+		}
+	}()
+}
+
+// RunOnce extends the horizon for all cached tenants. Failures are logged per
+// tenant so one broken schema does not block the others.
+func (j *RecurringHorizonJob) RunOnce(ctx context.Context) {
+	for _, t := range j.tenantCache.All() {
+		if err := j.runTenant(ctx, t.SchemaName); err != nil {
+			log.Printf("Recurring horizon: tenant %s: %v", t.SchemaName, err)
+		}
+	}
+}
+
+func (j *RecurringHorizonJob) runTenant(ctx context.Context, schemaName string) error {
+	schemaCtx := tenant.ContextWithSchema(ctx, schemaName)
+	conn, release, err := database.AcquireWithSchema(schemaCtx, j.pool)
+	if err != nil {
+		return err
+	}
+	defer release()
+
+	// Several instances may run the job at once; the advisory lock keeps them
+	// from materializing the same occurrences twice.
+	var locked bool
+	if err := conn.QueryRow(ctx, `SELECT pg_try_advisory_lock(hashtext($1))`, "recurring_horizon:"+schemaName).Scan(&locked); err != nil {
+		return err
+	}
+	if !locked {
+		return nil
+	}
+	defer conn.Exec(context.Background(), `SELECT pg_advisory_unlock(hashtext($1))`, "recurring_horizon:"+schemaName)
+
+	schemaCtx = database.ContextWithConn(schemaCtx, conn)
+	extended, err := j.uc.ExtendHorizon(schemaCtx)
+	if err != nil {
+		return err
+	}
+	if extended > 0 {
+		log.Printf("Recurring horizon: tenant %s: extended %d templates", schemaName, extended)
+	}
+	return nil
+}
@@ -0,0 +1,20 @@
+// Package storage keeps uploaded files outside the database. Storage is the
+// object-store abstraction; LocalStorage writes to the filesystem, and a
+// bucket-backed implementation only needs to satisfy the same interface.
+package storage
+
+import (
+	"context"
+	"errors"
+	"io"
+)
+
+// ErrNotFound is returned when no object exists under a key.
+var ErrNotFound = errors.New("storage: object not found")
+
+// Storage stores opaque objects under slash-separated keys.
+type Storage interface {
+	Put(ctx context.Context, key string, r io.Reader) error
+	Open(ctx context.Context, key string) (io.ReadCloser, error)
+	Delete(ctx context.Context, key string) error
+}
@@ -0,0 +1,2 @@
+DROP TEXT SEARCH CONFIGURATION IF EXISTS public.portuguese_unaccent;
+DROP EXTENSION IF EXISTS unaccent;
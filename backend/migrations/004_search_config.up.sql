@@ -0,0 +1,18 @@
+-- Full-text search configuration shared by every tenant schema: Portuguese
+-- stemming over accent-stripped words, so "farmacia" finds "Farmácia".
+-- Tenant connections only see their own schema, so objects are referenced
+-- with the public prefix.
+CREATE EXTENSION IF NOT EXISTS unaccent SCHEMA public;
+
+DO $$
+BEGIN
+    IF NOT EXISTS (
+        SELECT 1 FROM pg_ts_config c JOIN pg_namespace n ON c.cfgnamespace = n.oid
+        WHERE n.nspname = 'public' AND c.cfgname = 'portuguese_unaccent'
+    ) THEN
+        CREATE TEXT SEARCH CONFIGURATION public.portuguese_unaccent (COPY = pg_catalog.portuguese);
+        ALTER TEXT SEARCH CONFIGURATION public.portuguese_unaccent
+            ALTER MAPPING FOR hword, hword_part, word WITH public.unaccent, portuguese_stem;
+    END IF;
+END
+$$;
@@ -0,0 +1,7 @@
+-- Single-use token emailed by the forgot-password flow, cleared once the
+-- password is reset.
+ALTER TABLE global_users ADD COLUMN password_reset_token VARCHAR(255);
+ALTER TABLE global_users ADD COLUMN password_reset_expires_at TIMESTAMPTZ;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_global_users_password_reset_token ON global_users(password_reset_token)
+    WHERE password_reset_token IS NOT NULL;
@@ -0,0 +1,20 @@
+-- One row per signed-in device. Access tokens carry the session id and are
+-- refused once the session is revoked. The refresh token is stored only as a
+-- SHA-256 hash and rotates on every use; the previous hash is kept so a
+-- replayed token can be recognised.
+CREATE TABLE sessions (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    global_user_id UUID NOT NULL REFERENCES global_users(id) ON DELETE CASCADE,
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    schema_user_id UUID NOT NULL,
+    refresh_token_hash VARCHAR(64) NOT NULL UNIQUE,
+    previous_token_hash VARCHAR(64),
+    expires_at TIMESTAMPTZ NOT NULL,
+    revoked_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    last_used_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_sessions_global_user ON sessions(global_user_id);
+CREATE INDEX idx_sessions_tenant_user ON sessions(tenant_id, schema_user_id);
+CREATE INDEX idx_sessions_previous_token ON sessions(previous_token_hash) WHERE previous_token_hash IS NOT NULL;
@@ -0,0 +1,5 @@
+ALTER TABLE tenants DROP COLUMN IF EXISTS require_two_factor;
+DROP TABLE IF EXISTS recovery_codes;
+ALTER TABLE global_users DROP COLUMN IF EXISTS totp_last_step;
+ALTER TABLE global_users DROP COLUMN IF EXISTS totp_enabled;
+ALTER TABLE global_users DROP COLUMN IF EXISTS totp_secret;
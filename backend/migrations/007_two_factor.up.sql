@@ -0,0 +1,19 @@
+-- Optional TOTP two-factor authentication. totp_secret is set when enrollment
+-- starts and totp_enabled once the first code is confirmed. totp_last_step is
+-- the time step of the last accepted code, so a code cannot be replayed.
+ALTER TABLE global_users ADD COLUMN totp_secret VARCHAR(64);
+ALTER TABLE global_users ADD COLUMN totp_enabled BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE global_users ADD COLUMN totp_last_step BIGINT NOT NULL DEFAULT 0;
+
+-- Single-use codes for when the authenticator is lost, stored as SHA-256 hashes.
+CREATE TABLE recovery_codes (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    global_user_id UUID NOT NULL REFERENCES global_users(id) ON DELETE CASCADE,
+    code_hash VARCHAR(64) NOT NULL,
+    used_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE(global_user_id, code_hash)
+);
+
+-- Set by the owner: every member must have two-factor authentication enabled.
+ALTER TABLE tenants ADD COLUMN require_two_factor BOOLEAN NOT NULL DEFAULT FALSE;
@@ -0,0 +1,11 @@
+-- Expiring counters for login throttling and lockout, shared by every
+-- instance when RATE_LIMIT_STORE=postgres. Keys look like "ip:login:203.0.113.7"
+-- or "lock:ana@example.com"; expired rows are reset on the next increment and
+-- swept periodically.
+CREATE TABLE rate_limits (
+    key TEXT PRIMARY KEY,
+    count INTEGER NOT NULL,
+    expires_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX idx_rate_limits_expires_at ON rate_limits(expires_at);
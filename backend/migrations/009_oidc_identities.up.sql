@@ -0,0 +1,12 @@
+-- Accounts at an OpenID Connect provider (Google) linked to a global user.
+-- The provider's subject identifies the account even if its email changes.
+CREATE TABLE oidc_identities (
+    issuer TEXT NOT NULL,
+    subject TEXT NOT NULL,
+    global_user_id UUID NOT NULL REFERENCES global_users(id) ON DELETE CASCADE,
+    email VARCHAR(255) NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (issuer, subject)
+);
+
+CREATE INDEX idx_oidc_identities_global_user ON oidc_identities(global_user_id);
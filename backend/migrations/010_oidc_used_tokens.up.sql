@@ -0,0 +1,9 @@
+-- Completion tokens of Google logins already exchanged, so each works once.
+-- A row only has to outlive its token; expired rows are dropped on the next
+-- insert.
+CREATE TABLE oidc_used_tokens (
+    jti UUID PRIMARY KEY,
+    expires_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX idx_oidc_used_tokens_expires_at ON oidc_used_tokens(expires_at);
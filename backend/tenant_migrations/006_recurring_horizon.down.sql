@@ -0,0 +1 @@
+ALTER TABLE recurring_transactions DROP COLUMN IF EXISTS materialized_until;
@@ -0,0 +1,10 @@
+-- Recurring templates are materialized only up to a rolling horizon
+ALTER TABLE recurring_transactions ADD COLUMN materialized_until DATE;
+
+-- Occurrences used to be stored eagerly, so the latest stored one is where
+-- each template's horizon currently ends. Nothing is deleted here: the
+-- horizon length is configurable, and the horizon job extends templates
+-- from this point at runtime. Templates without occurrences stay NULL and
+-- are materialized from their start date.
+UPDATE recurring_transactions rt
+SET materialized_until = (SELECT MAX(t.date) FROM transactions t WHERE t.recurring_id = rt.id);
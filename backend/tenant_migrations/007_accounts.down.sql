@@ -0,0 +1,4 @@
+ALTER TABLE recurring_transactions DROP COLUMN IF EXISTS account_id;
+DROP INDEX IF EXISTS idx_transactions_account_id;
+ALTER TABLE transactions DROP COLUMN IF EXISTS account_id;
+DROP TABLE IF EXISTS accounts;
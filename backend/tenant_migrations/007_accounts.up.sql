@@ -0,0 +1,25 @@
+-- Accounts (checking, savings, cash, ...)
+CREATE TABLE IF NOT EXISTS accounts (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID REFERENCES users(id) ON DELETE SET NULL,
+    name VARCHAR(100) NOT NULL UNIQUE,
+    type VARCHAR(20) NOT NULL CHECK (type IN ('checking', 'savings', 'cash', 'investment', 'other')),
+    initial_balance DECIMAL(12,2) NOT NULL DEFAULT 0,
+    is_default BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- Exactly one default account, used when a transaction does not name one
+CREATE UNIQUE INDEX IF NOT EXISTS idx_accounts_default ON accounts(is_default) WHERE is_default = true;
+
+INSERT INTO accounts (name, type, is_default) VALUES ('Conta principal', 'checking', true);
+
+ALTER TABLE transactions ADD COLUMN account_id UUID REFERENCES accounts(id);
+UPDATE transactions SET account_id = (SELECT id FROM accounts WHERE is_default = true);
+ALTER TABLE transactions ALTER COLUMN account_id SET NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_transactions_account_id ON transactions(account_id);
+
+ALTER TABLE recurring_transactions ADD COLUMN account_id UUID REFERENCES accounts(id);
+UPDATE recurring_transactions SET account_id = (SELECT id FROM accounts WHERE is_default = true);
+ALTER TABLE recurring_transactions ALTER COLUMN account_id SET NOT NULL;
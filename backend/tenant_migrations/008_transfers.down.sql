@@ -0,0 +1,10 @@
+DELETE FROM transactions WHERE type = 'transfer';
+
+DROP INDEX IF EXISTS idx_transactions_transfer;
+ALTER TABLE transactions DROP CONSTRAINT IF EXISTS transactions_transfer_check;
+ALTER TABLE transactions DROP COLUMN IF EXISTS transfer_direction;
+ALTER TABLE transactions DROP COLUMN IF EXISTS transfer_id;
+ALTER TABLE transactions ALTER COLUMN category_id SET NOT NULL;
+
+ALTER TABLE transactions DROP CONSTRAINT IF EXISTS transactions_type_check;
+ALTER TABLE transactions ADD CONSTRAINT transactions_type_check CHECK (type IN ('income', 'expense'));
@@ -0,0 +1,16 @@
+-- Transfers between accounts: two linked legs (out of the source account, into
+-- the destination) sharing a transfer_id. Legs have no category.
+ALTER TABLE transactions DROP CONSTRAINT IF EXISTS transactions_type_check;
+ALTER TABLE transactions ADD CONSTRAINT transactions_type_check CHECK (type IN ('income', 'expense', 'transfer'));
+
+ALTER TABLE transactions ALTER COLUMN category_id DROP NOT NULL;
+ALTER TABLE transactions ADD COLUMN transfer_id UUID;
+ALTER TABLE transactions ADD COLUMN transfer_direction VARCHAR(3) CHECK (transfer_direction IN ('in', 'out'));
+
+ALTER TABLE transactions ADD CONSTRAINT transactions_transfer_check CHECK (
+    (type = 'transfer' AND transfer_id IS NOT NULL AND transfer_direction IS NOT NULL)
+    OR (type <> 'transfer' AND transfer_id IS NULL AND transfer_direction IS NULL AND category_id IS NOT NULL)
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_transactions_transfer ON transactions(transfer_id, transfer_direction)
+    WHERE transfer_id IS NOT NULL;
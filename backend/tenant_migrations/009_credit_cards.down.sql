@@ -0,0 +1,9 @@
+DROP INDEX IF EXISTS idx_transactions_statement_id;
+ALTER TABLE transactions DROP COLUMN IF EXISTS statement_id;
+DROP TABLE IF EXISTS credit_card_statements;
+DROP TABLE IF EXISTS credit_cards;
+
+UPDATE accounts SET type = 'other' WHERE type = 'credit_card';
+ALTER TABLE accounts DROP CONSTRAINT IF EXISTS accounts_type_check;
+ALTER TABLE accounts ADD CONSTRAINT accounts_type_check
+    CHECK (type IN ('checking', 'savings', 'cash', 'investment', 'other'));
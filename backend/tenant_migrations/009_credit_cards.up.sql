@@ -0,0 +1,34 @@
+-- Credit cards: each card is backed by an account of type 'credit_card' whose
+-- balance is what is owed. Purchases are expenses on that account and payments
+-- are transfers into it.
+ALTER TABLE accounts DROP CONSTRAINT IF EXISTS accounts_type_check;
+ALTER TABLE accounts ADD CONSTRAINT accounts_type_check
+    CHECK (type IN ('checking', 'savings', 'cash', 'investment', 'credit_card', 'other'));
+
+CREATE TABLE IF NOT EXISTS credit_cards (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    account_id UUID NOT NULL UNIQUE REFERENCES accounts(id) ON DELETE CASCADE,
+    closing_day INT NOT NULL CHECK (closing_day BETWEEN 1 AND 31),
+    due_day INT NOT NULL CHECK (due_day BETWEEN 1 AND 31),
+    credit_limit DECIMAL(12,2) CHECK (credit_limit > 0),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- Statements (faturas), identified by the month of their due date
+CREATE TABLE IF NOT EXISTS credit_card_statements (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    credit_card_id UUID NOT NULL REFERENCES credit_cards(id) ON DELETE CASCADE,
+    month INT NOT NULL CHECK (month BETWEEN 1 AND 12),
+    year INT NOT NULL CHECK (year > 0),
+    closing_date DATE NOT NULL,
+    due_date DATE NOT NULL,
+    paid_amount DECIMAL(12,2) NOT NULL DEFAULT 0,
+    paid_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (credit_card_id, year, month)
+);
+
+ALTER TABLE transactions ADD COLUMN statement_id UUID REFERENCES credit_card_statements(id) ON DELETE SET NULL;
+CREATE INDEX IF NOT EXISTS idx_transactions_statement_id ON transactions(statement_id);
@@ -0,0 +1,5 @@
+-- Identifier of an imported transaction in its source file (OFX FITID)
+ALTER TABLE transactions ADD COLUMN external_id VARCHAR(255);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_transactions_external_id ON transactions(account_id, external_id)
+    WHERE external_id IS NOT NULL;
@@ -0,0 +1,22 @@
+-- Column mappings for CSV statement imports. Columns are referenced by header
+-- name, or by 1-based position when the file has no header row.
+CREATE TABLE IF NOT EXISTS import_profiles (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID REFERENCES users(id) ON DELETE SET NULL,
+    name VARCHAR(100) NOT NULL UNIQUE,
+    delimiter VARCHAR(1) NOT NULL DEFAULT ';',
+    has_header BOOLEAN NOT NULL DEFAULT true,
+    skip_rows INT NOT NULL DEFAULT 0 CHECK (skip_rows >= 0),
+    date_column VARCHAR(100) NOT NULL,
+    description_column VARCHAR(100) NOT NULL,
+    amount_column VARCHAR(100) NOT NULL,
+    external_id_column VARCHAR(100),
+    date_format VARCHAR(10) NOT NULL DEFAULT 'dd/mm/yyyy'
+        CHECK (date_format IN ('dd/mm/yyyy', 'mm/dd/yyyy', 'yyyy-mm-dd', 'dd-mm-yyyy', 'dd.mm.yyyy')),
+    decimal_separator VARCHAR(1) NOT NULL DEFAULT ',' CHECK (decimal_separator IN (',', '.')),
+    negate_amounts BOOLEAN NOT NULL DEFAULT false,
+    default_income_category_id UUID REFERENCES categories(id) ON DELETE SET NULL,
+    default_expense_category_id UUID REFERENCES categories(id) ON DELETE SET NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_categorization_rules_priority;
+DROP TABLE IF EXISTS categorization_rules;
@@ -0,0 +1,20 @@
+-- Rules that pick a category for new and imported transactions. Rules are
+-- evaluated by ascending priority and the first match wins.
+CREATE TABLE IF NOT EXISTS categorization_rules (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID REFERENCES users(id) ON DELETE SET NULL,
+    name VARCHAR(100) NOT NULL,
+    priority INT NOT NULL DEFAULT 0,
+    description_contains VARCHAR(255),
+    type VARCHAR(10) CHECK (type IN ('income', 'expense')),
+    min_amount DECIMAL(12,2),
+    max_amount DECIMAL(12,2),
+    account_id UUID REFERENCES accounts(id) ON DELETE CASCADE,
+    category_id UUID NOT NULL REFERENCES categories(id) ON DELETE CASCADE,
+    is_active BOOLEAN NOT NULL DEFAULT true,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CHECK (min_amount IS NULL OR max_amount IS NULL OR min_amount <= max_amount)
+);
+
+CREATE INDEX IF NOT EXISTS idx_categorization_rules_priority ON categorization_rules(priority, created_at);
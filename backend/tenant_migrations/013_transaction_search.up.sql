@@ -0,0 +1,5 @@
+-- Full-text search over descriptions (config from public migration 004)
+ALTER TABLE transactions ADD COLUMN search_vector tsvector
+    GENERATED ALWAYS AS (to_tsvector('public.portuguese_unaccent', COALESCE(description, ''))) STORED;
+
+CREATE INDEX IF NOT EXISTS idx_transactions_search ON transactions USING GIN (search_vector);
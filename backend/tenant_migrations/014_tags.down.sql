@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_recurring_transaction_tags_tag;
+DROP INDEX IF EXISTS idx_transaction_tags_tag;
+DROP TABLE IF EXISTS recurring_transaction_tags;
+DROP TABLE IF EXISTS transaction_tags;
+DROP TABLE IF EXISTS tags;
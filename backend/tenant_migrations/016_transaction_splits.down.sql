@@ -0,0 +1,4 @@
+DROP VIEW IF EXISTS transaction_lines;
+DROP INDEX IF EXISTS idx_transaction_splits_category;
+DROP INDEX IF EXISTS idx_transaction_splits_transaction;
+DROP TABLE IF EXISTS transaction_splits;
@@ -0,0 +1,22 @@
+-- Split transactions: the parent keeps the total and the category of its
+-- largest line; each line carries its own category and amount.
+CREATE TABLE IF NOT EXISTS transaction_splits (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    transaction_id UUID NOT NULL REFERENCES transactions(id) ON DELETE CASCADE,
+    category_id UUID NOT NULL REFERENCES categories(id),
+    amount DECIMAL(12,2) NOT NULL CHECK (amount > 0),
+    description VARCHAR(255),
+    position INT NOT NULL DEFAULT 0
+);
+
+CREATE INDEX IF NOT EXISTS idx_transaction_splits_transaction ON transaction_splits(transaction_id);
+CREATE INDEX IF NOT EXISTS idx_transaction_splits_category ON transaction_splits(category_id);
+
+-- One row per category line: the split lines of split transactions and the
+-- transaction itself otherwise. Totals per category read from here.
+CREATE OR REPLACE VIEW transaction_lines AS
+SELECT t.id AS transaction_id, t.user_id, t.account_id, t.type, t.date, t.recurring_id,
+       COALESCE(s.category_id, t.category_id) AS category_id,
+       COALESCE(s.amount, t.amount) AS amount
+FROM transactions t
+LEFT JOIN transaction_splits s ON s.transaction_id = t.id;
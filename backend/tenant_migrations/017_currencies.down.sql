@@ -0,0 +1,15 @@
+DROP VIEW IF EXISTS transaction_lines;
+DROP FUNCTION IF EXISTS base_amount(NUMERIC, CHAR(3), DATE);
+DROP FUNCTION IF EXISTS convert_amount(NUMERIC, CHAR(3), CHAR(3), DATE);
+DROP FUNCTION IF EXISTS exchange_rate(CHAR(3), CHAR(3), DATE);
+DROP TABLE IF EXISTS exchange_rates;
+ALTER TABLE transactions DROP COLUMN IF EXISTS currency;
+ALTER TABLE accounts DROP COLUMN IF EXISTS currency;
+DROP TABLE IF EXISTS settings;
+
+CREATE OR REPLACE VIEW transaction_lines AS
+SELECT t.id AS transaction_id, t.user_id, t.account_id, t.type, t.date, t.recurring_id,
+       COALESCE(s.category_id, t.category_id) AS category_id,
+       COALESCE(s.amount, t.amount) AS amount
+FROM transactions t
+LEFT JOIN transaction_splits s ON s.transaction_id = t.id;
@@ -0,0 +1,88 @@
+-- Tenant-wide settings, a single row. Dashboard totals are reported in the
+-- base currency.
+CREATE TABLE IF NOT EXISTS settings (
+    id BOOLEAN PRIMARY KEY DEFAULT TRUE CHECK (id),
+    base_currency CHAR(3) NOT NULL DEFAULT 'BRL' CHECK (base_currency ~ '^[A-Z]{3}$'),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+INSERT INTO settings DEFAULT VALUES ON CONFLICT DO NOTHING;
+
+-- ISO 4217 codes. Accounts hold balances in their currency; a transaction is
+-- in its account's currency unless stated otherwise.
+ALTER TABLE accounts ADD COLUMN currency CHAR(3) NOT NULL DEFAULT 'BRL' CHECK (currency ~ '^[A-Z]{3}$');
+
+ALTER TABLE transactions ADD COLUMN currency CHAR(3) NOT NULL DEFAULT 'BRL' CHECK (currency ~ '^[A-Z]{3}$');
+ALTER TABLE transactions ALTER COLUMN currency DROP DEFAULT;
+
+-- One unit of from_currency is worth rate units of to_currency on date.
+-- Rates are entered by hand (source 'manual') or fetched from a provider.
+CREATE TABLE IF NOT EXISTS exchange_rates (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    from_currency CHAR(3) NOT NULL CHECK (from_currency ~ '^[A-Z]{3}$'),
+    to_currency CHAR(3) NOT NULL CHECK (to_currency ~ '^[A-Z]{3}$'),
+    date DATE NOT NULL,
+    rate DECIMAL(18,8) NOT NULL CHECK (rate > 0),
+    source VARCHAR(20) NOT NULL DEFAULT 'manual',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (from_currency, to_currency, date),
+    CHECK (from_currency <> to_currency)
+);
+
+-- exchange_rate returns what one unit of from_code is worth in to_code on the
+-- given date: the latest rate on or before it, or the earliest one after it
+-- when there is no older rate. A pair may be stored in either direction. NULL
+-- when the pair has no rate at all.
+CREATE OR REPLACE FUNCTION exchange_rate(from_code CHAR(3), to_code CHAR(3), on_date DATE)
+RETURNS NUMERIC
+LANGUAGE sql STABLE AS $$
+    SELECT CASE WHEN from_code = to_code THEN 1 ELSE (
+        SELECT r.rate
+        FROM (
+            SELECT er.rate, er.date FROM exchange_rates er
+            WHERE er.from_currency = from_code AND er.to_currency = to_code
+            UNION ALL
+            SELECT 1 / er.rate, er.date FROM exchange_rates er
+            WHERE er.from_currency = to_code AND er.to_currency = from_code
+        ) r
+        ORDER BY r.date > on_date, abs(r.date - on_date)
+        LIMIT 1
+    ) END
+$$;
+
+-- convert_amount converts between two currencies. A pair without any rate
+-- raises FX404 instead of yielding NULL, which SUM would skip: totals and
+-- account balances must never leave a transaction out silently.
+CREATE OR REPLACE FUNCTION convert_amount(amount NUMERIC, from_code CHAR(3), to_code CHAR(3), on_date DATE)
+RETURNS NUMERIC
+LANGUAGE plpgsql STABLE AS $$
+DECLARE
+    rate NUMERIC;
+BEGIN
+    IF amount IS NULL THEN
+        RETURN NULL;
+    END IF;
+    rate := exchange_rate(from_code, to_code, on_date);
+    IF rate IS NULL THEN
+        RAISE EXCEPTION 'no exchange rate from % to %', from_code, to_code
+            USING ERRCODE = 'FX404', DETAIL = from_code || ' to ' || to_code;
+    END IF;
+    RETURN ROUND(amount * rate, 2);
+END
+$$;
+
+-- base_amount converts into the tenant's base currency.
+CREATE OR REPLACE FUNCTION base_amount(amount NUMERIC, from_code CHAR(3), on_date DATE)
+RETURNS NUMERIC
+LANGUAGE sql STABLE AS $$
+    SELECT convert_amount(amount, from_code, (SELECT base_currency FROM settings), on_date)
+$$;
+
+CREATE OR REPLACE VIEW transaction_lines AS
+SELECT t.id AS transaction_id, t.user_id, t.account_id, t.type, t.date, t.recurring_id,
+       COALESCE(s.category_id, t.category_id) AS category_id,
+       COALESCE(s.amount, t.amount) AS amount,
+       t.currency
+FROM transactions t
+LEFT JOIN transaction_splits s ON s.transaction_id = t.id;
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_goal_contributions_goal;
+DROP TABLE IF EXISTS goal_contributions;
+DROP TABLE IF EXISTS goals;
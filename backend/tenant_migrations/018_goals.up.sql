@@ -0,0 +1,20 @@
+-- Savings goals: an amount, in the base currency, to reach by a deadline.
+CREATE TABLE IF NOT EXISTS goals (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID REFERENCES users(id) ON DELETE SET NULL,
+    name VARCHAR(100) NOT NULL UNIQUE,
+    target_amount DECIMAL(12,2) NOT NULL CHECK (target_amount > 0),
+    deadline DATE NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- A contribution links a stored transaction to a goal; a transfer is linked
+-- through its incoming leg. A transaction counts towards one goal at most.
+CREATE TABLE IF NOT EXISTS goal_contributions (
+    transaction_id UUID PRIMARY KEY REFERENCES transactions(id) ON DELETE CASCADE,
+    goal_id UUID NOT NULL REFERENCES goals(id) ON DELETE CASCADE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_goal_contributions_goal ON goal_contributions(goal_id);
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_budgets_global;
+DROP INDEX IF EXISTS idx_budgets_cat;
+DROP TABLE IF EXISTS budgets;
@@ -0,0 +1,28 @@
+-- Budget templates that repeat every period without being copied. A budget
+-- has either a fixed amount or a percentage of the period's income (from one
+-- income category, or all income when it is NULL). With rollover, what is
+-- left unspent in a period is added to the next one.
+CREATE TABLE IF NOT EXISTS budgets (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    category_id UUID REFERENCES categories(id) ON DELETE CASCADE,
+    period VARCHAR(10) NOT NULL CHECK (period IN ('weekly', 'monthly', 'quarterly', 'yearly')),
+    amount DECIMAL(12,2) CHECK (amount > 0),
+    income_percentage DECIMAL(5,2) CHECK (income_percentage > 0 AND income_percentage <= 100),
+    income_category_id UUID REFERENCES categories(id) ON DELETE CASCADE,
+    rollover BOOLEAN NOT NULL DEFAULT false,
+    start_date DATE NOT NULL,
+    end_date DATE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CHECK ((amount IS NULL) <> (income_percentage IS NULL)),
+    CHECK (income_category_id IS NULL OR income_percentage IS NOT NULL),
+    CHECK (end_date IS NULL OR end_date >= start_date)
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_budgets_cat
+    ON budgets(category_id, period);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_budgets_global
+    ON budgets(period)
+    WHERE category_id IS NULL;
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_limit_alerts_budget;
+DROP INDEX IF EXISTS idx_limit_alerts_limit;
+DROP TABLE IF EXISTS limit_alerts;
+ALTER TABLE budgets DROP COLUMN IF EXISTS alert_thresholds;
+ALTER TABLE expense_limits DROP COLUMN IF EXISTS alert_thresholds;
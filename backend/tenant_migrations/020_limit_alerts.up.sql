@@ -0,0 +1,24 @@
+-- Percentages of an expense limit or budget at which an email alert is sent,
+-- e.g. {80,100}. Empty means no alerts.
+ALTER TABLE expense_limits ADD COLUMN alert_thresholds INTEGER[] NOT NULL DEFAULT '{}';
+ALTER TABLE budgets ADD COLUMN alert_thresholds INTEGER[] NOT NULL DEFAULT '{}';
+
+-- Alerts already sent, so each threshold alerts once per period. Expense
+-- limits are monthly, so their period is the limit's month.
+CREATE TABLE IF NOT EXISTS limit_alerts (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    expense_limit_id UUID REFERENCES expense_limits(id) ON DELETE CASCADE,
+    budget_id UUID REFERENCES budgets(id) ON DELETE CASCADE,
+    period_start DATE NOT NULL,
+    threshold INTEGER NOT NULL,
+    sent_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CHECK ((expense_limit_id IS NULL) <> (budget_id IS NULL))
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_limit_alerts_limit
+    ON limit_alerts(expense_limit_id, period_start, threshold)
+    WHERE expense_limit_id IS NOT NULL;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_limit_alerts_budget
+    ON limit_alerts(budget_id, period_start, threshold)
+    WHERE budget_id IS NOT NULL;
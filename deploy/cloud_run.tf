@@ -90,6 +90,26 @@ resource "google_cloud_run_v2_service" "finance" {
         value = var.email_from
       }
 
+      env {
+        name  = "OIDC_CLIENT_ID"
+        value = var.google_client_id
+      }
+
+      env {
+        name = "OIDC_CLIENT_SECRET"
+        value_source {
+          secret_key_ref {
+            secret  = google_secret_manager_secret.google_client_secret.secret_id
+            version = "latest"
+          }
+        }
+      }
+
+      env {
+        name  = "RATE_LIMIT_STORE"
+        value = "postgres"
+      }
+
       env {
         name  = "GIN_MODE"
         value = "release"
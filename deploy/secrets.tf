@@ -59,3 +59,22 @@ resource "google_secret_manager_secret_iam_member" "sendgrid_api_key_access" {
   role      = "roles/secretmanager.secretAccessor"
   member    = "serviceAccount:${google_service_account.cloud_run.email}"
 }
+
+resource "google_secret_manager_secret" "google_client_secret" {
+  secret_id = "finance-google-client-secret"
+  replication {
+    auto {}
+  }
+  depends_on = [google_project_service.apis]
+}
+
+resource "google_secret_manager_secret_version" "google_client_secret" {
+  secret      = google_secret_manager_secret.google_client_secret.id
+  secret_data = var.google_client_secret
+}
+
+resource "google_secret_manager_secret_iam_member" "google_client_secret_access" {
+  secret_id = google_secret_manager_secret.google_client_secret.id
+  role      = "roles/secretmanager.secretAccessor"
+  member    = "serviceAccount:${google_service_account.cloud_run.email}"
+}
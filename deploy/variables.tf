@@ -60,3 +60,16 @@ variable "email_from" {
   default     = "noreply@dnafami.com.br"
   description = "Email sender address"
 }
+
+variable "google_client_id" {
+  type        = string
+  default     = ""
+  description = "Google OAuth client ID for \"Entrar com Google\" (empty = disabled)"
+}
+
+variable "google_client_secret" {
+  type        = string
+  sensitive   = true
+  default     = ""
+  description = "Google OAuth client secret"
+}
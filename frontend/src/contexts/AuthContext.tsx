@@ -1,11 +1,12 @@
 import { createContext, useContext, useState, type ReactNode } from 'react';
 import { useQueryClient } from '@tanstack/react-query';
+import { authService } from '../services/auth';
 import type { User } from '../types';
 
 interface AuthContextType {
   user: User | null;
   token: string | null;
-  login: (token: string, user: User) => void;
+  login: (token: string, refreshToken: string, user: User) => void;
   logout: () => void;
   updateUser: (user: User) => void;
   isAuthenticated: boolean;
@@ -23,15 +24,20 @@ export function AuthProvider({ children }: { children: ReactNode }) {
     localStorage.getItem('token')
   );
 
-  const login = (token: string, user: User) => {
+  const login = (token: string, refreshToken: string, user: User) => {
     localStorage.setItem('token', token);
+    localStorage.setItem('refreshToken', refreshToken);
     localStorage.setItem('user', JSON.stringify(user));
     setToken(token);
     setUser(user);
   };
 
   const logout = () => {
+    // Revoke the session server-side; the local state is cleared regardless
+    const current = localStorage.getItem('token');
+    if (current) authService.logout(current).catch(() => {});
     localStorage.removeItem('token');
+    localStorage.removeItem('refreshToken');
     localStorage.removeItem('user');
     queryClient.clear();
     setToken(null);
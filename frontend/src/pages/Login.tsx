@@ -1,9 +1,17 @@
-import { useState } from 'react';
+import { useEffect, useRef, useState } from 'react';
 import { Link, useNavigate } from 'react-router-dom';
 import { AxiosError } from 'axios';
 import { useAuth } from '../contexts/AuthContext';
 import { authService } from '../services/auth';
-import type { TenantInfo } from '../types';
+import type { LoginResponse, TenantInfo, TwoFactorSetup } from '../types';
+
+// Reasons the OIDC callback sends back in #oidc_error
+const oidcErrors: Record<string, string> = {
+  denied: 'Login com Google cancelado',
+  expired: 'O login com Google expirou, tente novamente',
+  email_not_verified: 'O email da conta Google não está verificado',
+  unavailable: 'Login com Google indisponível no momento',
+};
 
 export default function Login() {
   const [email, setEmail] = useState('');
@@ -15,31 +23,122 @@ export default function Login() {
   const [selectorToken, setSelectorToken] = useState<string | null>(null);
   const [tenants, setTenants] = useState<TenantInfo[]>([]);
 
+  // Two-factor step
+  const [twoFactorToken, setTwoFactorToken] = useState<string | null>(null);
+  const [twoFactorStep, setTwoFactorStep] = useState<'verify' | 'setup' | null>(null);
+  const [setup, setSetup] = useState<TwoFactorSetup | null>(null);
+  const [code, setCode] = useState('');
+  const [recovery, setRecovery] = useState<LoginResponse | null>(null);
+
+  // Sign-in with Google
+  const [oidcEnabled, setOidcEnabled] = useState(false);
+  const oidcHandled = useRef(false);
+
   const { login } = useAuth();
   const navigate = useNavigate();
 
+  const resetToLogin = () => {
+    setSelectorToken(null);
+    setTenants([]);
+    setTwoFactorToken(null);
+    setTwoFactorStep(null);
+    setSetup(null);
+    setCode('');
+    setRecovery(null);
+  };
+
+  const handleResult = async (data: LoginResponse) => {
+    // Two-factor: ask for a code, enrolling first if a tenant requires it
+    if (data.two_factor_token && data.two_factor) {
+      setTwoFactorToken(data.two_factor_token);
+      setTwoFactorStep(data.two_factor);
+      if (data.two_factor === 'setup') {
+        const { data: s } = await authService.setupTwoFactor(data.two_factor_token);
+        setSetup(s);
+      }
+      return;
+    }
+
+    // Single tenant: auto-selected
+    if (data.token && data.refresh_token && data.user) {
+      login(data.token, data.refresh_token, data.user);
+      navigate('/');
+      return;
+    }
+
+    // Multi-tenant: show selector
+    if (data.selector_token && data.tenants) {
+      setTwoFactorToken(null);
+      setTwoFactorStep(null);
+      setSelectorToken(data.selector_token);
+      setTenants(data.tenants);
+    }
+  };
+
+  useEffect(() => {
+    authService
+      .getOIDCConfig()
+      .then(({ data }) => setOidcEnabled(data.enabled))
+      .catch(() => setOidcEnabled(false));
+
+    // Back from the provider: the callback puts the result in the fragment
+    if (oidcHandled.current) return;
+    oidcHandled.current = true;
+    const params = new URLSearchParams(window.location.hash.slice(1));
+    const oidcToken = params.get('oidc_token');
+    const oidcError = params.get('oidc_error');
+    if (!oidcToken && !oidcError) return;
+    window.history.replaceState(null, '', window.location.pathname);
+
+    if (oidcError) {
+      setError(oidcErrors[oidcError] || 'Erro ao entrar com Google');
+      return;
+    }
+    setLoading(true);
+    authService
+      .completeOIDC(oidcToken!)
+      .then(({ data }) => handleResult(data))
+      .catch((err: unknown) => {
+        const axiosErr = err as AxiosError<{ error: string }>;
+        setError(axiosErr.response?.data?.error || 'Erro ao entrar com Google');
+      })
+      .finally(() => setLoading(false));
+    // eslint-disable-next-line react-hooks/exhaustive-deps
+  }, []);
+
   const handleLogin = async (e: React.FormEvent) => {
     e.preventDefault();
     setLoading(true);
     setError('');
     try {
       const { data } = await authService.login(email, password);
+      await handleResult(data);
+    } catch (err: unknown) {
+      const axiosErr = err as AxiosError<{ error: string }>;
+      setError(axiosErr.response?.data?.error || 'Erro ao fazer login');
+    } finally {
+      setLoading(false);
+    }
+  };
 
-      // Single tenant: auto-selected
-      if (data.token && data.user) {
-        login(data.token, data.user);
-        navigate('/');
-        return;
-      }
-
-      // Multi-tenant: show selector
-      if (data.selector_token && data.tenants) {
-        setSelectorToken(data.selector_token);
-        setTenants(data.tenants);
+  const handleTwoFactor = async (e: React.FormEvent) => {
+    e.preventDefault();
+    if (!twoFactorToken) return;
+    setLoading(true);
+    setError('');
+    try {
+      if (twoFactorStep === 'setup') {
+        const { data } = await authService.enableTwoFactor(twoFactorToken, code);
+        // Show the recovery codes before going on
+        setRecovery(data);
+      } else {
+        const { data } = await authService.verifyTwoFactor(twoFactorToken, code);
+        await handleResult(data);
       }
     } catch (err: unknown) {
       const axiosErr = err as AxiosError<{ error: string }>;
-      setError(axiosErr.response?.data?.error || 'Erro ao fazer login');
+      setError(axiosErr.response?.data?.error || 'Erro ao verificar código');
+      setCode('');
     } finally {
       setLoading(false);
     }
@@ -51,7 +150,7 @@ export default function Login() {
     setError('');
     try {
       const { data } = await authService.selectTenant(selectorToken, tenantId);
-      login(data.token, data.user);
+      login(data.token, data.refresh_token, data.user);
       navigate('/');
     } catch (err: unknown) {
       const axiosErr = err as AxiosError<{ error: string }>;
@@ -72,6 +171,89 @@ export default function Login() {
     user: 'Usuário',
   };
 
+  // Recovery codes, shown once after enrolling during login
+  if (recovery) {
+    return (
+      <div className="min-h-screen bg-gray-50 flex items-center justify-center p-4">
+        <div className="bg-white rounded-xl shadow-lg p-8 w-full max-w-md">
+          <h1 className="text-xl font-bold text-gray-900 mb-2">Códigos de recuperação</h1>
+          <p className="text-gray-500 mb-4">
+            Guarde estes códigos em um lugar seguro. Cada um pode ser usado uma vez no lugar do código do
+            aplicativo, caso você perca acesso a ele.
+          </p>
+          <ul className="grid grid-cols-2 gap-2 font-mono text-sm bg-gray-50 rounded-lg p-4 mb-6">
+            {recovery.recovery_codes?.map((c) => <li key={c}>{c}</li>)}
+          </ul>
+          <button
+            onClick={() => { const data = recovery; setRecovery(null); handleResult({ ...data, recovery_codes: undefined }); }}
+            className="w-full bg-blue-600 text-white rounded-lg py-2 font-medium hover:bg-blue-700 transition-colors"
+          >
+            Continuar
+          </button>
+        </div>
+      </div>
+    );
+  }
+
+  // Two-factor view
+  if (twoFactorToken && twoFactorStep) {
+    return (
+      <div className="min-h-screen bg-gray-50 flex items-center justify-center p-4">
+        <div className="bg-white rounded-xl shadow-lg p-8 w-full max-w-md">
+          <div className="flex items-center justify-center gap-2 mb-2">
+            <img src="/assets/logo.svg" alt="DNA Fami" className="h-8 w-8" />
+            <h1 className="text-2xl font-bold text-gray-900">DNA Fami</h1>
+          </div>
+          {twoFactorStep === 'setup' ? (
+            <div className="text-gray-500 mb-6 space-y-2">
+              <p>Um dos seus dashboards exige autenticação em dois fatores. Adicione sua conta a um aplicativo autenticador e digite o código gerado.</p>
+              {setup && (
+                <>
+                  <a href={setup.provisioning_uri} className="block text-blue-600 hover:text-blue-700 text-sm">
+                    Abrir no aplicativo autenticador
+                  </a>
+                  <p className="text-sm">Ou digite a chave: <span className="font-mono break-all">{setup.secret}</span></p>
+                </>
+              )}
+            </div>
+          ) : (
+            <p className="text-gray-500 mb-6">Digite o código do seu aplicativo autenticador ou um código de recuperação</p>
+          )}
+          <form onSubmit={handleTwoFactor} className="space-y-4">
+            <input
+              type="text"
+              inputMode="numeric"
+              autoComplete="one-time-code"
+              value={code}
+              onChange={(e) => setCode(e.target.value)}
+              required
+              autoFocus
+              className="w-full rounded-lg border border-gray-300 px-3 py-2 text-center tracking-widest focus:outline-none focus:ring-2 focus:ring-blue-500 focus:border-transparent"
+            />
+            <button
+              type="submit"
+              disabled={loading}
+              className="w-full bg-blue-600 text-white rounded-lg py-2 font-medium hover:bg-blue-700 disabled:opacity-50 transition-colors"
+            >
+              {loading ? 'Verificando...' : 'Verificar'}
+            </button>
+          </form>
+
+          <button
+            onClick={() => { resetToLogin(); setError(''); }}
+            className="mt-4 w-full text-sm text-gray-500 hover:text-gray-700"
+          >
+            Voltar ao login
+          </button>
+
+          {error && (
+            <p className="text-sm text-red-600 text-center mt-2">{error}</p>
+          )}
+        </div>
+      </div>
+    );
+  }
+
   // Tenant selector view
   if (selectorToken && tenants.length > 0) {
     return (
@@ -158,6 +340,21 @@ export default function Login() {
             <p className="text-sm text-red-600 text-center mt-2">{error}</p>
           )}
         </form>
+        {oidcEnabled && (
+          <>
+            <div className="flex items-center gap-3 my-4">
+              <div className="flex-1 border-t border-gray-200" />
+              <span className="text-xs text-gray-400">ou</span>
+              <div className="flex-1 border-t border-gray-200" />
+            </div>
+            <a
+              href="/api/v1/auth/oidc/login"
+              className="block w-full text-center border border-gray-300 text-gray-700 rounded-lg py-2 font-medium hover:bg-gray-50 transition-colors"
+            >
+              Entrar com Google
+            </a>
+          </>
+        )}
         <p className="text-sm text-gray-500 text-center mt-6">
           Não tem conta?{' '}
           <Link to="/register" className="text-blue-600 hover:text-blue-700 font-medium">
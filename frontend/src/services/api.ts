@@ -1,4 +1,4 @@
-import axios from 'axios';
+import axios, { type InternalAxiosRequestConfig } from 'axios';
 
 const api = axios.create({
   baseURL: '/api/v1',
@@ -12,15 +12,56 @@ api.interceptors.request.use((config) => {
   return config;
 });
 
+// Access tokens are short-lived; a single refresh is shared by every request
+// that fails while it is in flight, since each refresh token works only once.
+let refreshing: Promise<string> | null = null;
+
+function refreshAccessToken(): Promise<string> {
+  if (!refreshing) {
+    const refreshToken = localStorage.getItem('refreshToken');
+    refreshing = (refreshToken
+      ? axios.post<{ token: string; refresh_token: string }>('/api/v1/auth/refresh', {
+          refresh_token: refreshToken,
+        })
+      : Promise.reject(new Error('no refresh token'))
+    )
+      .then(({ data }) => {
+        localStorage.setItem('token', data.token);
+        localStorage.setItem('refreshToken', data.refresh_token);
+        return data.token;
+      })
+      .finally(() => {
+        refreshing = null;
+      });
+  }
+  return refreshing;
+}
+
+function clearSession() {
+  localStorage.removeItem('token');
+  localStorage.removeItem('refreshToken');
+  localStorage.removeItem('user');
+  window.location.href = '/login';
+}
+
 api.interceptors.response.use(
   (response) => response,
-  (error) => {
-    if (error.response?.status === 401) {
-      const isAuthRequest = error.config?.url?.includes('/auth/');
+  async (error) => {
+    const config = error.config as (InternalAxiosRequestConfig & { _retried?: boolean }) | undefined;
+    if (error.response?.status === 401 && config) {
+      const isAuthRequest = config.url?.includes('/auth/');
       if (!isAuthRequest) {
-        localStorage.removeItem('token');
-        localStorage.removeItem('user');
-        window.location.href = '/login';
+        if (!config._retried) {
+          config._retried = true;
+          try {
+            const token = await refreshAccessToken();
+            config.headers.Authorization = `Bearer ${token}`;
+            return api(config);
+          } catch {
+            // fall through to a new login
+          }
+        }
+        clearSession();
       }
     }
     return Promise.reject(error);
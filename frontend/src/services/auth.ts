@@ -1,16 +1,36 @@
 import api from './api';
-import type { LoginResponse, SelectTenantResponse, User, InviteInfo } from '../types';
+import type { LoginResponse, SelectTenantResponse, User, InviteInfo, TwoFactorSetup } from '../types';
 
 export const authService = {
   login: (email: string, password: string) =>
     api.post<LoginResponse>('/auth/login', { email, password }),
 
+  verifyTwoFactor: (twoFactorToken: string, code: string) =>
+    api.post<LoginResponse>('/auth/2fa/verify', { two_factor_token: twoFactorToken, code }),
+
+  setupTwoFactor: (twoFactorToken: string) =>
+    api.post<TwoFactorSetup>('/auth/2fa/setup', { two_factor_token: twoFactorToken }),
+
+  enableTwoFactor: (twoFactorToken: string, code: string) =>
+    api.post<LoginResponse>('/auth/2fa/enable', { two_factor_token: twoFactorToken, code }),
+
+  getOIDCConfig: () => api.get<{ enabled: boolean }>('/auth/oidc/config'),
+
+  completeOIDC: (oidcToken: string) =>
+    api.post<LoginResponse>('/auth/oidc/complete', { oidc_token: oidcToken }),
+
   selectTenant: (selectorToken: string, tenantId: string) =>
     api.post<SelectTenantResponse>('/auth/select-tenant', {
       selector_token: selectorToken,
       tenant_id: tenantId,
     }),
 
+  // The token is passed explicitly because local storage is cleared right away.
+  logout: (token: string) =>
+    api.post('/auth/logout', null, { headers: { Authorization: `Bearer ${token}` } }),
+
+  logoutAll: () => api.post('/auth/logout-all'),
+
   register: (data: { name: string; email: string; password: string; tenant_name: string }) =>
     api.post<{ message: string }>('/auth/register', data),
 
@@ -12,21 +12,34 @@ export interface TenantInfo {
   tenant_id: string;
   tenant_name: string;
   role: string;
+  require_two_factor: boolean;
 }
 
 export interface LoginResponse {
   // Single tenant (auto-select)
   token?: string;
+  refresh_token?: string;
   user?: User;
   tenant_id?: string;
 
   // Multi-tenant (selector)
   selector_token?: string;
   tenants?: TenantInfo[];
+
+  // Two-factor step: verify a code, or set up first when a tenant requires it
+  two_factor_token?: string;
+  two_factor?: 'verify' | 'setup';
+  recovery_codes?: string[];
+}
+
+export interface TwoFactorSetup {
+  secret: string;
+  provisioning_uri: string;
 }
 
 export interface SelectTenantResponse {
   token: string;
+  refresh_token: string;
   user: User;
 }
 